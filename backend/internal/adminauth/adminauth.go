@@ -0,0 +1,75 @@
+package adminauth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	connect "connectrpc.com/connect"
+)
+
+// 운영용 RPC 호출 시 token을 보내는 헤더 (Authorization: Bearer <token>)
+const headerAuthorization = "Authorization"
+
+var (
+	errDisabled     = errors.New("운영용 RPC가 설정되지 않았습니다 (ADMIN_API_TOKEN)")
+	errMissingToken = errors.New("운영용 RPC는 Authorization: Bearer 헤더가 필요합니다")
+	errInvalidToken = errors.New("운영용 RPC token이 올바르지 않습니다")
+)
+
+// NewInterceptor: procedures에 나열한 운영용 RPC에만 Authorization: Bearer <token>을 요구하는 interceptor
+// 나머지 RPC는 그대로 통과시키며, token이 비어 있으면 운영용 RPC를 모두 거부함 (설정이 빠져도 열리지 않도록)
+func NewInterceptor(token string, procedures ...string) connect.Interceptor {
+	protected := make(map[string]struct{}, len(procedures))
+	for _, procedure := range procedures {
+		protected[procedure] = struct{}{}
+	}
+	return &interceptor{token: []byte(token), protected: protected}
+}
+
+type interceptor struct {
+	token     []byte
+	protected map[string]struct{}
+}
+
+func (i *interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := i.authorize(req.Spec().Procedure, req.Header()); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := i.authorize(conn.Spec().Procedure, conn.RequestHeader()); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+func (i *interceptor) authorize(procedure string, header http.Header) error {
+	if _, ok := i.protected[procedure]; !ok {
+		return nil
+	}
+	if len(i.token) == 0 {
+		return connect.NewError(connect.CodePermissionDenied, errDisabled)
+	}
+
+	token, ok := strings.CutPrefix(header.Get(headerAuthorization), "Bearer ")
+	if !ok || token == "" {
+		return connect.NewError(connect.CodeUnauthenticated, errMissingToken)
+	}
+	if subtle.ConstantTimeCompare([]byte(token), i.token) != 1 {
+		return connect.NewError(connect.CodeUnauthenticated, errInvalidToken)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package apperr
+
+import (
+	"errors"
+	"time"
+)
+
+// 에러 분류 (storage, store 계층의 모든 에러는 아래 중 하나로 분류됨)
+// 분류되지 않은 에러는 내부 에러로 취급
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrAlreadyExists      = errors.New("already exists")
+	ErrConflict           = errors.New("conflict")
+	ErrInvalidInput       = errors.New("invalid input")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrUnavailable        = errors.New("dependency unavailable")
+)
+
+// kindError: 분류(kind)에 속하는 sentinel 에러
+type kindError struct {
+	kind error
+	msg  string
+}
+
+func (e *kindError) Error() string { return e.msg }
+
+func (e *kindError) Is(target error) bool { return target == e.kind }
+
+// New: kind로 분류되는 sentinel 에러를 만듦
+// errors.Is(err, 반환값)과 errors.Is(err, kind)가 모두 true가 됨
+func New(kind error, msg string) error {
+	return &kindError{kind: kind, msg: msg}
+}
+
+// FieldError: 실패 원인이 된 요청 필드를 에러에 함께 기록
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string { return e.Err.Error() }
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+func WithField(field string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &FieldError{Field: field, Err: err}
+}
+
+// Field: 에러 체인에서 가장 바깥쪽 필드 이름을 찾음
+func Field(err error) (string, bool) {
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		return fe.Field, true
+	}
+	return "", false
+}
+
+// RetryError: 잠시 뒤 같은 요청을 다시 보내면 성공할 수 있는 에러 (다시 보내기 전에 기다릴 시간을 함께 기록)
+type RetryError struct {
+	Delay time.Duration
+	Err   error
+}
+
+func (e *RetryError) Error() string { return e.Err.Error() }
+
+func (e *RetryError) Unwrap() error { return e.Err }
+
+func WithRetryDelay(delay time.Duration, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &RetryError{Delay: delay, Err: err}
+}
+
+// RetryDelay: 에러 체인에서 가장 바깥쪽 재시도 대기 시간을 찾음
+func RetryDelay(err error) (time.Duration, bool) {
+	var re *RetryError
+	if errors.As(err, &re) {
+		return re.Delay, true
+	}
+	return 0, false
+}
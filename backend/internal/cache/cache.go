@@ -0,0 +1,218 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LoadFunc: 캐시에 없는 값을 원본에서 읽는 함수
+// found가 false면 값이 없다는 결과(negative)를 negativeTTL 동안 캐시함
+// 에러는 캐시하지 않음 (다음 호출이 다시 읽음)
+type LoadFunc[V any] func(ctx context.Context) (value V, found bool, err error)
+
+// Stats: 관측용 누적 카운터와 현재 항목 수
+type Stats struct {
+	// 캐시에 있던 값을 돌려준 횟수
+	Hits int64
+	// 캐시에 있던 "없음" 결과를 돌려준 횟수
+	NegativeHits int64
+	// 캐시에 없어(또는 만료돼) 원본을 읽은 횟수
+	Misses int64
+	// 이미 진행 중인 읽기의 결과를 기다려 받은 횟수
+	Shared int64
+	// 원본 읽기가 에러로 끝난 횟수
+	LoadErrors int64
+	// 용량이 차서 가장 오래 사용하지 않은 항목을 버린 횟수
+	Evictions int64
+	// Invalidate로 지운 횟수
+	Invalidations int64
+	Size          int
+}
+
+// Cache: 항목 수가 제한된 TTL + LRU 캐시
+// 같은 key를 동시에 조회하면 원본 읽기는 한 번만 실행하고 결과를 나눠 받음
+type Cache[K comparable, V any] struct {
+	capacity    int
+	ttl         time.Duration
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	mu sync.Mutex
+	// 앞쪽이 최근에 사용한 항목
+	order    *list.List
+	entries  map[K]*list.Element
+	inflight map[K]*call[V]
+
+	hits, negativeHits, misses, shared, loadErrors, evictions, invalidations atomic.Int64
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	found     bool
+	expiresAt time.Time
+}
+
+// call: 진행 중인 원본 읽기 하나
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	found bool
+	err   error
+	// 읽는 도중 Invalidate되면 결과를 캐시에 넣지 않음
+	invalidated bool
+}
+
+func New[K comparable, V any](capacity int, ttl, negativeTTL time.Duration) (*Cache[K, V], error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("캐시 용량은 0보다 커야 합니다: %d", capacity)
+	}
+	if ttl <= 0 || negativeTTL <= 0 {
+		return nil, fmt.Errorf("캐시 TTL은 0보다 커야 합니다: ttl=%s negativeTTL=%s", ttl, negativeTTL)
+	}
+
+	return &Cache[K, V]{
+		capacity:    capacity,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		now:         time.Now,
+		order:       list.New(),
+		entries:     make(map[K]*list.Element),
+		inflight:    make(map[K]*call[V]),
+	}, nil
+}
+
+// Get: 캐시에 있으면 바로 돌려주고, 없으면 load로 읽어 캐시에 넣은 뒤 돌려줌
+// load는 호출자의 취소와 무관하게 끝까지 실행되므로(다른 호출자가 결과를 기다릴 수 있음) 자체 제한 시간이 있어야 함
+func (c *Cache[K, V]) Get(ctx context.Context, key K, load LoadFunc[V]) (V, bool, error) {
+	c.mu.Lock()
+	if value, found, ok := c.lookup(key); ok {
+		c.mu.Unlock()
+		if found {
+			c.hits.Add(1)
+		} else {
+			c.negativeHits.Add(1)
+		}
+		return value, found, nil
+	}
+
+	if cl, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		c.shared.Add(1)
+		return c.wait(ctx, cl)
+	}
+
+	cl := &call[V]{done: make(chan struct{})}
+	c.inflight[key] = cl
+	c.mu.Unlock()
+	c.misses.Add(1)
+
+	go c.load(context.WithoutCancel(ctx), key, cl, load)
+	return c.wait(ctx, cl)
+}
+
+// Invalidate: key의 캐시 항목을 지우고, 진행 중인 읽기 결과도 캐시하지 않게 함
+func (c *Cache[K, V]) Invalidate(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+	if cl, ok := c.inflight[key]; ok {
+		cl.invalidated = true
+		// 이후 호출은 진행 중인 (이미 오래됐을 수 있는) 읽기를 기다리지 않고 새로 읽음
+		delete(c.inflight, key)
+	}
+	c.invalidations.Add(1)
+}
+
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	size := len(c.entries)
+	c.mu.Unlock()
+
+	return Stats{
+		Hits:          c.hits.Load(),
+		NegativeHits:  c.negativeHits.Load(),
+		Misses:        c.misses.Load(),
+		Shared:        c.shared.Load(),
+		LoadErrors:    c.loadErrors.Load(),
+		Evictions:     c.evictions.Load(),
+		Invalidations: c.invalidations.Load(),
+		Size:          size,
+	}
+}
+
+// lookup: 만료되지 않은 항목이 있으면 LRU 맨 앞으로 옮기고 반환 (c.mu를 잡은 상태에서 호출)
+func (c *Cache[K, V]) lookup(key K) (V, bool, bool) {
+	var zero V
+	elem, ok := c.entries[key]
+	if !ok {
+		return zero, false, false
+	}
+	e := elem.Value.(*entry[K, V])
+	if !c.now().Before(e.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return zero, false, false
+	}
+	c.order.MoveToFront(elem)
+	return e.value, e.found, true
+}
+
+func (c *Cache[K, V]) load(ctx context.Context, key K, cl *call[V], load LoadFunc[V]) {
+	cl.value, cl.found, cl.err = load(ctx)
+	if cl.err != nil {
+		c.loadErrors.Add(1)
+	}
+
+	c.mu.Lock()
+	if c.inflight[key] == cl {
+		delete(c.inflight, key)
+	}
+	if cl.err == nil && !cl.invalidated {
+		c.store(key, cl.value, cl.found)
+	}
+	c.mu.Unlock()
+
+	close(cl.done)
+}
+
+// store: 항목을 넣고 용량을 넘으면 가장 오래 사용하지 않은 항목을 버림 (c.mu를 잡은 상태에서 호출)
+func (c *Cache[K, V]) store(key K, value V, found bool) {
+	ttl := c.ttl
+	if !found {
+		ttl = c.negativeTTL
+	}
+	e := &entry[K, V]{key: key, value: value, found: found, expiresAt: c.now().Add(ttl)}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = e
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(e)
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry[K, V]).key)
+		c.evictions.Add(1)
+	}
+}
+
+func (c *Cache[K, V]) wait(ctx context.Context, cl *call[V]) (V, bool, error) {
+	select {
+	case <-cl.done:
+		return cl.value, cl.found, cl.err
+	case <-ctx.Done():
+		var zero V
+		return zero, false, ctx.Err()
+	}
+}
@@ -0,0 +1,283 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testCache: 시계를 직접 움직일 수 있는 캐시
+func testCache(t *testing.T, capacity int) (*Cache[string, string], *time.Time) {
+	t.Helper()
+	c, err := New[string, string](capacity, 10*time.Second, 2*time.Second)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	now := time.Unix(1735787045, 0)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+// countingLoad: 호출 횟수를 세며 value(found=value != "")를 돌려주는 LoadFunc
+func countingLoad(count *atomic.Int32, value string) LoadFunc[string] {
+	return func(context.Context) (string, bool, error) {
+		count.Add(1)
+		return value, value != "", nil
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name             string
+		capacity         int
+		ttl, negativeTTL time.Duration
+		wantErr          bool
+	}{
+		{name: "정상", capacity: 1, ttl: time.Second, negativeTTL: time.Second},
+		{name: "용량 0", capacity: 0, ttl: time.Second, negativeTTL: time.Second, wantErr: true},
+		{name: "TTL 0", capacity: 1, ttl: 0, negativeTTL: time.Second, wantErr: true},
+		{name: "negative TTL 0", capacity: 1, ttl: time.Second, negativeTTL: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New[string, int](tt.capacity, tt.ttl, tt.negativeTTL)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		// 이 시간이 지나기 전까지 캐시된 결과를 사용
+		ttl time.Duration
+	}{
+		{name: "값이 있는 결과", value: "active", ttl: 10 * time.Second},
+		{name: "값이 없는 결과", value: "", ttl: 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, now := testCache(t, 10)
+			ctx := context.Background()
+			var loads atomic.Int32
+			load := countingLoad(&loads, tt.value)
+
+			for _, step := range []struct {
+				advance   time.Duration
+				wantLoads int32
+			}{
+				{advance: 0, wantLoads: 1},
+				{advance: tt.ttl - time.Millisecond, wantLoads: 1},
+				{advance: time.Millisecond, wantLoads: 2},
+			} {
+				*now = now.Add(step.advance)
+				value, found, err := c.Get(ctx, "user_1", load)
+				if err != nil {
+					t.Fatalf("Get: %v", err)
+				}
+				if value != tt.value || found != (tt.value != "") {
+					t.Fatalf("Get = %q, %v, want %q, %v", value, found, tt.value, tt.value != "")
+				}
+				if got := loads.Load(); got != step.wantLoads {
+					t.Fatalf("%s 경과 후 원본 읽기 %d번, want %d", step.advance, got, step.wantLoads)
+				}
+			}
+		})
+	}
+}
+
+func TestCacheLRUEviction(t *testing.T) {
+	c, _ := testCache(t, 2)
+	ctx := context.Background()
+	var loads atomic.Int32
+
+	get := func(key string) {
+		t.Helper()
+		if _, _, err := c.Get(ctx, key, countingLoad(&loads, "v-"+key)); err != nil {
+			t.Fatalf("Get(%s): %v", key, err)
+		}
+	}
+
+	get("a")
+	get("b")
+	get("a") // a를 최근 사용으로 옮김
+	get("c") // 가장 오래 사용하지 않은 b를 버림
+	if loads.Load() != 3 {
+		t.Fatalf("원본 읽기 %d번, want 3", loads.Load())
+	}
+
+	get("a")
+	get("c")
+	if loads.Load() != 3 {
+		t.Fatalf("a, c는 캐시에 남아 있어야 함 (원본 읽기 %d번)", loads.Load())
+	}
+	get("b")
+	if loads.Load() != 4 {
+		t.Fatalf("b는 버려져 다시 읽어야 함 (원본 읽기 %d번)", loads.Load())
+	}
+
+	stats := c.Stats()
+	if stats.Size != 2 || stats.Evictions != 2 {
+		t.Errorf("Stats = %+v, want Size 2, Evictions 2", stats)
+	}
+}
+
+func TestCacheSingleflight(t *testing.T) {
+	c, _ := testCache(t, 10)
+	const callers = 8
+
+	release := make(chan struct{})
+	var loads atomic.Int32
+	load := func(context.Context) (string, bool, error) {
+		loads.Add(1)
+		<-release
+		return "active", true, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan string, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, _, err := c.Get(context.Background(), "user_1", load)
+			if err != nil {
+				t.Errorf("Get: %v", err)
+			}
+			results <- value
+		}()
+	}
+
+	// 모든 호출자가 진행 중인 읽기를 기다릴 때까지 기다림
+	deadline := time.Now().Add(5 * time.Second)
+	for c.Stats().Shared != callers-1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Stats = %+v, 호출자가 읽기를 나눠 받지 않음", c.Stats())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	close(results)
+
+	for value := range results {
+		if value != "active" {
+			t.Errorf("Get = %q, want active", value)
+		}
+	}
+	if loads.Load() != 1 {
+		t.Errorf("원본 읽기 %d번, want 1", loads.Load())
+	}
+	if stats := c.Stats(); stats.Misses != 1 || stats.Shared != callers-1 {
+		t.Errorf("Stats = %+v, want Misses 1, Shared %d", stats, callers-1)
+	}
+}
+
+func TestCacheLoadError(t *testing.T) {
+	c, _ := testCache(t, 10)
+	ctx := context.Background()
+	errLoad := errors.New("user 서비스 장애")
+
+	var loads atomic.Int32
+	failing := func(context.Context) (string, bool, error) {
+		loads.Add(1)
+		return "", false, errLoad
+	}
+	for range 2 {
+		if _, _, err := c.Get(ctx, "user_1", failing); !errors.Is(err, errLoad) {
+			t.Fatalf("Get error = %v, want %v", err, errLoad)
+		}
+	}
+	if loads.Load() != 2 {
+		t.Errorf("에러는 캐시하지 않아야 함 (원본 읽기 %d번)", loads.Load())
+	}
+	if stats := c.Stats(); stats.LoadErrors != 2 || stats.Size != 0 {
+		t.Errorf("Stats = %+v, want LoadErrors 2, Size 0", stats)
+	}
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	t.Run("캐시된 항목", func(t *testing.T) {
+		c, _ := testCache(t, 10)
+		ctx := context.Background()
+		var loads atomic.Int32
+
+		c.Get(ctx, "user_1", countingLoad(&loads, "active"))
+		c.Invalidate("user_1")
+		value, _, _ := c.Get(ctx, "user_1", countingLoad(&loads, "suspended"))
+
+		if value != "suspended" || loads.Load() != 2 {
+			t.Errorf("Get = %q (원본 읽기 %d번), want suspended를 다시 읽음", value, loads.Load())
+		}
+	})
+
+	t.Run("진행 중인 읽기", func(t *testing.T) {
+		c, _ := testCache(t, 10)
+		ctx := context.Background()
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		done := make(chan string)
+		go func() {
+			value, _, _ := c.Get(ctx, "user_1", func(context.Context) (string, bool, error) {
+				close(started)
+				<-release
+				return "active", true, nil
+			})
+			done <- value
+		}()
+		<-started
+
+		// 읽는 도중 변경되면 이후 호출은 진행 중인 읽기를 기다리지 않고 새로 읽음
+		c.Invalidate("user_1")
+		var loads atomic.Int32
+		value, _, err := c.Get(ctx, "user_1", countingLoad(&loads, "suspended"))
+		if err != nil || value != "suspended" || loads.Load() != 1 {
+			t.Fatalf("Invalidate 후 Get = %q, %v (원본 읽기 %d번), want suspended", value, err, loads.Load())
+		}
+
+		// 먼저 시작한 읽기는 호출자에게는 결과를 주지만 캐시를 덮어쓰지 않음
+		close(release)
+		if value := <-done; value != "active" {
+			t.Fatalf("먼저 시작한 Get = %q, want active", value)
+		}
+		value, _, _ = c.Get(ctx, "user_1", countingLoad(&loads, "closed"))
+		if value != "suspended" || loads.Load() != 1 {
+			t.Errorf("Get = %q (원본 읽기 %d번), want 캐시된 suspended", value, loads.Load())
+		}
+	})
+}
+
+func TestCacheCallerCancel(t *testing.T) {
+	c, _ := testCache(t, 10)
+
+	release := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// 호출자가 취소돼도 읽기는 끝까지 실행되어 캐시에 들어감
+	_, _, err := c.Get(ctx, "user_1", func(ctx context.Context) (string, bool, error) {
+		<-release
+		if ctx.Err() != nil {
+			return "", false, ctx.Err()
+		}
+		return "active", true, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Get error = %v, want context.Canceled", err)
+	}
+	close(release)
+
+	// 진행 중인 읽기를 기다리거나 캐시된 결과를 받으며 원본은 다시 읽지 않음
+	var loads atomic.Int32
+	value, _, err := c.Get(context.Background(), "user_1", countingLoad(&loads, "other"))
+	if err != nil || value != "active" || loads.Load() != 0 {
+		t.Errorf("Get = %q, %v (원본 읽기 %d번), want 취소된 호출이 시작한 읽기의 결과 active", value, err, loads.Load())
+	}
+}
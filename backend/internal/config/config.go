@@ -2,33 +2,326 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// STORAGE_BACKEND로 선택할 수 있는 저장소 구현
+const (
+	StorageBackendDynamoDB = "dynamodb"
+	StorageBackendMemory   = "memory"
+)
+
+// 서비스가 사용하는 DynamoDB 테이블의 이름을 담는 환경 변수
+// LoadConfig에 넘기면 STORAGE_BACKEND=dynamodb일 때 값이 있는지 확인함
+const (
+	TableUser        = "DYNAMO_USER_TABLE"
+	TableOrder       = "DYNAMO_ORDER_TABLE"
+	TableIdempotency = "DYNAMO_IDEMPOTENCY_TABLE"
+	TableProduct     = "DYNAMO_PRODUCT_TABLE"
+	TableInventory   = "DYNAMO_INVENTORY_TABLE"
+	TableSaga        = "DYNAMO_SAGA_TABLE"
+	TableOutbox      = "DYNAMO_OUTBOX_TABLE"
+)
+
+// EVENT_PUBLISHER로 선택할 수 있는 outbox 이벤트 발행 방식
+const (
+	EventPublisherMemory  = "memory"
+	EventPublisherWebhook = "webhook"
+)
+
+// TRACING_EXPORTER로 선택할 수 있는 trace 내보내기 방식
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
 )
 
 type Config struct {
-	Port             string
-	AWSRegion        string
-	AWSEndpoint      string
-	DynamoUserTable  string
-	DynamoOrderTable string
-	UserServiceURL   string
+	Port string
+	// /metrics만 서비스하는 포트 (Service로 노출하지 않고 Prometheus가 파드에서 직접 수집)
+	MetricsPort            string
+	StorageBackend         string
+	AWSRegion              string
+	AWSEndpoint            string
+	DynamoUserTable        string
+	DynamoOrderTable       string
+	DynamoIdempotencyTable string
+	DynamoProductTable     string
+	DynamoInventoryTable   string
+	DynamoSagaTable        string
+	DynamoOutboxTable      string
+	UserServiceURL         string
+	ProductServiceURL      string
+	InventoryServiceURL    string
+	PageTokenSecret        string
+	// 운영용 RPC(사용자 정지/재활성화/해지)를 호출할 때 Authorization: Bearer로 보내는 token
+	// 비어 있으면 운영용 RPC를 모두 거부
+	AdminAPIToken string
+	// 사용자를 만들거나 이메일을 바꿀 때 이메일 가드와 함께 이메일 GSI도 확인할지
+	// 가드가 없는 이전 사용자가 남아 있는 동안 켜 두고, backfill-email을 끝까지 실행한 뒤 끔
+	UserEmailLegacyCheck bool
+	// 비어 있으면 STORAGE_BACKEND=memory에서만 memory를 사용 (dynamodb이면 outbox.NewPublisher가 거부)
+	EventPublisher string
+	// EVENT_PUBLISHER=webhook일 때 이벤트를 POST할 URL
+	EventWebhookURL string
+	// EVENT_PUBLISHER와 별개로 이벤트를 POST할 클러스터 내부 URL (쉼표로 구분, http만 지원)
+	// 호스트의 모든 주소로 보내므로 headless Service를 지정하면 구독 서비스의 모든 파드가 이벤트를 받음
+	EventFanoutURLs []string
+	// 주문 금액에 적용할 세율 (basis point, 1000 = 10%)
+	OrderTaxRateBPS int64
+	// 다른 서비스 호출(rpcclient) 설정
+	// 시도 한 번의 제한 시간
+	RPCClientTimeout time.Duration
+	// 첫 시도를 포함한 최대 시도 횟수 (Unavailable, DeadlineExceeded만 재시도)
+	RPCClientMaxAttempts int
+	// 재시도 대기 시간 (시도할 때마다 두 배, 최대 RPCClientRetryMaxDelay 안에서 무작위)
+	RPCClientRetryBaseDelay time.Duration
+	RPCClientRetryMaxDelay  time.Duration
+	// 연속 실패가 이 횟수에 이르면 circuit breaker를 열어 바로 실패시킴
+	RPCClientBreakerFailures int
+	// circuit breaker가 열린 뒤 다시 호출해 보기까지 기다리는 시간
+	RPCClientBreakerOpenDuration time.Duration
+	// 주문 서비스의 사용자 상태 캐시 설정
+	// 최대 항목 수 (가장 오래 사용하지 않은 항목부터 버림)
+	UserCacheSize int
+	// 조회한 사용자 상태를 유지하는 시간
+	UserCacheTTL time.Duration
+	// 없는 사용자(NotFound) 결과를 유지하는 시간
+	UserCacheNegativeTTL time.Duration
+	// HTTP 서버 설정
+	// 요청 헤더를 다 받을 때까지의 제한 시간
+	HTTPReadHeaderTimeout time.Duration
+	// 요청 본문까지 다 받을 때까지의 제한 시간
+	HTTPReadTimeout time.Duration
+	// keep-alive 연결을 요청 없이 유지하는 시간
+	HTTPIdleTimeout time.Duration
+	// 요청 헤더 최대 크기 (byte)
+	HTTPMaxHeaderBytes int
+	// 종료 신호를 받은 뒤 readiness를 실패로 바꾸고 새 연결을 계속 받는 시간 (로드밸런서에서 빠질 때까지)
+	ShutdownDrainPeriod time.Duration
+	// 진행 중인 요청과 백그라운드 작업이 끝나기를 기다리는 최대 시간
+	ShutdownTimeout time.Duration
+	// readiness 확인 하나의 제한 시간
+	HealthCheckTimeout time.Duration
+	// readiness 확인 결과를 재사용하는 시간 (프로브마다 DynamoDB와 다른 서비스를 호출하지 않도록)
+	HealthCheckCacheTTL time.Duration
+	// OpenTelemetry trace 설정
+	// none: 내보내지 않음 (trace context 전파만 함), stdout: 표준 출력 (로컬 확인용), otlp: OTLP/HTTP collector
+	TracingExporter string
+	// TRACING_EXPORTER=otlp일 때 collector 주소 (예: http://otel-collector:4318)
+	TracingOTLPEndpoint string
+	// 새로 시작하는 trace를 기록할 비율 (0~1, 상위 서비스가 정한 샘플링 여부는 그대로 따름)
+	TracingSampleRatio float64
+	// 남길 최소 로그 레벨 (LOG_LEVEL: debug, info, warn, error)
+	LogLevel slog.Level
 }
 
-func LoadConfig() (*Config, error) {
+// LoadConfig: 환경 변수로 설정을 읽음
+// tables는 서비스가 사용하는 테이블(TableUser 등)로, 다른 서비스의 테이블 이름은 비어 있어도 됨
+func LoadConfig(tables ...string) (*Config, error) {
 	cfg := &Config{
-		Port:             getEnv("PORT", "8080"),
-		AWSRegion:        getEnv("AWS_REGION", "ap-northeast-2"),
-		AWSEndpoint:      getEnv("AWS_ENDPOINT", ""),
-		DynamoUserTable:  getEnv("DYNAMO_USER_TABLE", ""),
-		DynamoOrderTable: getEnv("DYNAMO_ORDER_TABLE", ""),
-		UserServiceURL:   getEnv("USER_SERVICE_URL", "http://localhost:8081"),
+		Port:                   getEnv("PORT", "8080"),
+		MetricsPort:            getEnv("METRICS_PORT", "9090"),
+		StorageBackend:         getEnv("STORAGE_BACKEND", StorageBackendDynamoDB),
+		AWSRegion:              getEnv("AWS_REGION", "ap-northeast-2"),
+		AWSEndpoint:            getEnv("AWS_ENDPOINT", ""),
+		DynamoUserTable:        getEnv(TableUser, ""),
+		DynamoOrderTable:       getEnv(TableOrder, ""),
+		DynamoIdempotencyTable: getEnv(TableIdempotency, ""),
+		DynamoProductTable:     getEnv(TableProduct, ""),
+		DynamoInventoryTable:   getEnv(TableInventory, ""),
+		DynamoSagaTable:        getEnv(TableSaga, ""),
+		DynamoOutboxTable:      getEnv(TableOutbox, ""),
+		UserServiceURL:         getEnv("USER_SERVICE_URL", "http://localhost:8081"),
+		ProductServiceURL:      getEnv("PRODUCT_SERVICE_URL", "http://localhost:8082"),
+		InventoryServiceURL:    getEnv("INVENTORY_SERVICE_URL", "http://localhost:8083"),
+		PageTokenSecret:        getEnv("PAGE_TOKEN_SECRET", ""),
+		AdminAPIToken:          getEnv("ADMIN_API_TOKEN", ""),
+		EventPublisher:         getEnv("EVENT_PUBLISHER", ""),
+		EventWebhookURL:        getEnv("EVENT_WEBHOOK_URL", ""),
+		EventFanoutURLs:        getList("EVENT_FANOUT_URLS"),
+		TracingExporter:        getEnv("TRACING_EXPORTER", TracingExporterNone),
+		TracingOTLPEndpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+	}
+
+	if cfg.MetricsPort == cfg.Port {
+		return nil, fmt.Errorf("METRICS_PORT는 PORT와 달라야 함")
+	}
+
+	taxRate, err := strconv.ParseInt(getEnv("ORDER_TAX_RATE_BPS", "0"), 10, 64)
+	if err != nil || taxRate < 0 || taxRate > 10000 {
+		return nil, fmt.Errorf("ORDER_TAX_RATE_BPS는 0~10000 사이의 정수여야 함")
+	}
+	cfg.OrderTaxRateBPS = taxRate
+
+	if cfg.RPCClientTimeout, err = getDuration("RPC_CLIENT_TIMEOUT", 2*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.RPCClientMaxAttempts, err = getPositiveInt("RPC_CLIENT_MAX_ATTEMPTS", 3); err != nil {
+		return nil, err
+	}
+	if cfg.RPCClientRetryBaseDelay, err = getDuration("RPC_CLIENT_RETRY_BASE_DELAY", 100*time.Millisecond); err != nil {
+		return nil, err
+	}
+	if cfg.RPCClientRetryMaxDelay, err = getDuration("RPC_CLIENT_RETRY_MAX_DELAY", time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.RPCClientBreakerFailures, err = getPositiveInt("RPC_CLIENT_BREAKER_FAILURES", 5); err != nil {
+		return nil, err
+	}
+	if cfg.RPCClientBreakerOpenDuration, err = getDuration("RPC_CLIENT_BREAKER_OPEN_DURATION", 30*time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.UserCacheSize, err = getPositiveInt("USER_CACHE_SIZE", 10000); err != nil {
+		return nil, err
+	}
+	if cfg.UserCacheTTL, err = getDuration("USER_CACHE_TTL", 30*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.UserCacheNegativeTTL, err = getDuration("USER_CACHE_NEGATIVE_TTL", 5*time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.UserEmailLegacyCheck, err = getBool("USER_EMAIL_LEGACY_CHECK", true); err != nil {
+		return nil, err
+	}
+
+	if cfg.HTTPReadHeaderTimeout, err = getDuration("HTTP_READ_HEADER_TIMEOUT", 5*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.HTTPReadTimeout, err = getDuration("HTTP_READ_TIMEOUT", 30*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.HTTPIdleTimeout, err = getDuration("HTTP_IDLE_TIMEOUT", 120*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.HTTPMaxHeaderBytes, err = getPositiveInt("HTTP_MAX_HEADER_BYTES", 64<<10); err != nil {
+		return nil, err
 	}
-	if cfg.DynamoUserTable == "" || cfg.DynamoOrderTable == "" {
-		return nil, fmt.Errorf("DynamoDB 테이블 이름이 비어 있음")
+	if cfg.ShutdownDrainPeriod, err = getDuration("SHUTDOWN_DRAIN_PERIOD", 5*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.ShutdownTimeout, err = getDuration("SHUTDOWN_TIMEOUT", 20*time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.HealthCheckTimeout, err = getDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.HealthCheckCacheTTL, err = getDuration("HEALTH_CHECK_CACHE_TTL", 5*time.Second); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.LogLevel.UnmarshalText([]byte(getEnv("LOG_LEVEL", "info"))); err != nil {
+		return nil, fmt.Errorf("LOG_LEVEL은 debug, info, warn, error 중 하나여야 함")
+	}
+
+	sampleRatio, err := strconv.ParseFloat(getEnv("TRACING_SAMPLE_RATIO", "1"), 64)
+	if err != nil || sampleRatio < 0 || sampleRatio > 1 {
+		return nil, fmt.Errorf("TRACING_SAMPLE_RATIO는 0~1 사이의 숫자여야 함")
+	}
+	cfg.TracingSampleRatio = sampleRatio
+
+	switch cfg.TracingExporter {
+	case TracingExporterNone, TracingExporterStdout:
+	case TracingExporterOTLP:
+		if cfg.TracingOTLPEndpoint == "" {
+			return nil, fmt.Errorf("TRACING_EXPORTER=otlp이면 OTEL_EXPORTER_OTLP_ENDPOINT가 필요함")
+		}
+	default:
+		return nil, fmt.Errorf("지원하지 않는 TRACING_EXPORTER: %s", cfg.TracingExporter)
+	}
+
+	switch cfg.EventPublisher {
+	case "", EventPublisherMemory:
+	case EventPublisherWebhook:
+		if cfg.EventWebhookURL == "" {
+			return nil, fmt.Errorf("EVENT_PUBLISHER=webhook이면 EVENT_WEBHOOK_URL이 필요함")
+		}
+	default:
+		return nil, fmt.Errorf("지원하지 않는 EVENT_PUBLISHER: %s", cfg.EventPublisher)
+	}
+
+	switch cfg.StorageBackend {
+	case StorageBackendDynamoDB:
+		names := map[string]string{
+			TableUser:        cfg.DynamoUserTable,
+			TableOrder:       cfg.DynamoOrderTable,
+			TableIdempotency: cfg.DynamoIdempotencyTable,
+			TableProduct:     cfg.DynamoProductTable,
+			TableInventory:   cfg.DynamoInventoryTable,
+			TableSaga:        cfg.DynamoSagaTable,
+			TableOutbox:      cfg.DynamoOutboxTable,
+		}
+		for _, table := range tables {
+			name, ok := names[table]
+			if !ok {
+				return nil, fmt.Errorf("알 수 없는 DynamoDB 테이블 환경 변수: %s", table)
+			}
+			if name == "" {
+				return nil, fmt.Errorf("STORAGE_BACKEND=dynamodb이면 %s 값이 필요함", table)
+			}
+		}
+	case StorageBackendMemory:
+	default:
+		return nil, fmt.Errorf("지원하지 않는 STORAGE_BACKEND: %s", cfg.StorageBackend)
 	}
 	return cfg, nil
 }
 
+// getDuration: "500ms", "2s" 같은 Go duration 형식의 양수 값
+func getDuration(key string, def time.Duration) (time.Duration, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%s는 0보다 큰 duration이어야 함 (예: 500ms, 2s)", key)
+	}
+	return d, nil
+}
+
+func getPositiveInt(key string, def int) (int, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s는 0보다 큰 정수여야 함", key)
+	}
+	return n, nil
+}
+
+func getBool(key string, def bool) (bool, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return def, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("%s는 true 또는 false여야 함", key)
+	}
+	return b, nil
+}
+
+// getList: 쉼표로 구분한 값 (빈 항목은 건너뜀)
+func getList(key string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
 func getEnv(key, def string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
@@ -0,0 +1,164 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/logging"
+)
+
+// Check: 의존하는 자원 하나가 요청을 처리할 수 있는 상태인지 확인 (nil이면 정상)
+type Check func(ctx context.Context) error
+
+// 결과 상태 값
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Result: 확인 하나의 결과
+// readiness JSON은 서비스 포트로 노출되므로 status만 내보내고, 실패 원인은 확인을 실행할 때 로그로 남김
+type Result struct {
+	Status    string        `json:"status"`
+	Error     string        `json:"-"`
+	Duration  time.Duration `json:"-"`
+	CheckedAt time.Time     `json:"-"`
+}
+
+// Report: 등록된 모든 확인의 결과
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+func (r Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+// Checker: 이름을 붙여 등록한 확인들을 실행하고 결과를 cacheTTL 동안 재사용
+// 프로브가 자주 호출되어도 DynamoDB나 다른 서비스에 매번 요청하지 않도록 캐시함
+type Checker struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	mu     sync.Mutex
+	checks map[string]*entry
+}
+
+type entry struct {
+	name  string
+	check Check
+	// 같은 확인을 동시에 실행하지 않도록 잠금 (먼저 실행한 결과를 기다렸다 재사용)
+	mu     sync.Mutex
+	result Result
+	valid  bool
+}
+
+func NewChecker(timeout, cacheTTL time.Duration) (*Checker, error) {
+	if timeout <= 0 {
+		return nil, fmt.Errorf("health check timeout은 0보다 커야 합니다: %s", timeout)
+	}
+	if cacheTTL < 0 {
+		return nil, fmt.Errorf("health check cache TTL은 음수일 수 없습니다: %s", cacheTTL)
+	}
+
+	return &Checker{
+		timeout:  timeout,
+		cacheTTL: cacheTTL,
+		now:      time.Now,
+		checks:   make(map[string]*entry),
+	}, nil
+}
+
+// Register: 확인 등록 (같은 이름이면 덮어씀)
+func (c *Checker) Register(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks[name] = &entry{name: name, check: check}
+}
+
+// Run: 모든 확인을 동시에 실행하고 결과를 모음 (하나라도 실패하면 전체가 fail)
+func (c *Checker) Run(ctx context.Context) Report {
+	c.mu.Lock()
+	checks := make(map[string]*entry, len(c.checks))
+	for name, e := range c.checks {
+		checks[name] = e
+	}
+	c.mu.Unlock()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(checks))}
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for name, e := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := c.run(ctx, e)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status != StatusOK {
+				report.Status = StatusFail
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, e *entry) Result {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.valid && c.now().Sub(e.result.CheckedAt) < c.cacheTTL {
+		return e.result
+	}
+
+	// 프로브 요청이 먼저 끊겨도 확인은 끝까지 실행해 결과를 캐시함 (다음 프로브가 재사용)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.timeout)
+	defer cancel()
+
+	start := c.now()
+	err := e.check(ctx)
+	result := Result{
+		Status:    StatusOK,
+		Duration:  c.now().Sub(start),
+		CheckedAt: start,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+		logging.FromContext(ctx).Warn("readiness 확인 실패", "check", e.name, "duration", result.Duration.Round(time.Millisecond).String(), "error", err)
+	}
+	e.result = result
+	e.valid = true
+	return result
+}
+
+// HTTPCheck: url에 GET 요청을 보내 2xx 응답이 오는지 확인 (다른 서비스의 /livez 등)
+func HTTPCheck(client *http.Client, url string) Check {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return fmt.Errorf("요청 생성 실패: %w", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("%s 응답 상태 %d", url, resp.StatusCode)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,244 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/apperr"
+	"Acho-mj/2025_Golang_MSA/backend/internal/logging"
+	"Acho-mj/2025_Golang_MSA/backend/internal/retry"
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+)
+
+const (
+	// 클라이언트가 재시도 시 같은 값을 보내는 헤더
+	HeaderKey = "Idempotency-Key"
+	// key 최대 길이
+	MaxKeyLength = 255
+	// 기록 보관 기간 (DynamoDB TTL)
+	DefaultTTL = 24 * time.Hour
+	// 처리 중(pending)인 요청이 기록을 점유하는 시간
+	// 요청을 처리하던 인스턴스가 죽어 기록을 완료하지 못하면 이 시간이 지난 뒤 같은 key의 요청이 기록을 가져감
+	// (다른 서비스 호출의 재시도를 모두 포함한 요청 처리 시간보다 길어야 함)
+	DefaultLeaseTTL = 2 * time.Minute
+	// 생성 후 기록을 완료하는 최대 시도 횟수
+	completeAttempts = 3
+	// 같은 key의 요청이 처리 중일 때 클라이언트에 알려 주는 재시도 대기 시간
+	InProgressRetryDelay = time.Second
+)
+
+// 기록 완료 재시도 간격
+var completeBackoff = retry.Backoff{Base: 50 * time.Millisecond, Max: 500 * time.Millisecond}
+
+var (
+	ErrInvalidKey = apperr.New(apperr.ErrInvalidInput, "idempotency key가 올바르지 않습니다")
+	ErrKeyReused  = apperr.New(apperr.ErrInvalidInput, "idempotency key가 다른 요청에 이미 사용되었습니다")
+	// 처리 중인 요청이 끝나면 같은 요청으로 결과를 받을 수 있으므로 충돌(Aborted)로 분류
+	ErrInProgress = apperr.New(apperr.ErrConflict, "같은 idempotency key의 요청이 처리 중입니다")
+	// create가 끝나지 않은 작업을 남기고 반환한 경우 (Hold로 묶은 기록은 작업이 끝날 때 정리됨)
+	ErrPending = apperr.New(apperr.ErrUnavailable, "요청 처리가 아직 끝나지 않았습니다")
+)
+
+// Hold: create 안에서 리소스 ID를 정한 뒤, 요청 경로 밖에서 끝날 수 있는 작업(saga)을 시작하기 전에 호출
+// 기록에 리소스 ID를 묶고 lease를 없애므로 작업이 끝날 때까지 같은 key의 요청이 기록을 가져가지 못함
+// 이후 create가 ErrPending으로 실패하면 Do는 기록을 지우지 않고, 작업을 끝낸 쪽이 Guard.Complete/Release로 정리해야 함
+type Hold func(ctx context.Context, resourceID string) error
+
+// Result: 생성 요청의 결과
+type Result struct {
+	ResourceID string
+	// 처음 응답한 리소스를 직렬화한 값 (재시도에는 이 값을 그대로 돌려줌)
+	// 응답을 기록하기 전에 완료된 기록을 재사용하면 비어 있으므로 ResourceID로 다시 조회해야 함
+	Response []byte
+	// 이전 요청의 결과를 재사용했는지 여부
+	Replayed bool
+}
+
+// Guard: idempotency key별로 생성 요청을 한 번만 실행하도록 보장
+type Guard struct {
+	repo     storage.IdempotencyRepository
+	ttl      time.Duration
+	leaseTTL time.Duration
+	now      func() time.Time
+}
+
+// NewGuard: ttl은 완료된 기록을 재사용하는 기간, leaseTTL은 처리 중인 요청이 기록을 점유하는 시간
+func NewGuard(repo storage.IdempotencyRepository, ttl, leaseTTL time.Duration) (*Guard, error) {
+	if repo == nil {
+		return nil, errors.New("idempotency repository가 nil입니다")
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if leaseTTL <= 0 {
+		leaseTTL = DefaultLeaseTTL
+	}
+	if leaseTTL > ttl {
+		return nil, fmt.Errorf("idempotency lease(%s)는 기록 보관 기간(%s)보다 길 수 없습니다", leaseTTL, ttl)
+	}
+
+	return &Guard{
+		repo:     repo,
+		ttl:      ttl,
+		leaseTTL: leaseTTL,
+		now:      time.Now,
+	}, nil
+}
+
+// Do: key로 처음 들어온 요청이면 create를 실행하고 생성된 리소스 ID와 응답을 기록
+// 같은 key와 같은 요청이 다시 들어오면 create 없이 기록된 결과를 Replayed=true로 반환
+// 처리 중인 요청이 있으면 ErrInProgress를 반환하되, 처리하던 인스턴스가 lease 안에 끝내지 못한 기록은 새 요청이 가져가 다시 실행
+// key가 비어 있거나 Guard가 nil이면 create만 실행
+func (g *Guard) Do(ctx context.Context, scope, key, requestHash string, create func(ctx context.Context, hold Hold) (Result, error)) (Result, error) {
+	if g == nil || key == "" {
+		return create(ctx, func(context.Context, string) error { return nil })
+	}
+	if len(key) > MaxKeyLength {
+		return Result{}, fmt.Errorf("%w: 최대 %d자까지 사용할 수 있습니다", ErrInvalidKey, MaxKeyLength)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return Result{}, err
+	}
+	claim := storage.IdempotencyClaim{Token: token}
+
+	now := g.now().UTC()
+	recordKey := recordKey(scope, key)
+	existing, err := g.repo.Reserve(ctx, &storage.IdempotencyRecord{
+		Key:            recordKey,
+		RequestHash:    requestHash,
+		Status:         storage.IdempotencyStatusPending,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(g.ttl).Unix(),
+		Token:          token,
+		LeaseExpiresAt: now.Add(g.leaseTTL).UnixMilli(),
+	})
+	if err != nil {
+		if !errors.Is(err, storage.ErrIdempotencyKeyExists) || existing == nil {
+			return Result{}, err
+		}
+		switch {
+		case existing.RequestHash != requestHash:
+			return Result{}, ErrKeyReused
+		case existing.Status != storage.IdempotencyStatusCompleted:
+			return Result{}, ErrInProgress
+		}
+		return Result{ResourceID: existing.ResourceID, Response: existing.Response, Replayed: true}, nil
+	}
+
+	held := false
+	hold := func(ctx context.Context, resourceID string) error {
+		if err := g.repo.Bind(ctx, recordKey, claim, resourceID); err != nil {
+			if errors.Is(err, storage.ErrIdempotencyClaimLost) {
+				// lease가 지나 같은 key의 다른 요청이 기록을 가져간 경우
+				return fmt.Errorf("%w: %v", ErrInProgress, err)
+			}
+			return err
+		}
+		held = true
+		return nil
+	}
+
+	result, err := create(ctx, hold)
+	if err != nil {
+		if held && errors.Is(err, ErrPending) {
+			// 작업이 아직 진행 중이므로 기록을 남겨 둠 (재시도는 작업이 끝날 때까지 ErrInProgress)
+			return Result{}, err
+		}
+		// 같은 key로 다시 시도할 수 있도록 pending 기록을 지움 (요청 context가 취소되었어도 정리는 수행)
+		if releaseErr := g.repo.Release(context.WithoutCancel(ctx), recordKey, claim); releaseErr != nil {
+			return Result{}, errors.Join(err, releaseErr)
+		}
+		return Result{}, err
+	}
+
+	// 리소스는 이미 생성되었으므로 기록 실패는 응답을 바꾸지 않음
+	// 끝내 기록하지 못하면 lease가 지난 뒤 같은 key의 재시도가 리소스를 한 번 더 만들 수 있으므로 에러 로그를 남김
+	g.complete(context.WithoutCancel(ctx), recordKey, claim, result.ResourceID, result.Response)
+
+	return result, nil
+}
+
+// complete: pending 기록을 완료로 표시 (일시적인 실패는 몇 번 다시 시도)
+func (g *Guard) complete(ctx context.Context, recordKey string, claim storage.IdempotencyClaim, resourceID string, response []byte) {
+	var err error
+	for attempt := 1; attempt <= completeAttempts; attempt++ {
+		err = g.repo.Complete(ctx, recordKey, claim, resourceID, response)
+		if err == nil || errors.Is(err, storage.ErrIdempotencyClaimLost) {
+			break
+		}
+		if attempt < completeAttempts {
+			_ = retry.Sleep(ctx, completeBackoff.Jitter(attempt))
+		}
+	}
+	if err != nil {
+		logging.FromContext(ctx).Error("idempotency 기록 완료 실패", "idempotency_key", recordKey, "resource_id", resourceID, "error", err)
+	}
+}
+
+// Complete: Do 밖에서 끝난 생성 요청(예: 파드 재시작 후 재개된 saga)의 pending 기록을 완료로 표시
+// Hold로 resourceID를 묶은 같은 요청(requestHash)의 기록일 때만 바꿈 (아니면 storage.ErrIdempotencyClaimLost)
+// response는 재시도에 돌려줄 응답으로, 요청 경로에서 끝났다면 돌려줬을 값과 같아야 함
+func (g *Guard) Complete(ctx context.Context, scope, key, requestHash, resourceID string, response []byte) error {
+	if g == nil || key == "" {
+		return nil
+	}
+	return g.repo.Complete(ctx, recordKey(scope, key), storage.IdempotencyClaim{RequestHash: requestHash, ResourceID: resourceID}, resourceID, response)
+}
+
+// Release: Do 밖에서 실패로 끝난 생성 요청의 pending 기록을 지워 같은 key로 다시 시도할 수 있게 함
+// Complete와 같이 Hold로 resourceID를 묶은 같은 요청의 기록만 지움
+func (g *Guard) Release(ctx context.Context, scope, key, requestHash, resourceID string) error {
+	if g == nil || key == "" {
+		return nil
+	}
+	if resourceID == "" {
+		return fmt.Errorf("%w: resourceID가 비어 있습니다", storage.ErrInvalidArgument)
+	}
+	return g.repo.Release(ctx, recordKey(scope, key), storage.IdempotencyClaim{RequestHash: requestHash, ResourceID: resourceID})
+}
+
+func recordKey(scope, key string) string {
+	return scope + "#" + key
+}
+
+// newToken: pending 기록을 예약한 요청을 구분하는 임의 값
+func newToken() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("idempotency token 생성 실패: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// KeyFrom: 요청 필드 또는 Idempotency-Key 헤더에서 key를 읽음 (둘 다 있으면 같아야 함)
+func KeyFrom(header http.Header, field string) (string, error) {
+	fromHeader := header.Get(HeaderKey)
+	if field != "" && fromHeader != "" && field != fromHeader {
+		return "", fmt.Errorf("%w: 헤더와 요청 필드의 값이 다릅니다", ErrInvalidKey)
+	}
+	if field != "" {
+		return field, nil
+	}
+	return fromHeader, nil
+}
+
+// HashRequest: 요청 내용을 비교하기 위한 해시 (필드 경계가 섞이지 않도록 길이를 함께 기록)
+func HashRequest(parts ...string) string {
+	h := sha256.New()
+	var size [8]byte
+	for _, part := range parts {
+		binary.BigEndian.PutUint64(size[:], uint64(len(part)))
+		h.Write(size[:])
+		h.Write([]byte(part))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,268 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+)
+
+const (
+	testScope = "test"
+	testKey   = "key-1"
+	testHash  = "hash-1"
+)
+
+// guardHarness: 메모리 저장소를 쓰는 Guard와 create 호출 기록
+type guardHarness struct {
+	guard *Guard
+	repo  *storage.MemoryIdempotencyStorage
+	// create가 실행된 횟수
+	calls int
+}
+
+func newGuardHarness(t *testing.T) *guardHarness {
+	t.Helper()
+	repo := storage.NewMemoryIdempotencyStorage()
+	guard, err := NewGuard(repo, time.Hour, time.Minute)
+	if err != nil {
+		t.Fatalf("NewGuard: %v", err)
+	}
+	return &guardHarness{guard: guard, repo: repo}
+}
+
+// create: 호출 횟수를 세고 result/err를 그대로 돌려주는 create
+func (h *guardHarness) create(result Result, err error) func(ctx context.Context, hold Hold) (Result, error) {
+	return func(ctx context.Context, hold Hold) (Result, error) {
+		h.calls++
+		return result, err
+	}
+}
+
+func (h *guardHarness) do(t *testing.T, hash string, create func(ctx context.Context, hold Hold) (Result, error)) (Result, error) {
+	t.Helper()
+	return h.guard.Do(context.Background(), testScope, testKey, hash, create)
+}
+
+var created = Result{ResourceID: "res_1", Response: []byte("first")}
+
+func TestGuardDoRetry(t *testing.T) {
+	errCreate := errors.New("생성 실패")
+
+	tests := []struct {
+		name string
+		// 첫 요청의 create 결과
+		firstErr error
+		// 재시도의 요청 해시
+		retryHash    string
+		wantErr      error
+		wantReplayed bool
+		// 첫 요청과 재시도를 합쳐 create가 실행된 횟수
+		wantCalls int
+	}{
+		{
+			name:         "완료된 요청은 create 없이 기록된 응답을 돌려줌",
+			retryHash:    testHash,
+			wantReplayed: true,
+			wantCalls:    1,
+		},
+		{
+			name:      "같은 key로 다른 요청을 보내면 ErrKeyReused",
+			retryHash: "hash-2",
+			wantErr:   ErrKeyReused,
+			wantCalls: 1,
+		},
+		{
+			// 실패한 요청의 기록은 지워지므로 다시 실행
+			name:      "실패한 요청은 다시 실행",
+			firstErr:  errCreate,
+			retryHash: testHash,
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newGuardHarness(t)
+			first := created
+			if tt.firstErr != nil {
+				first = Result{}
+			}
+			if _, err := h.do(t, testHash, h.create(first, tt.firstErr)); !errors.Is(err, tt.firstErr) {
+				t.Fatalf("첫 요청 Do error = %v, want %v", err, tt.firstErr)
+			}
+
+			got, err := h.do(t, tt.retryHash, h.create(Result{ResourceID: "res_2", Response: []byte("second")}, nil))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("재시도 Do error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("재시도 Do: %v", err)
+			}
+			if h.calls != tt.wantCalls {
+				t.Errorf("create 실행 횟수 = %d, want %d", h.calls, tt.wantCalls)
+			}
+			if got.Replayed != tt.wantReplayed {
+				t.Errorf("Replayed = %t, want %t", got.Replayed, tt.wantReplayed)
+			}
+			if tt.wantReplayed && (got.ResourceID != created.ResourceID || !bytes.Equal(got.Response, created.Response)) {
+				t.Errorf("재사용한 결과 = %s %q, want %s %q", got.ResourceID, got.Response, created.ResourceID, created.Response)
+			}
+		})
+	}
+}
+
+func TestGuardDoInProgress(t *testing.T) {
+	h := newGuardHarness(t)
+
+	var inner error
+	_, err := h.do(t, testHash, func(ctx context.Context, hold Hold) (Result, error) {
+		// 처리 중인 요청과 같은 key의 요청
+		_, inner = h.do(t, testHash, h.create(created, nil))
+		return created, nil
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if !errors.Is(inner, ErrInProgress) {
+		t.Errorf("처리 중인 key의 Do error = %v, want ErrInProgress", inner)
+	}
+	if h.calls != 0 {
+		t.Errorf("처리 중인 key로 create가 %d번 실행됨", h.calls)
+	}
+}
+
+func TestGuardDoWithoutKey(t *testing.T) {
+	h := newGuardHarness(t)
+
+	for i := 0; i < 2; i++ {
+		if _, err := h.guard.Do(context.Background(), testScope, "", testHash, h.create(created, nil)); err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+	}
+	if h.calls != 2 {
+		t.Errorf("key 없는 요청의 create 실행 횟수 = %d, want 2", h.calls)
+	}
+
+	_, err := h.guard.Do(context.Background(), testScope, strings.Repeat("k", MaxKeyLength+1), testHash, h.create(created, nil))
+	if !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("긴 key의 Do error = %v, want ErrInvalidKey", err)
+	}
+}
+
+func TestGuardDoLeaseTakeover(t *testing.T) {
+	ctx := context.Background()
+	h := newGuardHarness(t)
+	// 메모리 저장소는 실제 시각으로 lease를 확인하므로, 첫 요청은 과거 시각으로 예약해 lease가 이미 지난 상태로 만듦
+	h.guard.now = func() time.Time { return time.Now().Add(-time.Hour) }
+
+	var holdErr error
+	_, err := h.do(t, testHash, func(ctx context.Context, hold Hold) (Result, error) {
+		// 첫 요청을 처리하던 인스턴스가 멈춘 사이 같은 key의 재시도가 기록을 가져감
+		h.guard.now = time.Now
+		takeover, err := h.do(t, testHash, h.create(Result{ResourceID: "res_2", Response: []byte("second")}, nil))
+		if err != nil || takeover.Replayed {
+			t.Fatalf("lease가 지난 기록의 Do = %+v, %v, want 새로 실행", takeover, err)
+		}
+
+		// 기록을 잃은 첫 요청은 리소스 ID를 묶지 못함
+		holdErr = hold(ctx, "res_1")
+		return Result{}, holdErr
+	})
+	if !errors.Is(holdErr, ErrInProgress) || !errors.Is(err, ErrInProgress) {
+		t.Fatalf("기록을 잃은 요청의 Hold = %v, Do = %v, want ErrInProgress", holdErr, err)
+	}
+
+	// 첫 요청의 정리(Release)는 가져간 요청의 기록을 지우지 않음
+	got, err := h.do(t, testHash, h.create(created, nil))
+	if err != nil || !got.Replayed || got.ResourceID != "res_2" {
+		t.Errorf("재시도 = %+v, %v, want res_2 재사용", got, err)
+	}
+	if h.calls != 1 {
+		t.Errorf("create 실행 횟수 = %d, want 1", h.calls)
+	}
+
+	// 가져간 요청의 기록은 첫 요청의 token으로 완료할 수 없음
+	err = h.repo.Complete(ctx, recordKey(testScope, testKey), storage.IdempotencyClaim{Token: "stale"}, "res_1", nil)
+	if !errors.Is(err, storage.ErrIdempotencyClaimLost) {
+		t.Errorf("다른 token의 Complete = %v, want ErrIdempotencyClaimLost", err)
+	}
+}
+
+func TestGuardDoPending(t *testing.T) {
+	tests := []struct {
+		name string
+		// 요청 경로 밖에서 작업을 끝내는 방법
+		finish       func(ctx context.Context, g *Guard) error
+		wantReplayed bool
+		// 정리 후 재시도까지 create가 실행된 횟수
+		wantCalls int
+	}{
+		{
+			name: "작업이 끝나면 Complete한 응답을 돌려줌",
+			finish: func(ctx context.Context, g *Guard) error {
+				return g.Complete(ctx, testScope, testKey, testHash, "res_1", []byte("recovered"))
+			},
+			wantReplayed: true,
+			wantCalls:    1,
+		},
+		{
+			name: "작업이 실패하면 Release 후 다시 실행",
+			finish: func(ctx context.Context, g *Guard) error {
+				return g.Release(ctx, testScope, testKey, testHash, "res_1")
+			},
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			h := newGuardHarness(t)
+
+			_, err := h.do(t, testHash, func(ctx context.Context, hold Hold) (Result, error) {
+				h.calls++
+				if err := hold(ctx, "res_1"); err != nil {
+					return Result{}, err
+				}
+				return Result{}, ErrPending
+			})
+			if !errors.Is(err, ErrPending) {
+				t.Fatalf("Do error = %v, want ErrPending", err)
+			}
+
+			// Hold로 묶은 기록은 작업이 끝날 때까지 남아 있음
+			if _, err := h.do(t, testHash, h.create(created, nil)); !errors.Is(err, ErrInProgress) {
+				t.Fatalf("작업 중 재시도 error = %v, want ErrInProgress", err)
+			}
+
+			// 다른 요청이나 다른 리소스로는 정리할 수 없음
+			if err := h.guard.Complete(ctx, testScope, testKey, "hash-2", "res_1", nil); !errors.Is(err, storage.ErrIdempotencyClaimLost) {
+				t.Fatalf("다른 요청의 Complete = %v, want ErrIdempotencyClaimLost", err)
+			}
+			if err := h.guard.Complete(ctx, testScope, testKey, testHash, "res_2", nil); !errors.Is(err, storage.ErrIdempotencyClaimLost) {
+				t.Fatalf("다른 리소스의 Complete = %v, want ErrIdempotencyClaimLost", err)
+			}
+
+			if err := tt.finish(ctx, h.guard); err != nil {
+				t.Fatalf("작업 정리: %v", err)
+			}
+			got, err := h.do(t, testHash, h.create(created, nil))
+			if err != nil {
+				t.Fatalf("정리 후 재시도: %v", err)
+			}
+			if got.Replayed != tt.wantReplayed {
+				t.Errorf("Replayed = %t, want %t", got.Replayed, tt.wantReplayed)
+			}
+			if tt.wantReplayed && (got.ResourceID != "res_1" || string(got.Response) != "recovered") {
+				t.Errorf("재사용한 결과 = %s %q, want res_1 \"recovered\"", got.ResourceID, got.Response)
+			}
+			if h.calls != tt.wantCalls {
+				t.Errorf("create 실행 횟수 = %d, want %d", h.calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package ids
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ID 접두사 (리소스 종류를 ID만 보고 구분할 수 있도록 함)
+const (
+	PrefixUser    = "user_"
+	PrefixOrder   = "order_"
+	PrefixProduct = "prod_"
+	PrefixEvent   = "evt_"
+	PrefixRequest = "req_"
+)
+
+// Crockford base32 (I, L, O, U 제외)
+const encoding = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID 구성: 48비트 밀리초 타임스탬프 + 80비트 난수
+const (
+	timeBytes    = 6
+	entropyBytes = 10
+	encodedLen   = 26
+	maxTimestamp = 1<<48 - 1
+)
+
+var errEntropyOverflow = errors.New("같은 밀리초 안에서 생성 가능한 ID를 모두 사용했습니다")
+
+// Generator: 시간 순으로 정렬되는 ULID 형식의 ID 생성기
+// 같은 밀리초 안에서는 직전 난수에 1을 더해 프로세스 내에서 단조 증가를 보장함
+type Generator struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	entropy io.Reader
+
+	started     bool
+	lastMS      uint64
+	lastEntropy [entropyBytes]byte
+}
+
+type Option func(*Generator)
+
+// WithClock: 테스트에서 시간을 고정할 때 사용
+func WithClock(now func() time.Time) Option {
+	return func(g *Generator) {
+		g.now = now
+	}
+}
+
+// WithEntropy: 테스트에서 난수를 고정할 때 사용 (기본값 crypto/rand)
+func WithEntropy(r io.Reader) Option {
+	return func(g *Generator) {
+		g.entropy = r
+	}
+}
+
+func NewGenerator(opts ...Option) *Generator {
+	g := &Generator{
+		now:     time.Now,
+		entropy: rand.Reader,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// New: prefix + 26자 ULID 문자열을 생성
+func (g *Generator) New(prefix string) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(g.now().UnixMilli())
+	if ms > maxTimestamp {
+		return "", fmt.Errorf("ID 타임스탬프 범위를 벗어났습니다: %d", ms)
+	}
+
+	// 시계가 뒤로 가더라도 직전 ID보다 작아지지 않도록 마지막 시각을 유지
+	if g.started && ms <= g.lastMS {
+		ms = g.lastMS
+		if !increment(&g.lastEntropy) {
+			return "", errEntropyOverflow
+		}
+	} else {
+		if _, err := io.ReadFull(g.entropy, g.lastEntropy[:]); err != nil {
+			return "", fmt.Errorf("난수 생성 실패: %w", err)
+		}
+		g.lastMS = ms
+		g.started = true
+	}
+
+	var raw [timeBytes + entropyBytes]byte
+	for i := 0; i < timeBytes; i++ {
+		raw[i] = byte(ms >> (8 * (timeBytes - 1 - i)))
+	}
+	copy(raw[timeBytes:], g.lastEntropy[:])
+
+	return prefix + encode(raw), nil
+}
+
+// increment: 80비트 난수를 1 증가 (overflow 시 false)
+func increment(b *[entropyBytes]byte) bool {
+	for i := entropyBytes - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encode: 128비트 값을 26자 Crockford base32로 인코딩 (앞의 2비트는 항상 0)
+func encode(raw [timeBytes + entropyBytes]byte) string {
+	var out [encodedLen]byte
+	// 128비트를 뒤에서부터 5비트씩 잘라 문자로 변환
+	var acc uint32
+	var bits uint
+	pos := encodedLen - 1
+	for i := len(raw) - 1; i >= 0; i-- {
+		acc |= uint32(raw[i]) << bits
+		bits += 8
+		for bits >= 5 {
+			out[pos] = encoding[acc&0x1f]
+			pos--
+			acc >>= 5
+			bits -= 5
+		}
+	}
+	if pos >= 0 {
+		out[pos] = encoding[acc&0x1f]
+	}
+	return string(out[:])
+}
+
+var defaultGenerator = NewGenerator()
+
+// NewUserID: 기본 생성기로 사용자 ID 생성
+func NewUserID() (string, error) {
+	return defaultGenerator.New(PrefixUser)
+}
+
+// NewOrderID: 기본 생성기로 주문 ID 생성
+func NewOrderID() (string, error) {
+	return defaultGenerator.New(PrefixOrder)
+}
+
+// NewProductID: 기본 생성기로 상품 ID 생성
+func NewProductID() (string, error) {
+	return defaultGenerator.New(PrefixProduct)
+}
+
+// NewEventID: 기본 생성기로 도메인 이벤트 ID 생성
+func NewEventID() (string, error) {
+	return defaultGenerator.New(PrefixEvent)
+}
+
+// NewRequestID: 기본 생성기로 요청 ID(X-Request-Id) 생성
+func NewRequestID() (string, error) {
+	return defaultGenerator.New(PrefixRequest)
+}
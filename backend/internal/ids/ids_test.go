@@ -0,0 +1,118 @@
+package ids
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stepClock: 호출할 때마다 times를 차례로 돌려주는 시계 (마지막 값은 계속 반복)
+func stepClock(times ...time.Time) func() time.Time {
+	i := 0
+	return func() time.Time {
+		t := times[i]
+		if i < len(times)-1 {
+			i++
+		}
+		return t
+	}
+}
+
+func TestGeneratorEncoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		ms      int64
+		entropy []byte
+		want    string
+	}{
+		{
+			name:    "모두 0",
+			ms:      0,
+			entropy: make([]byte, entropyBytes),
+			want:    "00000000000000000000000000",
+		},
+		{
+			// ULID 명세의 예시 타임스탬프
+			name:    "타임스탬프 부분",
+			ms:      1469918176385,
+			entropy: make([]byte, entropyBytes),
+			want:    "01ARYZ6S410000000000000000",
+		},
+		{
+			name:    "난수 최댓값",
+			ms:      0,
+			entropy: bytes.Repeat([]byte{0xFF}, entropyBytes),
+			want:    "0000000000ZZZZZZZZZZZZZZZZ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGenerator(
+				WithClock(func() time.Time { return time.UnixMilli(tt.ms) }),
+				WithEntropy(bytes.NewReader(tt.entropy)),
+			)
+			got, err := g.New(PrefixOrder)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			if got != PrefixOrder+tt.want {
+				t.Errorf("New = %q, want %q", got, PrefixOrder+tt.want)
+			}
+		})
+	}
+}
+
+func TestGeneratorMonotonic(t *testing.T) {
+	base := time.UnixMilli(1735787045000)
+
+	tests := []struct {
+		name  string
+		times []time.Time
+	}{
+		{name: "같은 밀리초", times: []time.Time{base}},
+		{name: "시간이 흐름", times: []time.Time{base, base.Add(time.Millisecond), base.Add(2 * time.Millisecond)}},
+		{name: "시계가 뒤로 감", times: []time.Time{base, base.Add(-time.Second), base.Add(-time.Millisecond)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGenerator(WithClock(stepClock(tt.times...)))
+
+			prev := ""
+			for i := 0; i < 1000; i++ {
+				id, err := g.New(PrefixEvent)
+				if err != nil {
+					t.Fatalf("New #%d: %v", i, err)
+				}
+				if len(id) != len(PrefixEvent)+encodedLen || !strings.HasPrefix(id, PrefixEvent) {
+					t.Fatalf("New #%d = %q: 형식이 올바르지 않음", i, id)
+				}
+				if id <= prev {
+					t.Fatalf("New #%d = %q, 직전 ID %q보다 커야 함", i, id, prev)
+				}
+				prev = id
+			}
+		})
+	}
+}
+
+func TestGeneratorEntropyOverflow(t *testing.T) {
+	g := NewGenerator(
+		WithClock(func() time.Time { return time.UnixMilli(1) }),
+		WithEntropy(bytes.NewReader(bytes.Repeat([]byte{0xFF}, entropyBytes))),
+	)
+	if _, err := g.New(PrefixUser); err != nil {
+		t.Fatalf("첫 New: %v", err)
+	}
+	if _, err := g.New(PrefixUser); !errors.Is(err, errEntropyOverflow) {
+		t.Fatalf("같은 밀리초의 두 번째 New error = %v, want errEntropyOverflow", err)
+	}
+}
+
+func TestGeneratorTimestampRange(t *testing.T) {
+	g := NewGenerator(WithClock(func() time.Time { return time.UnixMilli(maxTimestamp + 1) }))
+	if _, err := g.New(PrefixUser); err == nil {
+		t.Fatal("48비트를 넘는 타임스탬프: want error")
+	}
+}
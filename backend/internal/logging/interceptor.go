@@ -0,0 +1,117 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"go.opentelemetry.io/otel/trace"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/ids"
+)
+
+// ServerInterceptor: 처리한 RPC마다 procedure, 결과 코드, 처리 시간, 호출한 쪽 주소, 요청 ID를 로그로 남김
+// X-Request-Id 헤더가 있으면 그 값을, 없으면 새로 만든 값을 요청 ID로 쓰고 응답 헤더에도 돌려줌
+// 핸들러에는 요청 ID(와 trace ID)가 붙은 로거를 ctx에 담아 넘기므로 FromContext로 꺼내 쓰면 됨
+// (tracing interceptor 안쪽에 두어야 로그에 trace_id가 남음)
+func ServerInterceptor() connect.Interceptor {
+	return serverInterceptor{}
+}
+
+type serverInterceptor struct{}
+
+func (serverInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx, done := begin(ctx, req.Spec().Procedure, req.Peer(), req.Header())
+		resp, err := next(ctx, req)
+		done(err)
+
+		// 실패하면 resp는 타입이 있는 nil이므로 에러의 메타데이터에 붙임
+		requestID := RequestIDFromContext(ctx)
+		if err == nil {
+			resp.Header().Set(RequestIDHeader, requestID)
+		} else if connectErr := new(connect.Error); errors.As(err, &connectErr) {
+			connectErr.Meta().Set(RequestIDHeader, requestID)
+		}
+		return resp, err
+	}
+}
+
+func (serverInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (serverInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, done := begin(ctx, conn.Spec().Procedure, conn.Peer(), conn.RequestHeader())
+		conn.ResponseHeader().Set(RequestIDHeader, RequestIDFromContext(ctx))
+		err := next(ctx, conn)
+		done(err)
+		return err
+	}
+}
+
+// begin: 요청 ID를 정하고 요청 로거를 ctx에 담은 뒤, RPC가 끝나면 호출할 로그 함수를 반환
+func begin(ctx context.Context, procedure string, peer connect.Peer, header http.Header) (context.Context, func(err error)) {
+	start := time.Now()
+
+	requestID := header.Get(RequestIDHeader)
+	if !validRequestID(requestID) {
+		// ID를 만들지 못해도 요청은 처리하고 요청 ID 없이 로그를 남김
+		requestID, _ = ids.NewRequestID()
+	}
+
+	attrs := []any{slog.String("request_id", requestID), slog.String("procedure", procedure)}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		attrs = append(attrs, slog.String("trace_id", spanContext.TraceID().String()))
+	}
+	logger := slog.Default().With(attrs...)
+	ctx = WithRequestID(WithLogger(ctx, logger), requestID)
+
+	return ctx, func(err error) {
+		level := slog.LevelInfo
+		code := "ok"
+		if err != nil {
+			code = connect.CodeOf(err).String()
+			if isServerError(connect.CodeOf(err)) {
+				level = slog.LevelError
+			}
+		}
+		logAttrs := []slog.Attr{
+			slog.String("code", code),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("peer", peer.Addr),
+			slog.String("protocol", peer.Protocol),
+		}
+		if err != nil {
+			logAttrs = append(logAttrs, slog.String("error", err.Error()))
+		}
+		logger.LogAttrs(ctx, level, "RPC 처리", logAttrs...)
+	}
+}
+
+// isServerError: 서비스 쪽 문제로 실패한 경우 (요청 값이나 상태 때문에 거절한 경우는 제외)
+func isServerError(code connect.Code) bool {
+	switch code {
+	case connect.CodeUnknown, connect.CodeInternal, connect.CodeDataLoss, connect.CodeUnimplemented,
+		connect.CodeUnavailable, connect.CodeDeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// ClientInterceptor: 처리 중인 요청의 ID를 다른 서비스 호출에 X-Request-Id 헤더로 붙임
+// (호출받은 서비스도 같은 요청 ID로 로그를 남기므로 서비스를 넘나들며 한 요청의 로그를 찾을 수 있음)
+func ClientInterceptor() connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if requestID := RequestIDFromContext(ctx); requestID != "" && req.Header().Get(RequestIDHeader) == "" {
+				req.Header().Set(RequestIDHeader, requestID)
+			}
+			return next(ctx, req)
+		}
+	})
+}
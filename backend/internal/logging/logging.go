@@ -0,0 +1,38 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+)
+
+// Setup: 표준 출력에 JSON으로 쓰는 로거를 기본 로거(slog.Default)로 설정
+// 모든 로그에 service 속성을 붙이며, log 패키지로 남긴 로그도 같은 형식으로 나감
+func Setup(service string, level slog.Level) *slog.Logger {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level})).
+		With("service", service)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// Fatal: 에러 로그를 남기고 프로세스 종료 (main의 초기화 실패용)
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+type loggerKey struct{}
+
+// WithLogger: ctx에 로거를 담음 (RPC interceptor가 request_id, procedure 등을 붙인 로거를 담아 둠)
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext: ctx에 담긴 로거, 없으면 기본 로거
+// store, storage처럼 요청을 처리하는 코드는 이 로거로 남겨야 요청 ID로 로그를 묶어 볼 수 있음
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,41 @@
+package logging
+
+import (
+	"context"
+)
+
+// RequestIDHeader: 요청 ID를 주고받는 헤더
+// 받은 요청에 있으면 그대로 쓰고, 없으면 새로 만들어 응답과 다른 서비스 호출에 붙임
+const RequestIDHeader = "X-Request-Id"
+
+// 받은 요청 ID를 그대로 쓸 수 있는 최대 길이
+const maxRequestIDLen = 128
+
+type requestIDKey struct{}
+
+// WithRequestID: ctx에 요청 ID를 담음
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext: ctx에 담긴 요청 ID (없으면 빈 문자열)
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// validRequestID: 로그와 헤더에 그대로 옮겨도 되는 값인지 (길이 제한, 영문/숫자와 - _ . : 만 허용)
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLen {
+		return false
+	}
+	for _, r := range requestID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
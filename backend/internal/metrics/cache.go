@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/cache"
+)
+
+// cacheCollector: 수집할 때마다 캐시의 누적 카운터를 읽어 지표로 내보냄
+type cacheCollector struct {
+	stats func() cache.Stats
+
+	hits, negativeHits, misses, shared, loadErrors, evictions, invalidations, size *prometheus.Desc
+}
+
+// RegisterCache: name 캐시의 hit/miss 등 카운터를 지표로 등록 (캐시마다 한 번만 호출)
+func RegisterCache(name string, stats func() cache.Stats) error {
+	labels := prometheus.Labels{"cache": name}
+	desc := func(metric, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "cache", metric), help, nil, labels)
+	}
+	return prometheus.Register(&cacheCollector{
+		stats:         stats,
+		hits:          desc("hits_total", "캐시에 있던 값을 돌려준 횟수"),
+		negativeHits:  desc("negative_hits_total", "캐시에 있던 없음(NotFound) 결과를 돌려준 횟수"),
+		misses:        desc("misses_total", "캐시에 없어 원본을 읽은 횟수"),
+		shared:        desc("shared_loads_total", "진행 중인 원본 읽기 결과를 나눠 받은 횟수"),
+		loadErrors:    desc("load_errors_total", "원본 읽기가 실패한 횟수"),
+		evictions:     desc("evictions_total", "용량이 차서 버린 항목 수"),
+		invalidations: desc("invalidations_total", "변경 이벤트로 지운 횟수"),
+		size:          desc("entries", "현재 항목 수"),
+	})
+}
+
+func (c *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{c.hits, c.negativeHits, c.misses, c.shared, c.loadErrors, c.evictions, c.invalidations, c.size} {
+		ch <- d
+	}
+}
+
+func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.stats()
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(s.Hits))
+	ch <- prometheus.MustNewConstMetric(c.negativeHits, prometheus.CounterValue, float64(s.NegativeHits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(s.Misses))
+	ch <- prometheus.MustNewConstMetric(c.shared, prometheus.CounterValue, float64(s.Shared))
+	ch <- prometheus.MustNewConstMetric(c.loadErrors, prometheus.CounterValue, float64(s.LoadErrors))
+	ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(s.Evictions))
+	ch <- prometheus.MustNewConstMetric(c.invalidations, prometheus.CounterValue, float64(s.Invalidations))
+	ch <- prometheus.MustNewConstMetric(c.size, prometheus.GaugeValue, float64(s.Size))
+}
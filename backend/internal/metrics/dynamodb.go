@@ -0,0 +1,170 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	dynamoRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "dynamodb",
+		Name:      "requests_total",
+		Help:      "DynamoDB 요청 수 (operation, 결과별. SDK 재시도를 포함한 요청 하나를 한 번으로 셈)",
+	}, []string{"operation", "result"})
+	dynamoLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "dynamodb",
+		Name:      "request_duration_seconds",
+		Help:      "DynamoDB 요청 시간 (SDK 재시도 포함)",
+		Buckets:   latencyBuckets,
+	}, []string{"operation", "result"})
+	dynamoThrottles = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "dynamodb",
+		Name:      "throttled_attempts_total",
+		Help:      "처리량 제한으로 거절된 DynamoDB 요청 시도 수 (SDK가 재시도해 성공한 시도 포함)",
+	}, []string{"operation"})
+	dynamoCapacity = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "dynamodb",
+		Name:      "consumed_capacity_units_total",
+		Help:      "DynamoDB가 보고한 소비 용량 (테이블별)",
+	}, []string{"operation", "table"})
+)
+
+// DynamoDBMiddleware: DynamoDB 클라이언트의 APIOptions에 추가하는 지표 middleware
+// operation별 요청 수, 시간, 처리량 제한, 소비 용량(ReturnConsumedCapacity를 TOTAL로 요청)을 기록
+func DynamoDBMiddleware(stack *middleware.Stack) error {
+	// 서비스 메타데이터(operation 이름)가 ctx에 기록된 뒤 실행되도록 Initialize 단계 마지막에 둠
+	if err := stack.Initialize.Add(middleware.InitializeMiddlewareFunc("MetricsObserveOperation", observeDynamoOperation), middleware.After); err != nil {
+		return err
+	}
+	// 재시도되는 시도마다 역직렬화된 에러를 보도록 Deserialize 단계 처음에 둠
+	return stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("MetricsObserveAttempt", observeDynamoAttempt), middleware.Before)
+}
+
+func observeDynamoOperation(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+	operation := awsmiddleware.GetOperationName(ctx)
+	requestConsumedCapacity(in.Parameters)
+
+	start := time.Now()
+	out, metadata, err := next.HandleInitialize(ctx, in)
+
+	result := dynamoResult(err)
+	dynamoRequests.WithLabelValues(operation, result).Inc()
+	dynamoLatency.WithLabelValues(operation, result).Observe(time.Since(start).Seconds())
+	if err == nil {
+		for _, capacity := range consumedCapacity(out.Result) {
+			dynamoCapacity.WithLabelValues(operation, aws.ToString(capacity.TableName)).Add(aws.ToFloat64(capacity.CapacityUnits))
+		}
+	}
+	return out, metadata, err
+}
+
+func observeDynamoAttempt(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (middleware.DeserializeOutput, middleware.Metadata, error) {
+	out, metadata, err := next.HandleDeserialize(ctx, in)
+	if isThrottle(err) {
+		dynamoThrottles.WithLabelValues(awsmiddleware.GetOperationName(ctx)).Inc()
+	}
+	return out, metadata, err
+}
+
+// dynamoResult: 성공이면 "ok", 실패면 AWS 에러 코드 (API 에러가 아니면 "error")
+func dynamoResult(err error) string {
+	if err == nil {
+		return "ok"
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode()
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "canceled"
+	}
+	return "error"
+}
+
+func isThrottle(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.ErrorCode() {
+	case "ProvisionedThroughputExceededException", "ThrottlingException", "RequestLimitExceeded":
+		return true
+	}
+	return false
+}
+
+// requestConsumedCapacity: 호출자가 따로 지정하지 않았으면 소비 용량을 응답에 포함하도록 요청
+func requestConsumedCapacity(params any) {
+	switch in := params.(type) {
+	case *dynamodb.GetItemInput:
+		setTotal(&in.ReturnConsumedCapacity)
+	case *dynamodb.PutItemInput:
+		setTotal(&in.ReturnConsumedCapacity)
+	case *dynamodb.UpdateItemInput:
+		setTotal(&in.ReturnConsumedCapacity)
+	case *dynamodb.DeleteItemInput:
+		setTotal(&in.ReturnConsumedCapacity)
+	case *dynamodb.QueryInput:
+		setTotal(&in.ReturnConsumedCapacity)
+	case *dynamodb.ScanInput:
+		setTotal(&in.ReturnConsumedCapacity)
+	case *dynamodb.TransactWriteItemsInput:
+		setTotal(&in.ReturnConsumedCapacity)
+	case *dynamodb.TransactGetItemsInput:
+		setTotal(&in.ReturnConsumedCapacity)
+	case *dynamodb.BatchGetItemInput:
+		setTotal(&in.ReturnConsumedCapacity)
+	case *dynamodb.BatchWriteItemInput:
+		setTotal(&in.ReturnConsumedCapacity)
+	}
+}
+
+func setTotal(v *types.ReturnConsumedCapacity) {
+	if *v == "" {
+		*v = types.ReturnConsumedCapacityTotal
+	}
+}
+
+func consumedCapacity(result any) []types.ConsumedCapacity {
+	var single *types.ConsumedCapacity
+	switch out := result.(type) {
+	case *dynamodb.GetItemOutput:
+		single = out.ConsumedCapacity
+	case *dynamodb.PutItemOutput:
+		single = out.ConsumedCapacity
+	case *dynamodb.UpdateItemOutput:
+		single = out.ConsumedCapacity
+	case *dynamodb.DeleteItemOutput:
+		single = out.ConsumedCapacity
+	case *dynamodb.QueryOutput:
+		single = out.ConsumedCapacity
+	case *dynamodb.ScanOutput:
+		single = out.ConsumedCapacity
+	case *dynamodb.TransactWriteItemsOutput:
+		return out.ConsumedCapacity
+	case *dynamodb.TransactGetItemsOutput:
+		return out.ConsumedCapacity
+	case *dynamodb.BatchGetItemOutput:
+		return out.ConsumedCapacity
+	case *dynamodb.BatchWriteItemOutput:
+		return out.ConsumedCapacity
+	}
+	if single == nil {
+		return nil
+	}
+	return []types.ConsumedCapacity{*single}
+}
@@ -0,0 +1,18 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// 모든 지표 이름 앞에 붙는 namespace
+const namespace = "msa"
+
+// 지연 시간 histogram 구간 (초): 캐시 hit 수준(1ms)부터 재시도가 겹친 호출(10s)까지
+var latencyBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Handler: Prometheus가 수집하는 /metrics 핸들러 (Go 런타임, 프로세스 지표 포함)
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
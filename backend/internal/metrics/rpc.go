@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	serverRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rpc_server",
+		Name:      "requests_total",
+		Help:      "처리한 RPC 수 (procedure, 결과 코드별)",
+	}, []string{"procedure", "code"})
+	serverLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "rpc_server",
+		Name:      "request_duration_seconds",
+		Help:      "RPC 처리 시간",
+		Buckets:   latencyBuckets,
+	}, []string{"procedure", "code"})
+	serverInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "rpc_server",
+		Name:      "requests_in_flight",
+		Help:      "처리 중인 RPC 수",
+	}, []string{"procedure"})
+
+	clientRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rpc_client",
+		Name:      "requests_total",
+		Help:      "다른 서비스 호출 수 (재시도를 포함한 호출 하나를 한 번으로 셈)",
+	}, []string{"target", "procedure", "code"})
+	clientLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "rpc_client",
+		Name:      "request_duration_seconds",
+		Help:      "다른 서비스 호출 시간 (재시도 대기 포함)",
+		Buckets:   latencyBuckets,
+	}, []string{"target", "procedure", "code"})
+	clientAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rpc_client",
+		Name:      "attempts_total",
+		Help:      "다른 서비스 호출 시도 수 (시도마다 셈, 결과 코드별)",
+	}, []string{"target", "procedure", "code"})
+	clientBreakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "rpc_client",
+		Name:      "circuit_breaker_state",
+		Help:      "circuit breaker 상태 (0: closed, 1: open, 2: half-open)",
+	}, []string{"target"})
+	clientBreakerRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rpc_client",
+		Name:      "circuit_breaker_rejected_total",
+		Help:      "circuit breaker가 열려 있어 보내지 않은 호출 시도 수",
+	}, []string{"target"})
+)
+
+// ServerInterceptor: 서비스 핸들러가 처리한 RPC의 수, 처리 시간, 결과 코드를 procedure별로 기록
+func ServerInterceptor() connect.Interceptor {
+	return serverInterceptor{}
+}
+
+type serverInterceptor struct{}
+
+func (serverInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		procedure := req.Spec().Procedure
+		done := observeServer(procedure)
+		resp, err := next(ctx, req)
+		done(err)
+		return resp, err
+	}
+}
+
+func (serverInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (serverInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		done := observeServer(conn.Spec().Procedure)
+		err := next(ctx, conn)
+		done(err)
+		return err
+	}
+}
+
+func observeServer(procedure string) func(err error) {
+	start := time.Now()
+	serverInFlight.WithLabelValues(procedure).Inc()
+	return func(err error) {
+		serverInFlight.WithLabelValues(procedure).Dec()
+		code := codeLabel(err)
+		serverRequests.WithLabelValues(procedure, code).Inc()
+		serverLatency.WithLabelValues(procedure, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ClientInterceptor: target 서비스 호출의 수, 시간(재시도 포함), 결과 코드를 procedure별로 기록
+func ClientInterceptor(target string) connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+
+			procedure := req.Spec().Procedure
+			code := codeLabel(err)
+			clientRequests.WithLabelValues(target, procedure, code).Inc()
+			clientLatency.WithLabelValues(target, procedure, code).Observe(time.Since(start).Seconds())
+			return resp, err
+		}
+	})
+}
+
+// ObserveClientAttempt: 재시도 중 시도 하나의 결과 기록
+func ObserveClientAttempt(target, procedure string, err error) {
+	clientAttempts.WithLabelValues(target, procedure, codeLabel(err)).Inc()
+}
+
+// SetCircuitBreakerState: circuit breaker 상태 기록 (0: closed, 1: open, 2: half-open)
+func SetCircuitBreakerState(target string, state int) {
+	clientBreakerState.WithLabelValues(target).Set(float64(state))
+}
+
+// ObserveCircuitBreakerRejected: circuit breaker가 열려 있어 보내지 않은 시도 기록
+func ObserveCircuitBreakerRejected(target string) {
+	clientBreakerRejected.WithLabelValues(target).Inc()
+}
+
+// codeLabel: 에러가 없으면 "ok", 있으면 connect 코드 이름
+func codeLabel(err error) string {
+	if err == nil {
+		return "ok"
+	}
+	return connect.CodeOf(err).String()
+}
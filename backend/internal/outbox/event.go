@@ -0,0 +1,80 @@
+package outbox
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	eventspb "Acho-mj/2025_Golang_MSA/backend/gen/events"
+	"Acho-mj/2025_Golang_MSA/backend/internal/ids"
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+)
+
+// 이벤트를 기록하는 서비스 (relay는 자기 서비스의 이벤트만 발행)
+const (
+	SourceUser  = "user"
+	SourceOrder = "order"
+	// 주문 상태 변경 후 재고 예약을 확정/취소하는 작업 (발행하지 않고 order 서비스의 worker가 처리)
+	SourceOrderStock = "order-stock"
+)
+
+// NewRecord: payload를 채운 이벤트에 ID/종류/발생 시각을 붙여 outbox 기록으로 만듦
+func NewRecord(source, aggregateID string, event *eventspb.Event) (*storage.OutboxRecord, error) {
+	if source == "" || aggregateID == "" {
+		return nil, errors.New("이벤트 source와 aggregateID는 필수입니다")
+	}
+	eventType, err := payloadType(event)
+	if err != nil {
+		return nil, err
+	}
+
+	eventID, err := ids.NewEventID()
+	if err != nil {
+		return nil, fmt.Errorf("이벤트 ID 생성 실패: %w", err)
+	}
+	now := time.Now().UTC()
+
+	event = proto.Clone(event).(*eventspb.Event)
+	event.EventId = eventID
+	event.EventType = eventType
+	event.AggregateId = aggregateID
+	event.OccurredAt = now.Format(time.RFC3339Nano)
+
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("이벤트 marshal 실패: %w", err)
+	}
+
+	return &storage.OutboxRecord{
+		EventID:     eventID,
+		EventType:   eventType,
+		Source:      source,
+		AggregateID: aggregateID,
+		Payload:     payload,
+		CreatedAt:   now,
+	}, nil
+}
+
+// Decode: outbox 기록에서 이벤트를 복원
+func Decode(record *storage.OutboxRecord) (*eventspb.Event, error) {
+	var event eventspb.Event
+	if err := proto.Unmarshal(record.Payload, &event); err != nil {
+		return nil, fmt.Errorf("이벤트 언마샬 실패: %w", err)
+	}
+	return &event, nil
+}
+
+// payloadType: oneof payload에 담긴 메시지 이름 (예: events.OrderCreated)
+func payloadType(event *eventspb.Event) (string, error) {
+	if event == nil {
+		return "", errors.New("이벤트가 nil입니다")
+	}
+	msg := event.ProtoReflect()
+	field := msg.WhichOneof(msg.Descriptor().Oneofs().ByName("payload"))
+	if field == nil {
+		return "", errors.New("이벤트 payload가 비어 있습니다")
+	}
+	return string(field.Message().FullName()), nil
+}
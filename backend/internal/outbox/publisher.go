@@ -0,0 +1,240 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	eventspb "Acho-mj/2025_Golang_MSA/backend/gen/events"
+	"Acho-mj/2025_Golang_MSA/backend/internal/config"
+	"Acho-mj/2025_Golang_MSA/backend/internal/logging"
+)
+
+const (
+	// MemoryPublisher가 보관하는 최근 이벤트 수
+	defaultMemoryCapacity = 1000
+	// webhook 요청 제한 시간
+	defaultWebhookTimeout = 10 * time.Second
+)
+
+// EventPublisher: outbox 이벤트를 외부로 내보내는 방식
+// 실패하면 relay가 나중에 다시 호출하므로 같은 이벤트가 여러 번 전달될 수 있음
+type EventPublisher interface {
+	Publish(ctx context.Context, event *eventspb.Event) error
+}
+
+// NewPublisher: EVENT_PUBLISHER 설정에 맞는 EventPublisher를 생성
+// memory는 발행한 이벤트를 파드 메모리에만 남기므로 STORAGE_BACKEND=memory에서만 허용
+// (DynamoDB outbox의 이벤트를 memory로 발행하면 발행된 것으로 표시된 채 사라짐)
+// EVENT_FANOUT_URLS가 있으면 같은 이벤트를 그 URL로도 보냄
+func NewPublisher(cfg *config.Config) (EventPublisher, error) {
+	publisher, err := newConfiguredPublisher(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.EventFanoutURLs) == 0 {
+		return publisher, nil
+	}
+
+	fanout, err := NewFanoutPublisher(cfg.EventFanoutURLs, nil)
+	if err != nil {
+		return nil, err
+	}
+	return multiPublisher{publisher, fanout}, nil
+}
+
+func newConfiguredPublisher(cfg *config.Config) (EventPublisher, error) {
+	switch cfg.EventPublisher {
+	case "", config.EventPublisherMemory:
+		if cfg.StorageBackend != config.StorageBackendMemory {
+			return nil, fmt.Errorf("STORAGE_BACKEND=%s이면 EVENT_PUBLISHER를 webhook으로 지정해야 함 (memory는 STORAGE_BACKEND=memory에서만 사용 가능)", cfg.StorageBackend)
+		}
+		return NewMemoryPublisher(defaultMemoryCapacity), nil
+	case config.EventPublisherWebhook:
+		return NewWebhookPublisher(cfg.EventWebhookURL, nil)
+	}
+	return nil, fmt.Errorf("지원하지 않는 EVENT_PUBLISHER: %s", cfg.EventPublisher)
+}
+
+// MemoryPublisher: 최근 이벤트를 메모리에 보관 (로컬 실행/디버깅용)
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	capacity int
+	events   []*eventspb.Event
+}
+
+func NewMemoryPublisher(capacity int) *MemoryPublisher {
+	if capacity <= 0 {
+		capacity = defaultMemoryCapacity
+	}
+	return &MemoryPublisher{capacity: capacity}
+}
+
+func (p *MemoryPublisher) Publish(ctx context.Context, event *eventspb.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, proto.Clone(event).(*eventspb.Event))
+	if len(p.events) > p.capacity {
+		p.events = p.events[len(p.events)-p.capacity:]
+	}
+	return nil
+}
+
+// Events: 보관 중인 이벤트 (오래된 순)
+func (p *MemoryPublisher) Events() []*eventspb.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	events := make([]*eventspb.Event, 0, len(p.events))
+	for _, event := range p.events {
+		events = append(events, proto.Clone(event).(*eventspb.Event))
+	}
+	return events
+}
+
+// WebhookPublisher: 이벤트를 JSON(protojson)으로 webhook URL에 POST
+// 2xx 응답만 성공으로 보며, 수신 측은 X-Event-Id 헤더로 중복을 걸러야 함
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookPublisher(url string, client *http.Client) (*WebhookPublisher, error) {
+	if url == "" {
+		return nil, errors.New("webhook URL이 비어 있습니다")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+	return &WebhookPublisher{url: url, client: client}, nil
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, event *eventspb.Event) error {
+	body, err := protojson.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("이벤트 JSON 변환 실패: %w", err)
+	}
+	return postEvent(ctx, p.client, p.url, "", body, event)
+}
+
+// FanoutPublisher: 이벤트를 URL 호스트의 모든 주소로 POST (WebhookPublisher와 같은 형식)
+// headless Service를 지정하면 준비된 모든 파드가 이벤트를 받으므로 파드마다 있는 캐시를 무효화할 때 사용
+// 한 주소라도 실패하면 이벤트 전체가 다시 발행되어 이미 받은 파드도 같은 이벤트를 다시 받음
+type FanoutPublisher struct {
+	targets    []*url.URL
+	client     *http.Client
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+}
+
+func NewFanoutPublisher(urls []string, client *http.Client) (*FanoutPublisher, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("fan-out URL이 비어 있습니다")
+	}
+	targets := make([]*url.URL, 0, len(urls))
+	for _, raw := range urls {
+		// 파드 IP로 직접 보내므로 인증서 검증이 필요한 https는 지원하지 않음
+		target, err := url.Parse(raw)
+		if err != nil || target.Scheme != "http" || target.Hostname() == "" {
+			return nil, fmt.Errorf("fan-out URL은 http://호스트 형식이어야 합니다: %s", raw)
+		}
+		targets = append(targets, target)
+	}
+	if client == nil {
+		client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+	return &FanoutPublisher{
+		targets:    targets,
+		client:     client,
+		lookupHost: net.DefaultResolver.LookupHost,
+	}, nil
+}
+
+func (p *FanoutPublisher) Publish(ctx context.Context, event *eventspb.Event) error {
+	body, err := protojson.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("이벤트 JSON 변환 실패: %w", err)
+	}
+
+	for _, target := range p.targets {
+		addrs, err := p.lookupHost(ctx, target.Hostname())
+		if err != nil {
+			var dnsErr *net.DNSError
+			if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+				// 준비된 파드가 없으면 headless Service에도 주소가 없음 (이벤트를 받을 파드가 없음)
+				logging.FromContext(ctx).Warn("fan-out 대상 주소가 없어 건너뜁니다", "host", target.Host, "event_id", event.GetEventId())
+				continue
+			}
+			return fmt.Errorf("fan-out 대상 %s 주소 조회 실패: %w", target.Host, err)
+		}
+
+		port := target.Port()
+		if port == "" {
+			port = "80"
+		}
+		for _, addr := range addrs {
+			endpoint := *target
+			endpoint.Host = net.JoinHostPort(addr, port)
+			if err := postEvent(ctx, p.client, endpoint.String(), target.Host, body, event); err != nil {
+				return fmt.Errorf("fan-out 대상 %s: %w", endpoint.Host, err)
+			}
+		}
+	}
+	return nil
+}
+
+// postEvent: JSON으로 변환한 이벤트를 POST하고 2xx 응답만 성공으로 봄 (host가 있으면 Host 헤더로 보냄)
+func postEvent(ctx context.Context, client *http.Client, target, host string, body []byte, event *eventspb.Event) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook 요청 생성 실패: %w", err)
+	}
+	if host != "" {
+		req.Host = host
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Id", event.GetEventId())
+	req.Header.Set("X-Event-Type", event.GetEventType())
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook 호출 실패: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook 응답 코드 %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// multiPublisher: 이벤트를 순서대로 모든 publisher로 발행 (하나라도 실패하면 실패)
+// 다시 발행할 때 앞에서 성공한 publisher도 같은 이벤트를 다시 받음
+type multiPublisher []EventPublisher
+
+func (m multiPublisher) Publish(ctx context.Context, event *eventspb.Event) error {
+	for _, publisher := range m {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	_ EventPublisher = (*MemoryPublisher)(nil)
+	_ EventPublisher = (*WebhookPublisher)(nil)
+	_ EventPublisher = (*FanoutPublisher)(nil)
+	_ EventPublisher = multiPublisher(nil)
+)
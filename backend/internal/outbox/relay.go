@@ -0,0 +1,179 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/logging"
+	"Acho-mj/2025_Golang_MSA/backend/internal/retry"
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+)
+
+const (
+	// 발행 대기 이벤트를 확인하는 주기
+	DefaultPollInterval = time.Second
+	// 한 번에 조회하는 이벤트 수
+	relayBatchSize = 100
+	// 한 번 실행할 때 조회하는 최대 페이지 수
+	// 앞쪽 이벤트가 재시도 대기로 막혀 있어도 뒤쪽의 다른 리소스 이벤트까지 발행함
+	relayMaxPages = 10
+	// 발행을 포기하고 dead letter로 옮기기 전까지의 최대 시도 횟수 (재시도 대기 시간을 합치면 약 1시간)
+	defaultMaxAttempts = 20
+	// 발행하는 동안 다른 relay가 같은 이벤트를 가져가지 못하게 점유하는 시간
+	claimTTL = 30 * time.Second
+)
+
+// 발행 실패 후 재시도 간격 (실패할 때마다 두 배, 최대 5분)
+var retryBackoff = retry.Backoff{Base: time.Second, Max: 5 * time.Minute}
+
+// Relay: outbox의 발행 대기 이벤트를 EventPublisher로 발행
+// 같은 리소스(aggregate)의 이벤트는 기록된 순서대로 발행하며, 앞 이벤트가 발행되지 않으면 뒤 이벤트도 기다림
+// 발행 후 기록에 실패하면 다시 발행될 수 있음 (at-least-once)
+// maxAttempts번 실패한 이벤트는 dead letter로 옮겨 같은 리소스의 뒤 이벤트가 계속 발행되도록 함
+type Relay struct {
+	repo      storage.OutboxRepository
+	source    string
+	publisher EventPublisher
+	now       func() time.Time
+	// 0이면 dead letter로 옮기지 않고 성공할 때까지 다시 시도 (NewWorker)
+	maxAttempts int
+}
+
+func NewRelay(repo storage.OutboxRepository, source string, publisher EventPublisher) (*Relay, error) {
+	if repo == nil {
+		return nil, errors.New("outbox repository가 nil입니다")
+	}
+	if source == "" {
+		return nil, errors.New("outbox source가 비어 있습니다")
+	}
+	if publisher == nil {
+		return nil, errors.New("event publisher가 nil입니다")
+	}
+
+	return &Relay{
+		repo:        repo,
+		source:      source,
+		publisher:   publisher,
+		now:         time.Now,
+		maxAttempts: defaultMaxAttempts,
+	}, nil
+}
+
+// PublishPending: 지금 발행할 수 있는 이벤트를 발행하고 발행한 수를 반환
+func (r *Relay) PublishPending(ctx context.Context) (int, error) {
+	published := 0
+	// 이번 차례에 발행하지 못한 이벤트가 있는 aggregate (뒤 이벤트도 건너뜀, 페이지가 바뀌어도 유지)
+	blocked := make(map[string]struct{})
+	after := ""
+	for page := 0; page < relayMaxPages; page++ {
+		pending, err := r.repo.ListPendingEvents(ctx, r.source, after, relayBatchSize)
+		if err != nil {
+			return published, fmt.Errorf("발행 대기 이벤트 조회 실패: %w", err)
+		}
+
+		n, err := r.publishPage(ctx, pending, blocked)
+		published += n
+		if err != nil {
+			return published, err
+		}
+		if len(pending) < relayBatchSize {
+			break
+		}
+		after = pending[len(pending)-1].EventID
+	}
+
+	return published, nil
+}
+
+func (r *Relay) publishPage(ctx context.Context, pending []storage.OutboxRecord, blocked map[string]struct{}) (int, error) {
+	published := 0
+	for i := range pending {
+		record := &pending[i]
+		if _, ok := blocked[record.AggregateID]; ok {
+			continue
+		}
+
+		now := r.now()
+		if record.AvailableAt > now.UnixMilli() {
+			blocked[record.AggregateID] = struct{}{}
+			continue
+		}
+		if err := r.repo.ClaimEvent(ctx, record.EventID, now, now.Add(claimTTL)); err != nil {
+			blocked[record.AggregateID] = struct{}{}
+			if errors.Is(err, storage.ErrOutboxEventClaimed) {
+				continue
+			}
+			return published, fmt.Errorf("이벤트 %s 점유 실패: %w", record.EventID, err)
+		}
+
+		if err := r.publish(ctx, record); err != nil {
+			if !r.fail(ctx, record, err) {
+				blocked[record.AggregateID] = struct{}{}
+			}
+			continue
+		}
+
+		if err := r.repo.MarkEventPublished(context.WithoutCancel(ctx), record.EventID); err != nil {
+			// 점유 시간이 지나면 다시 발행됨
+			blocked[record.AggregateID] = struct{}{}
+			logging.FromContext(ctx).Error("이벤트 발행 완료 기록 실패", "event_id", record.EventID, "error", err)
+			continue
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// fail: 발행 실패를 기록하고 다시 시도하도록 함 (maxAttempts번째 실패면 dead letter로 옮기고 true를 반환)
+func (r *Relay) fail(ctx context.Context, record *storage.OutboxRecord, err error) bool {
+	logger := logging.FromContext(ctx)
+	ctx = context.WithoutCancel(ctx)
+
+	if r.maxAttempts > 0 && record.Attempts+1 >= r.maxAttempts {
+		logger.Error("이벤트 발행을 포기하고 dead letter로 옮깁니다", "event_id", record.EventID, "event_type", record.EventType, "aggregate_id", record.AggregateID, "attempts", record.Attempts+1, "error", err)
+		if markErr := r.repo.MarkEventDeadLettered(ctx, record.EventID, retry.ErrorMessage(err)); markErr != nil {
+			logger.Error("이벤트 dead letter 기록 실패", "event_id", record.EventID, "error", markErr)
+			return false
+		}
+		return true
+	}
+
+	retryAt := r.now().Add(retryBackoff.Delay(record.Attempts))
+	logger.Warn("이벤트 발행 실패, 다시 시도합니다", "event_id", record.EventID, "event_type", record.EventType, "retry_at", retryAt.UTC().Format(time.RFC3339), "error", err)
+	if markErr := r.repo.MarkEventFailed(ctx, record.EventID, retryAt, retry.ErrorMessage(err)); markErr != nil {
+		logger.Error("이벤트 실패 기록 실패", "event_id", record.EventID, "error", markErr)
+	}
+	return false
+}
+
+// Run: ctx가 끝날 때까지 interval마다 PublishPending을 실행
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.PublishPending(ctx); err != nil && ctx.Err() == nil {
+			logging.FromContext(ctx).Error("outbox 이벤트 발행 실패", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) publish(ctx context.Context, record *storage.OutboxRecord) error {
+	event, err := Decode(record)
+	if err != nil {
+		return err
+	}
+	return r.publisher.Publish(ctx, event)
+}
@@ -0,0 +1,220 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	eventspb "Acho-mj/2025_Golang_MSA/backend/gen/events"
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+)
+
+// 주문마다 차례로 바꾸는 상태 (주문 하나에 이벤트를 여러 개 기록하기 위해 사용)
+var testOrderStatuses = []string{"pending", "confirmed", "shipped", "delivered"}
+
+// relayHarness: 메모리 주문 저장소로 outbox에 이벤트를 기록하고, 직접 움직일 수 있는 시계로 relay를 실행
+type relayHarness struct {
+	outbox *storage.MemoryOutboxStorage
+	orders *storage.MemoryOrderStorage
+	now    time.Time
+	// 발행(처리)에 성공한 이벤트 이름 순서 ("A1", "B1" 등)
+	published []string
+	// 이벤트 이름별 발행 시도 횟수
+	attempts map[string]int
+	// 발행에 실패할 이벤트 이름 (값은 실패할 남은 횟수, 음수면 계속 실패)
+	failures map[string]int
+	// 이벤트 ID별 이름
+	names map[string]string
+	// 주문별 기록한 이벤트 수
+	counts map[string]int
+}
+
+func newRelayHarness() *relayHarness {
+	outbox := storage.NewMemoryOutboxStorage()
+	return &relayHarness{
+		outbox:   outbox,
+		orders:   storage.NewMemoryOrderStorage(outbox),
+		now:      time.Unix(1735787045, 0),
+		attempts: make(map[string]int),
+		failures: make(map[string]int),
+		names:    make(map[string]string),
+		counts:   make(map[string]int),
+	}
+}
+
+// add: 주문(aggregate) 이벤트를 하나 기록하고 이름(주문 이름+순번)을 반환
+// 첫 이벤트는 주문 생성과, 이후 이벤트는 주문 상태 변경과 같은 쓰기로 기록됨
+func (h *relayHarness) add(t *testing.T, source, aggregate string) string {
+	t.Helper()
+	ctx := context.Background()
+	n := h.counts[aggregate]
+	event, err := NewRecord(source, aggregate, &eventspb.Event{
+		Payload: &eventspb.Event_OrderStatusChanged{OrderStatusChanged: &eventspb.OrderStatusChanged{OrderId: aggregate}},
+	})
+	if err != nil {
+		t.Fatalf("NewRecord: %v", err)
+	}
+
+	if n == 0 {
+		err = h.orders.CreateOrder(ctx, &storage.OrderRecord{OrderID: aggregate, Status: testOrderStatuses[0]}, event)
+	} else {
+		_, err = h.orders.UpdateOrderStatus(ctx, aggregate, testOrderStatuses[n-1], testOrderStatuses[n], event)
+	}
+	if err != nil {
+		t.Fatalf("이벤트 기록: %v", err)
+	}
+
+	h.counts[aggregate] = n + 1
+	name := aggregate + string(rune('1'+n))
+	h.names[event.EventID] = name
+	return name
+}
+
+// Publish: relay의 EventPublisher이자 worker의 Handler
+func (h *relayHarness) Publish(ctx context.Context, event *eventspb.Event) error {
+	name := h.names[event.GetEventId()]
+	h.attempts[name]++
+	if remaining, ok := h.failures[name]; ok && remaining != 0 {
+		h.failures[name] = remaining - 1
+		return errors.New("발행 실패")
+	}
+	h.published = append(h.published, name)
+	return nil
+}
+
+func (h *relayHarness) newRelay(t *testing.T, source string) *Relay {
+	t.Helper()
+	relay, err := NewRelay(h.outbox, source, h)
+	if err != nil {
+		t.Fatalf("NewRelay: %v", err)
+	}
+	relay.now = func() time.Time { return h.now }
+	return relay
+}
+
+// run: 재시도 대기 시간(최대 5분)이 지나도록 시계를 움직이며 PublishPending을 times번 실행
+func (h *relayHarness) run(t *testing.T, relay *Relay, times int) {
+	t.Helper()
+	for i := 0; i < times; i++ {
+		if _, err := relay.PublishPending(context.Background()); err != nil {
+			t.Fatalf("PublishPending: %v", err)
+		}
+		h.now = h.now.Add(10 * time.Minute)
+	}
+}
+
+func (h *relayHarness) pending(t *testing.T, source string) int {
+	t.Helper()
+	records, err := h.outbox.ListPendingEvents(context.Background(), source, "", 100)
+	if err != nil {
+		t.Fatalf("ListPendingEvents: %v", err)
+	}
+	return len(records)
+}
+
+func TestRelayPublishPending(t *testing.T) {
+	tests := []struct {
+		name string
+		// 첫 실행에서 실패할 이벤트와 실패 횟수
+		failures map[string]int
+		// 첫 실행에서 발행되는 순서
+		wantFirst []string
+		// 재시도 대기 시간이 지난 뒤 실행까지 발행된 순서
+		wantAll []string
+	}{
+		{
+			name:      "기록된 순서대로 발행",
+			wantFirst: []string{"A1", "B1", "A2"},
+			wantAll:   []string{"A1", "B1", "A2"},
+		},
+		{
+			// 같은 주문의 뒤 이벤트는 앞 이벤트가 발행될 때까지 기다리고, 다른 주문은 막지 않음
+			name:      "실패한 이벤트는 같은 리소스의 뒤 이벤트만 막음",
+			failures:  map[string]int{"A1": 1},
+			wantFirst: []string{"B1"},
+			wantAll:   []string{"B1", "A1", "A2"},
+		},
+		{
+			name:      "뒤 이벤트가 실패하면 앞 이벤트는 발행",
+			failures:  map[string]int{"A2": 1},
+			wantFirst: []string{"A1", "B1"},
+			wantAll:   []string{"A1", "B1", "A2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			h := newRelayHarness()
+			for name, n := range tt.failures {
+				h.failures[name] = n
+			}
+			h.add(t, SourceOrder, "A")
+			h.add(t, SourceOrder, "B")
+			h.add(t, SourceOrder, "A")
+			// 다른 source의 이벤트는 발행하지 않음
+			h.add(t, SourceOrderStock, "C")
+			relay := h.newRelay(t, SourceOrder)
+
+			published, err := relay.PublishPending(ctx)
+			if err != nil {
+				t.Fatalf("PublishPending: %v", err)
+			}
+			if published != len(tt.wantFirst) || !slices.Equal(h.published, tt.wantFirst) {
+				t.Errorf("첫 실행 발행 = %d %v, want %v", published, h.published, tt.wantFirst)
+			}
+
+			// 재시도 대기 시간 전에는 다시 시도하지 않음
+			if _, err := relay.PublishPending(ctx); err != nil {
+				t.Fatalf("PublishPending: %v", err)
+			}
+			if !slices.Equal(h.published, tt.wantFirst) {
+				t.Errorf("재시도 대기 중 발행 = %v, want %v", h.published, tt.wantFirst)
+			}
+
+			h.now = h.now.Add(10 * time.Minute)
+			h.run(t, relay, 1)
+			if !slices.Equal(h.published, tt.wantAll) {
+				t.Errorf("발행 순서 = %v, want %v", h.published, tt.wantAll)
+			}
+			if n := h.pending(t, SourceOrder); n != 0 {
+				t.Errorf("발행 대기 이벤트 %d개가 남음", n)
+			}
+			if n := h.pending(t, SourceOrderStock); n != 1 {
+				t.Errorf("다른 source의 발행 대기 이벤트 = %d개, want 1개", n)
+			}
+		})
+	}
+}
+
+func TestRelayDeadLetter(t *testing.T) {
+	h := newRelayHarness()
+	h.add(t, SourceOrder, "A")
+	h.add(t, SourceOrder, "A")
+	h.failures["A1"] = -1
+	relay := h.newRelay(t, SourceOrder)
+
+	// maxAttempts번째 실패 전까지는 뒤 이벤트를 막음
+	h.run(t, relay, defaultMaxAttempts-1)
+	if len(h.published) != 0 || h.attempts["A2"] != 0 {
+		t.Fatalf("dead letter 전 발행 = %v, A2 시도 %d번, want 없음", h.published, h.attempts["A2"])
+	}
+
+	// maxAttempts번째 실패에서 dead letter로 옮기고 같은 실행에서 뒤 이벤트를 발행
+	h.run(t, relay, 1)
+	if h.attempts["A1"] != defaultMaxAttempts {
+		t.Errorf("A1 시도 = %d번, want %d번", h.attempts["A1"], defaultMaxAttempts)
+	}
+	if !slices.Equal(h.published, []string{"A2"}) {
+		t.Errorf("dead letter 후 발행 = %v, want [A2]", h.published)
+	}
+	if n := h.pending(t, SourceOrder); n != 0 {
+		t.Errorf("발행 대기 이벤트 %d개가 남음 (dead letter는 발행 대기 목록에서 빠져야 함)", n)
+	}
+
+	h.run(t, relay, 1)
+	if h.attempts["A1"] != defaultMaxAttempts {
+		t.Errorf("dead letter 후 A1을 다시 시도함: %d번", h.attempts["A1"])
+	}
+}
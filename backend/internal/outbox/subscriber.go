@@ -0,0 +1,45 @@
+package outbox
+
+import (
+	"io"
+	"net/http"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	eventspb "Acho-mj/2025_Golang_MSA/backend/gen/events"
+	"Acho-mj/2025_Golang_MSA/backend/internal/logging"
+)
+
+// 받는 이벤트 본문의 최대 크기
+const maxEventBodyBytes = 1 << 20
+
+// NewWebhookHandler: 다른 서비스의 WebhookPublisher/FanoutPublisher가 POST한 이벤트를 handler로 처리하는 HTTP 핸들러
+// handler가 실패하면 500을 반환해 발행한 서비스의 relay가 다시 보내게 하므로 같은 이벤트를 여러 번 받을 수 있음
+func NewWebhookHandler(handler Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "POST만 지원합니다", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxEventBodyBytes))
+		if err != nil {
+			http.Error(w, "이벤트를 읽을 수 없습니다", http.StatusBadRequest)
+			return
+		}
+		// 발행하는 서비스에 새 필드나 payload가 먼저 추가되어도 받을 수 있도록 모르는 필드는 무시
+		var event eventspb.Event
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, &event); err != nil {
+			http.Error(w, "이벤트 형식이 올바르지 않습니다", http.StatusBadRequest)
+			return
+		}
+
+		if err := handler(r.Context(), &event); err != nil {
+			logging.FromContext(r.Context()).Error("이벤트 처리 실패", "event_id", event.GetEventId(), "event_type", event.GetEventType(), "error", err)
+			http.Error(w, "이벤트 처리 실패", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,36 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+
+	eventspb "Acho-mj/2025_Golang_MSA/backend/gen/events"
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+)
+
+// Handler: outbox에 기록된 작업을 외부로 발행하지 않고 서비스 안에서 처리하는 함수 (예: 주문 상태에 따른 재고 처리)
+// 같은 기록으로 여러 번 호출될 수 있으므로 결과가 같아야 함
+type Handler func(ctx context.Context, event *eventspb.Event) error
+
+// NewWorker: source에 기록된 작업을 handler로 처리하는 Relay
+// 발행할 이벤트와 다른 source에 기록하므로 handler가 실패해도 이벤트 발행이 늦어지거나 막히지 않음
+// 작업을 잃지 않도록 dead letter로 옮기지 않고 성공할 때까지 다시 시도함 (재시도 간격은 최대 5분)
+func NewWorker(repo storage.OutboxRepository, source string, handler Handler) (*Relay, error) {
+	if handler == nil {
+		return nil, errors.New("outbox handler가 nil입니다")
+	}
+
+	worker, err := NewRelay(repo, source, handlerPublisher(handler))
+	if err != nil {
+		return nil, err
+	}
+	worker.maxAttempts = 0
+	return worker, nil
+}
+
+// handlerPublisher: Handler를 EventPublisher로 사용
+type handlerPublisher Handler
+
+func (h handlerPublisher) Publish(ctx context.Context, event *eventspb.Event) error {
+	return h(ctx, event)
+}
@@ -0,0 +1,42 @@
+package outbox
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func (h *relayHarness) newWorker(t *testing.T, source string) *Relay {
+	t.Helper()
+	worker, err := NewWorker(h.outbox, source, h.Publish)
+	if err != nil {
+		t.Fatalf("NewWorker: %v", err)
+	}
+	worker.now = func() time.Time { return h.now }
+	return worker
+}
+
+func TestWorkerRetriesWithoutDeadLetter(t *testing.T) {
+	h := newRelayHarness()
+	h.add(t, SourceOrderStock, "A")
+	h.add(t, SourceOrderStock, "A")
+	// relay라면 dead letter로 옮겼을 횟수보다 많이 실패
+	h.failures["A1"] = defaultMaxAttempts * 2
+	worker := h.newWorker(t, SourceOrderStock)
+
+	h.run(t, worker, defaultMaxAttempts*2)
+	if len(h.published) != 0 || h.attempts["A2"] != 0 {
+		t.Fatalf("A1이 성공하기 전 처리 = %v, A2 시도 %d번, want 없음", h.published, h.attempts["A2"])
+	}
+
+	h.run(t, worker, 1)
+	if !slices.Equal(h.published, []string{"A1", "A2"}) {
+		t.Errorf("처리 순서 = %v, want [A1 A2]", h.published)
+	}
+	if h.attempts["A1"] != defaultMaxAttempts*2+1 {
+		t.Errorf("A1 시도 = %d번, want %d번", h.attempts["A1"], defaultMaxAttempts*2+1)
+	}
+	if n := h.pending(t, SourceOrderStock); n != 0 {
+		t.Errorf("처리 대기 작업 %d개가 남음", n)
+	}
+}
@@ -0,0 +1,128 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/apperr"
+)
+
+var ErrInvalidToken = apperr.New(apperr.ErrInvalidInput, "page token이 올바르지 않습니다")
+
+// TokenCodec: DynamoDB의 LastEvaluatedKey를 외부에 노출할 수 있는 page token으로 변환
+// token은 HMAC-SHA256으로 서명되어 클라이언트가 키를 조작하면 Decode에서 거부됨
+type TokenCodec struct {
+	secret []byte
+}
+
+// token 안에 담기는 값 (scope는 token을 발급한 조회 조건)
+type tokenPayload struct {
+	Scope string              `json:"s"`
+	Key   map[string]keyValue `json:"k"`
+}
+
+// LastEvaluatedKey의 속성 값 (키 속성은 S, N 타입만 가능)
+type keyValue struct {
+	S *string `json:"s,omitempty"`
+	N *string `json:"n,omitempty"`
+}
+
+func NewTokenCodec(secret []byte) (*TokenCodec, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("page token secret이 비어 있습니다")
+	}
+	return &TokenCodec{secret: secret}, nil
+}
+
+// RandomSecret: PAGE_TOKEN_SECRET이 없을 때 사용할 프로세스 단위 임시 secret
+// 파드마다 값이 달라지므로 여러 파드로 운영할 때는 반드시 secret을 설정해야 함
+func RandomSecret() ([]byte, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("secret 생성 실패: %w", err)
+	}
+	return secret, nil
+}
+
+// Encode: scope에 묶인 page token을 만듦 (key가 비어 있으면 마지막 페이지이므로 빈 문자열)
+func (c *TokenCodec) Encode(scope string, key map[string]types.AttributeValue) (string, error) {
+	if len(key) == 0 {
+		return "", nil
+	}
+
+	payload := tokenPayload{
+		Scope: scope,
+		Key:   make(map[string]keyValue, len(key)),
+	}
+	for name, av := range key {
+		switch v := av.(type) {
+		case *types.AttributeValueMemberS:
+			payload.Key[name] = keyValue{S: &v.Value}
+		case *types.AttributeValueMemberN:
+			payload.Key[name] = keyValue{N: &v.Value}
+		default:
+			return "", fmt.Errorf("지원하지 않는 키 속성 타입: %s", name)
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("page token marshal 실패: %w", err)
+	}
+
+	token := append(c.sign(body), body...)
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// Decode: token을 검증하고 ExclusiveStartKey로 사용할 키를 돌려줌
+// 빈 token은 첫 페이지를 의미하므로 nil을 반환
+func (c *TokenCodec) Decode(scope, token string) (map[string]types.AttributeValue, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) <= sha256.Size {
+		return nil, ErrInvalidToken
+	}
+	mac, body := raw[:sha256.Size], raw[sha256.Size:]
+	if !hmac.Equal(mac, c.sign(body)) {
+		return nil, ErrInvalidToken
+	}
+
+	var payload tokenPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+	// 다른 조회 조건으로 발급된 token은 재사용할 수 없음
+	if payload.Scope != scope {
+		return nil, ErrInvalidToken
+	}
+
+	key := make(map[string]types.AttributeValue, len(payload.Key))
+	for name, v := range payload.Key {
+		switch {
+		case v.S != nil:
+			key[name] = &types.AttributeValueMemberS{Value: *v.S}
+		case v.N != nil:
+			key[name] = &types.AttributeValueMemberN{Value: *v.N}
+		default:
+			return nil, ErrInvalidToken
+		}
+	}
+
+	return key, nil
+}
+
+func (c *TokenCodec) sign(body []byte) []byte {
+	h := hmac.New(sha256.New, c.secret)
+	h.Write(body)
+	return h.Sum(nil)
+}
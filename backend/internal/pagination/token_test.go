@@ -0,0 +1,124 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func newTestCodec(t *testing.T, secret string) *TokenCodec {
+	t.Helper()
+	codec, err := NewTokenCodec([]byte(secret))
+	if err != nil {
+		t.Fatalf("NewTokenCodec: %v", err)
+	}
+	return codec
+}
+
+func TestTokenCodecRoundTrip(t *testing.T) {
+	codec := newTestCodec(t, "secret")
+
+	tests := []struct {
+		name string
+		key  map[string]types.AttributeValue
+	}{
+		{
+			name: "문자열 키",
+			key: map[string]types.AttributeValue{
+				"user_id":    &types.AttributeValueMemberS{Value: "user_1"},
+				"created_at": &types.AttributeValueMemberS{Value: "2025-01-02T03:04:05.000000000Z"},
+			},
+		},
+		{
+			name: "숫자 키",
+			key: map[string]types.AttributeValue{
+				"in_flight":        &types.AttributeValueMemberS{Value: "Y"},
+				"lease_expires_at": &types.AttributeValueMemberN{Value: "1735787045000"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := codec.Encode("scope", tt.key)
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			got, err := codec.Decode("scope", token)
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.key) {
+				t.Errorf("Decode = %#v, want %#v", got, tt.key)
+			}
+		})
+	}
+}
+
+func TestTokenCodecEmpty(t *testing.T) {
+	codec := newTestCodec(t, "secret")
+
+	token, err := codec.Encode("scope", nil)
+	if err != nil || token != "" {
+		t.Fatalf("Encode(nil) = %q, %v, want empty token", token, err)
+	}
+	key, err := codec.Decode("scope", "")
+	if err != nil || key != nil {
+		t.Fatalf("Decode(\"\") = %v, %v, want nil key", key, err)
+	}
+}
+
+func TestTokenCodecRejectsUnsupportedType(t *testing.T) {
+	codec := newTestCodec(t, "secret")
+
+	_, err := codec.Encode("scope", map[string]types.AttributeValue{
+		"flag": &types.AttributeValueMemberBOOL{Value: true},
+	})
+	if err == nil {
+		t.Fatal("Encode with BOOL key: want error")
+	}
+}
+
+func TestTokenCodecRejectsInvalidToken(t *testing.T) {
+	codec := newTestCodec(t, "secret")
+	key := map[string]types.AttributeValue{
+		"user_id": &types.AttributeValueMemberS{Value: "user_1"},
+	}
+	token, err := codec.Encode("user_1", key)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		t.Fatalf("token decode: %v", err)
+	}
+
+	// 서명 뒤의 본문 한 byte를 바꾼 token
+	tampered := append([]byte(nil), raw...)
+	tampered[len(tampered)-2] ^= 0x01
+	// 서명만 남긴 token
+	signatureOnly := raw[:32]
+
+	tests := []struct {
+		name  string
+		codec *TokenCodec
+		scope string
+		token string
+	}{
+		{name: "본문 변조", codec: codec, scope: "user_1", token: base64.RawURLEncoding.EncodeToString(tampered)},
+		{name: "다른 조회 조건", codec: codec, scope: "user_2", token: token},
+		{name: "다른 secret", codec: newTestCodec(t, "other"), scope: "user_1", token: token},
+		{name: "base64가 아님", codec: codec, scope: "user_1", token: "!!!"},
+		{name: "본문 없음", codec: codec, scope: "user_1", token: base64.RawURLEncoding.EncodeToString(signatureOnly)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.codec.Decode(tt.scope, tt.token)
+			if !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("Decode error = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
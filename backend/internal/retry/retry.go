@@ -0,0 +1,60 @@
+package retry
+
+import (
+	"context"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+// 기록하는 에러 메시지 최대 길이
+const maxErrorLength = 500
+
+// Backoff: 실패할 때마다 두 배로 늘어나는 재시도 간격 (Base부터 시작해 Max를 넘지 않음)
+type Backoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+// Delay: failures번 실패한 뒤 다음 시도까지 기다리는 시간 (0번이면 Base)
+func (b Backoff) Delay(failures int) time.Duration {
+	delay := b.Base
+	for i := 0; i < failures && delay < b.Max; i++ {
+		delay *= 2
+	}
+	if delay > b.Max {
+		delay = b.Max
+	}
+	return delay
+}
+
+// Jitter: 0 ~ Delay(failures) 사이의 임의 시간 (full jitter, 여러 호출자가 동시에 재시도하지 않도록 흩어 놓음)
+func (b Backoff) Jitter(failures int) time.Duration {
+	delay := b.Delay(failures)
+	if delay <= 0 {
+		return 0
+	}
+	return rand.N(delay)
+}
+
+// Sleep: d만큼 기다림 (ctx가 먼저 끝나면 ctx의 에러를 반환)
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ErrorMessage: 재시도 기록(outbox 이벤트, saga 단계)에 남길 에러 메시지 (길면 잘라냄)
+func ErrorMessage(err error) string {
+	msg := err.Error()
+	if len(msg) > maxErrorLength {
+		msg = strings.ToValidUTF8(msg[:maxErrorLength], "")
+	}
+	return msg
+}
@@ -0,0 +1,203 @@
+package rpcclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// State: circuit breaker 상태
+// closed -> (연속 실패가 기준에 이름) -> open -> (openDuration 경과) -> half-open
+// half-open에서 시험 호출이 성공하면 closed, 실패하면 다시 open
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// ErrCircuitOpen: circuit breaker가 열려 있어 호출하지 않고 실패한 경우
+var ErrCircuitOpen = errors.New("circuit breaker가 열려 있습니다")
+
+// BreakerStats: 관측용 circuit breaker 상태
+type BreakerStats struct {
+	Name  string
+	State State
+	// 지금까지 연속으로 실패한 호출 수 (성공하면 0)
+	ConsecutiveFailures int
+	// 마지막으로 열린 시각 (한 번도 열리지 않았으면 zero)
+	OpenedAt time.Time
+	// 열려 있어 호출하지 않고 실패시킨 누적 횟수
+	Rejected int64
+}
+
+// Breaker: 대상 서비스 하나의 연속 실패를 세어 장애 중에는 호출을 바로 실패시키는 circuit breaker
+type Breaker struct {
+	name         string
+	threshold    int
+	openDuration time.Duration
+	now          func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	rejected int64
+	// half-open에서 시험 호출이 진행 중인지 (시험 호출은 한 번에 하나만 허용)
+	probing bool
+	// 상태가 바뀔 때 호출 (관측용)
+	onChange []func(name string, from, to State)
+}
+
+func NewBreaker(name string, threshold int, openDuration time.Duration) *Breaker {
+	if threshold <= 0 {
+		threshold = 1
+	}
+	return &Breaker{
+		name:         name,
+		threshold:    threshold,
+		openDuration: openDuration,
+		now:          time.Now,
+	}
+}
+
+// OnStateChange: 상태가 바뀔 때마다 fn을 호출 (breaker lock 밖에서 호출됨)
+func (b *Breaker) OnStateChange(fn func(name string, from, to State)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.onChange = append(b.onChange, fn)
+}
+
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State: 현재 상태 (open이어도 openDuration이 지났으면 half-open으로 보고)
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.openDuration {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+func (b *Breaker) Stats() BreakerStats {
+	state := b.State()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return BreakerStats{
+		Name:                b.name,
+		State:               state,
+		ConsecutiveFailures: b.failures,
+		OpenedAt:            b.openedAt,
+		Rejected:            b.rejected,
+	}
+}
+
+// Allow: 호출해도 되는지 확인. 허용되면 호출 결과를 반드시 Record나 Ignore로 알려야 함
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	var changed func()
+	defer func() {
+		b.mu.Unlock()
+		if changed != nil {
+			changed()
+		}
+	}()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.openDuration {
+			b.rejected++
+			return ErrCircuitOpen
+		}
+		changed = b.transition(StateHalfOpen)
+		b.probing = true
+		return nil
+	case StateHalfOpen:
+		if b.probing {
+			b.rejected++
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	}
+	return nil
+}
+
+// Record: Allow로 허용된 호출의 결과 기록 (failure는 대상 서비스 장애로 볼 수 있는 실패인지)
+func (b *Breaker) Record(failure bool) {
+	b.mu.Lock()
+	var changed func()
+	defer func() {
+		b.mu.Unlock()
+		if changed != nil {
+			changed()
+		}
+	}()
+
+	if !failure {
+		b.failures = 0
+		if b.state != StateClosed {
+			b.probing = false
+			changed = b.transition(StateClosed)
+		}
+		return
+	}
+
+	b.failures++
+	switch b.state {
+	case StateHalfOpen:
+		// 시험 호출이 실패하면 다시 openDuration 동안 열어 둠
+		b.probing = false
+		b.openedAt = b.now()
+		changed = b.transition(StateOpen)
+	case StateClosed:
+		if b.failures >= b.threshold {
+			b.openedAt = b.now()
+			changed = b.transition(StateOpen)
+		}
+	}
+}
+
+// Ignore: Allow로 허용됐지만 결과로 대상 서비스 상태를 판단할 수 없는 호출 (호출자가 취소한 경우)
+// half-open의 시험 호출이었다면 다음 호출이 다시 시험할 수 있게 함
+func (b *Breaker) Ignore() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// transition: 상태를 바꾸고, lock을 푼 뒤 실행할 알림 함수를 반환 (b.mu를 잡은 상태에서 호출)
+func (b *Breaker) transition(to State) func() {
+	from := b.state
+	if from == to {
+		return nil
+	}
+	b.state = to
+	listeners := append([]func(name string, from, to State){}, b.onChange...)
+	return func() {
+		for _, fn := range listeners {
+			fn(b.name, from, to)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package rpcclient
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// breaker 테스트의 한 단계
+type breakerStep struct {
+	// allow, success, failure, ignore, wait
+	op string
+	// wait일 때 흘려보낼 시간
+	wait time.Duration
+	// allow일 때 ErrCircuitOpen을 기대하는지
+	rejected bool
+	// 단계가 끝난 뒤의 상태
+	state State
+}
+
+func TestBreakerTransitions(t *testing.T) {
+	const openDuration = 10 * time.Second
+
+	tests := []struct {
+		name  string
+		steps []breakerStep
+		// 상태 변경 알림 순서 ("from->to")
+		changes []string
+	}{
+		{
+			name: "기준 미만의 실패는 닫힌 상태 유지",
+			steps: []breakerStep{
+				{op: "allow", state: StateClosed},
+				{op: "failure", state: StateClosed},
+				{op: "allow", state: StateClosed},
+				{op: "failure", state: StateClosed},
+			},
+		},
+		{
+			name: "성공하면 연속 실패 수를 초기화",
+			steps: []breakerStep{
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateClosed},
+				{op: "success", state: StateClosed},
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateClosed},
+			},
+		},
+		{
+			name: "연속 실패가 기준에 이르면 열림",
+			steps: []breakerStep{
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateOpen},
+				{op: "allow", rejected: true, state: StateOpen},
+				{op: "wait", wait: openDuration - time.Second, state: StateOpen},
+				{op: "allow", rejected: true, state: StateOpen},
+			},
+			changes: []string{"closed->open"},
+		},
+		{
+			name: "열린 시간이 지나면 시험 호출 하나만 허용하고 성공하면 닫힘",
+			steps: []breakerStep{
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateOpen},
+				{op: "wait", wait: openDuration, state: StateHalfOpen},
+				{op: "allow", state: StateHalfOpen},
+				{op: "allow", rejected: true, state: StateHalfOpen},
+				{op: "success", state: StateClosed},
+				{op: "allow", state: StateClosed},
+			},
+			changes: []string{"closed->open", "open->half-open", "half-open->closed"},
+		},
+		{
+			name: "시험 호출이 실패하면 다시 열림",
+			steps: []breakerStep{
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateOpen},
+				{op: "wait", wait: openDuration, state: StateHalfOpen},
+				{op: "allow", state: StateHalfOpen},
+				{op: "failure", state: StateOpen},
+				{op: "allow", rejected: true, state: StateOpen},
+				{op: "wait", wait: openDuration, state: StateHalfOpen},
+				{op: "allow", state: StateHalfOpen},
+			},
+			changes: []string{"closed->open", "open->half-open", "half-open->open", "open->half-open"},
+		},
+		{
+			name: "취소된 시험 호출은 다음 호출이 다시 시험",
+			steps: []breakerStep{
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateOpen},
+				{op: "wait", wait: openDuration, state: StateHalfOpen},
+				{op: "allow", state: StateHalfOpen},
+				{op: "ignore", state: StateHalfOpen},
+				{op: "allow", state: StateHalfOpen},
+				{op: "success", state: StateClosed},
+			},
+			changes: []string{"closed->open", "open->half-open", "half-open->closed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(1735787045, 0)
+			b := NewBreaker("test", 3, openDuration)
+			b.now = func() time.Time { return now }
+
+			var changes []string
+			b.OnStateChange(func(name string, from, to State) {
+				changes = append(changes, from.String()+"->"+to.String())
+			})
+
+			for i, step := range tt.steps {
+				switch step.op {
+				case "allow":
+					err := b.Allow()
+					if step.rejected != errors.Is(err, ErrCircuitOpen) {
+						t.Fatalf("단계 %d: Allow() = %v, rejected %v 기대", i, err, step.rejected)
+					}
+				case "success":
+					b.Record(false)
+				case "failure":
+					b.Record(true)
+				case "ignore":
+					b.Ignore()
+				case "wait":
+					now = now.Add(step.wait)
+				default:
+					t.Fatalf("단계 %d: 알 수 없는 op %q", i, step.op)
+				}
+				if got := b.State(); got != step.state {
+					t.Fatalf("단계 %d (%s): State() = %s, want %s", i, step.op, got, step.state)
+				}
+			}
+			if !slices.Equal(changes, tt.changes) {
+				t.Errorf("상태 변경 알림 = %v, want %v", changes, tt.changes)
+			}
+		})
+	}
+}
+
+func TestBreakerStats(t *testing.T) {
+	now := time.Unix(1735787045, 0)
+	b := NewBreaker("inventory-service", 2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	b.Record(true)
+	b.Record(true)
+	for range 3 {
+		if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("Allow() = %v, want ErrCircuitOpen", err)
+		}
+	}
+
+	stats := b.Stats()
+	want := BreakerStats{
+		Name:                "inventory-service",
+		State:               StateOpen,
+		ConsecutiveFailures: 2,
+		OpenedAt:            now,
+		Rejected:            3,
+	}
+	if stats != want {
+		t.Errorf("Stats() = %+v, want %+v", stats, want)
+	}
+}
@@ -0,0 +1,202 @@
+package rpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	connect "connectrpc.com/connect"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/config"
+	"Acho-mj/2025_Golang_MSA/backend/internal/logging"
+	"Acho-mj/2025_Golang_MSA/backend/internal/metrics"
+	"Acho-mj/2025_Golang_MSA/backend/internal/retry"
+	"Acho-mj/2025_Golang_MSA/backend/internal/tracing"
+)
+
+// Config: 다른 서비스를 호출하는 Connect 클라이언트 설정
+type Config struct {
+	// 시도 한 번의 제한 시간 (호출자 ctx의 deadline이 더 짧으면 그쪽을 따름)
+	Timeout time.Duration
+	// 첫 시도를 포함한 최대 시도 횟수
+	MaxAttempts int
+	// 재시도 대기 시간: n번째 재시도는 [0, min(RetryMaxDelay, RetryBaseDelay*2^(n-1))) 안에서 무작위
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// 연속 실패가 이 횟수에 이르면 circuit breaker를 엶
+	BreakerFailures int
+	// circuit breaker가 열린 뒤 시험 호출을 허용하기까지 기다리는 시간
+	BreakerOpenDuration time.Duration
+}
+
+func ConfigFrom(cfg *config.Config) Config {
+	return Config{
+		Timeout:             cfg.RPCClientTimeout,
+		MaxAttempts:         cfg.RPCClientMaxAttempts,
+		RetryBaseDelay:      cfg.RPCClientRetryBaseDelay,
+		RetryMaxDelay:       cfg.RPCClientRetryMaxDelay,
+		BreakerFailures:     cfg.RPCClientBreakerFailures,
+		BreakerOpenDuration: cfg.RPCClientBreakerOpenDuration,
+	}
+}
+
+// Client: 대상 서비스 하나를 위한 HTTP 클라이언트와 Connect 옵션
+// 생성된 Connect 클라이언트에 HTTPClient와 Options를 넘겨 사용
+//
+//	c, err := rpcclient.New("user-service", rpcclient.ConfigFrom(cfg))
+//	userClient := userconnect.NewUserServiceClient(c.HTTPClient(), cfg.UserServiceURL, c.Options()...)
+type Client struct {
+	name       string
+	cfg        Config
+	httpClient *http.Client
+	breaker    *Breaker
+	tracing    connect.Interceptor
+}
+
+func New(name string, cfg Config) (*Client, error) {
+	if name == "" {
+		return nil, fmt.Errorf("rpc client 이름이 필요합니다")
+	}
+	if cfg.Timeout <= 0 || cfg.MaxAttempts <= 0 || cfg.RetryBaseDelay <= 0 || cfg.RetryMaxDelay <= 0 ||
+		cfg.BreakerFailures <= 0 || cfg.BreakerOpenDuration <= 0 {
+		return nil, fmt.Errorf("rpc client 설정 값은 모두 0보다 커야 합니다: %+v", cfg)
+	}
+
+	tracingInterceptor, err := tracing.NewInterceptor()
+	if err != nil {
+		return nil, err
+	}
+
+	breaker := NewBreaker(name, cfg.BreakerFailures, cfg.BreakerOpenDuration)
+	breaker.OnStateChange(func(name string, from, to State) {
+		slog.Warn("circuit breaker 상태 변경", "target", name, "from", from.String(), "to", to.String())
+		metrics.SetCircuitBreakerState(name, int(to))
+	})
+	metrics.SetCircuitBreakerState(name, int(StateClosed))
+
+	return &Client{
+		name:    name,
+		cfg:     cfg,
+		breaker: breaker,
+		tracing: tracingInterceptor,
+		// 제한 시간은 시도마다 ctx로 걸기 때문에 http.Client.Timeout은 두지 않음
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				DialContext: (&net.Dialer{
+					Timeout:   cfg.Timeout,
+					KeepAlive: 30 * time.Second,
+				}).DialContext,
+				ForceAttemptHTTP2:     true,
+				MaxIdleConns:          100,
+				MaxIdleConnsPerHost:   32,
+				IdleConnTimeout:       90 * time.Second,
+				TLSHandshakeTimeout:   cfg.Timeout,
+				ResponseHeaderTimeout: cfg.Timeout,
+			},
+		},
+	}, nil
+}
+
+func (c *Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+// Options: 생성된 Connect 클라이언트에 넘길 옵션 (요청 ID 전파, 지표, deadline, 재시도, circuit breaker, tracing interceptor)
+// tracing interceptor는 재시도 안쪽에 두어 시도마다 span을 만들고 traceparent 헤더를 보냄
+func (c *Client) Options() []connect.ClientOption {
+	return []connect.ClientOption{
+		connect.WithInterceptors(
+			logging.ClientInterceptor(),
+			metrics.ClientInterceptor(c.name),
+			connect.UnaryInterceptorFunc(c.intercept),
+			c.tracing,
+		),
+	}
+}
+
+// Breaker: 상태 관측용 circuit breaker
+func (c *Client) Breaker() *Breaker {
+	return c.breaker
+}
+
+// intercept: unary 호출에만 적용 (스트리밍 호출은 그대로 전달)
+func (c *Client) intercept(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		var err error
+		for attempt := 1; ; attempt++ {
+			if allowErr := c.breaker.Allow(); allowErr != nil {
+				metrics.ObserveCircuitBreakerRejected(c.name)
+				// 이미 시도한 적이 있으면 마지막 에러를 그대로 돌려줌
+				if err != nil {
+					return nil, err
+				}
+				return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("%s: %w", c.name, allowErr))
+			}
+
+			var resp connect.AnyResponse
+			resp, err = c.attempt(ctx, next, req)
+			metrics.ObserveClientAttempt(c.name, req.Spec().Procedure, err)
+			if ctx.Err() != nil {
+				// 호출자가 취소했거나 호출자의 deadline이 지난 경우는 대상 서비스의 상태로 보지 않음
+				c.breaker.Ignore()
+				return resp, err
+			}
+			c.breaker.Record(isFailure(err))
+			if err == nil {
+				return resp, nil
+			}
+
+			if attempt >= c.cfg.MaxAttempts || !isRetryable(err) {
+				return nil, err
+			}
+			if waitErr := retry.Sleep(ctx, c.backoff(attempt)); waitErr != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (c *Client) attempt(ctx context.Context, next connect.UnaryFunc, req connect.AnyRequest) (connect.AnyResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	return next(ctx, req)
+}
+
+// backoff: full jitter 방식의 재시도 대기 시간 (attempt번째 시도가 실패한 뒤)
+func (c *Client) backoff(attempt int) time.Duration {
+	return retry.Backoff{Base: c.cfg.RetryBaseDelay, Max: c.cfg.RetryMaxDelay}.Jitter(attempt - 1)
+}
+
+// isRetryable: 요청이 처리되지 않았거나 다시 보내도 되는 일시적인 실패만 재시도
+// (재시도하는 호출은 모두 멱등이어야 함 - 재고 예약처럼 ID로 중복을 막는 호출)
+func isRetryable(err error) bool {
+	switch connect.CodeOf(err) {
+	case connect.CodeUnavailable, connect.CodeDeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// isFailure: circuit breaker가 대상 서비스 장애로 세는 실패
+// NotFound, InvalidArgument 같은 업무 에러는 서비스가 정상 응답한 것이므로 성공으로 봄
+func isFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch connect.CodeOf(err) {
+	case connect.CodeUnavailable, connect.CodeDeadlineExceeded, connect.CodeInternal, connect.CodeUnknown:
+		return true
+	}
+	return false
+}
+
+// IsCircuitOpen: circuit breaker가 열려 있어 호출하지 않고 실패한 에러인지
+func IsCircuitOpen(err error) bool {
+	return errors.Is(err, ErrCircuitOpen)
+}
@@ -0,0 +1,111 @@
+package rpcerr
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	connect "connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/apperr"
+)
+
+// 분류별 connect 에러 코드
+var codes = []struct {
+	kind error
+	code connect.Code
+}{
+	{apperr.ErrInvalidInput, connect.CodeInvalidArgument},
+	{apperr.ErrNotFound, connect.CodeNotFound},
+	{apperr.ErrAlreadyExists, connect.CodeAlreadyExists},
+	{apperr.ErrConflict, connect.CodeAborted},
+	{apperr.ErrFailedPrecondition, connect.CodeFailedPrecondition},
+	{apperr.ErrPermissionDenied, connect.CodePermissionDenied},
+	{apperr.ErrUnavailable, connect.CodeUnavailable},
+}
+
+// ToConnect: 서비스 에러를 connect 에러로 변환 (두 서비스의 핸들러가 공통으로 사용)
+// 필드 정보가 있으면 google.rpc.BadRequest, 재시도 대기 시간이 있으면 google.rpc.RetryInfo 상세 정보로 함께 전달
+func ToConnect(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// 핸들러가 직접 만든 connect 에러는 그대로 전달
+	// (하위 서비스 호출에서 감싸진 connect 에러는 코드를 그대로 노출하지 않고 분류에 따름)
+	if connectErr, ok := err.(*connect.Error); ok {
+		return connectErr
+	}
+
+	code := Code(err)
+	out := connect.NewError(code, err)
+
+	if field, ok := apperr.Field(err); ok {
+		detail, detailErr := connect.NewErrorDetail(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: field, Description: err.Error()},
+			},
+		})
+		if detailErr == nil {
+			out.AddDetail(detail)
+		}
+	}
+	if delay, ok := apperr.RetryDelay(err); ok {
+		detail, detailErr := connect.NewErrorDetail(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+		if detailErr == nil {
+			out.AddDetail(detail)
+		}
+	}
+
+	return out
+}
+
+// Code: 에러 분류에 해당하는 connect 코드 (분류되지 않은 에러는 CodeInternal)
+func Code(err error) connect.Code {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return connect.CodeCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return connect.CodeDeadlineExceeded
+	}
+	for _, c := range codes {
+		if errors.Is(err, c.kind) {
+			return c.code
+		}
+	}
+	return connect.CodeInternal
+}
+
+// InvalidField: 핸들러에서 요청 필드 검증에 실패했을 때 사용
+func InvalidField(field, msg string) error {
+	return ToConnect(apperr.WithField(field, apperr.New(apperr.ErrInvalidInput, msg)))
+}
+
+// FieldViolation: 요청 필드 하나의 검증 실패
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// InvalidFields: 요청 필드 여러 개가 검증에 실패했을 때 사용 (위반 내용을 모두 google.rpc.BadRequest로 전달)
+func InvalidFields(violations []FieldViolation) error {
+	details := make([]*errdetails.BadRequest_FieldViolation, 0, len(violations))
+	messages := make([]string, 0, len(violations))
+	for _, v := range violations {
+		details = append(details, &errdetails.BadRequest_FieldViolation{Field: v.Field, Description: v.Description})
+		// 메시지 단위 규칙(여러 필드를 함께 보는 규칙)은 필드 경로가 없음
+		if v.Field == "" {
+			messages = append(messages, v.Description)
+			continue
+		}
+		messages = append(messages, v.Field+": "+v.Description)
+	}
+
+	out := connect.NewError(connect.CodeInvalidArgument, apperr.New(apperr.ErrInvalidInput, "잘못된 입력입니다: "+strings.Join(messages, ", ")))
+	if detail, err := connect.NewErrorDetail(&errdetails.BadRequest{FieldViolations: details}); err == nil {
+		out.AddDetail(detail)
+	}
+	return out
+}
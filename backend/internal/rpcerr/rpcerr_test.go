@@ -0,0 +1,143 @@
+package rpcerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/apperr"
+)
+
+func TestToConnectCode(t *testing.T) {
+	errNotFound := apperr.New(apperr.ErrNotFound, "사용자를 찾을 수 없습니다")
+
+	tests := []struct {
+		name string
+		err  error
+		want connect.Code
+	}{
+		{name: "잘못된 입력", err: apperr.New(apperr.ErrInvalidInput, "잘못된 입력"), want: connect.CodeInvalidArgument},
+		{name: "없음", err: errNotFound, want: connect.CodeNotFound},
+		{name: "감싼 에러도 분류를 따름", err: fmt.Errorf("조회 실패: %w", errNotFound), want: connect.CodeNotFound},
+		{name: "이미 존재", err: apperr.New(apperr.ErrAlreadyExists, "이미 존재"), want: connect.CodeAlreadyExists},
+		{name: "충돌", err: apperr.New(apperr.ErrConflict, "충돌"), want: connect.CodeAborted},
+		{name: "전제 조건 실패", err: apperr.New(apperr.ErrFailedPrecondition, "전제 조건"), want: connect.CodeFailedPrecondition},
+		{name: "권한 없음", err: apperr.New(apperr.ErrPermissionDenied, "권한 없음"), want: connect.CodePermissionDenied},
+		{name: "하위 서비스 장애", err: apperr.New(apperr.ErrUnavailable, "장애"), want: connect.CodeUnavailable},
+		{name: "요청 취소", err: fmt.Errorf("조회 실패: %w", context.Canceled), want: connect.CodeCanceled},
+		{name: "시간 초과", err: fmt.Errorf("조회 실패: %w", context.DeadlineExceeded), want: connect.CodeDeadlineExceeded},
+		{name: "분류되지 않은 에러", err: errors.New("알 수 없는 에러"), want: connect.CodeInternal},
+		{
+			// 하위 서비스의 connect 에러를 감싼 경우 코드를 그대로 노출하지 않음
+			name: "감싼 connect 에러는 분류를 따름",
+			err:  fmt.Errorf("상품 조회 실패: %w", connect.NewError(connect.CodeNotFound, errors.New("없음"))),
+			want: connect.CodeInternal,
+		},
+		{name: "핸들러가 만든 connect 에러는 그대로", err: connect.NewError(connect.CodeResourceExhausted, errors.New("한도 초과")), want: connect.CodeResourceExhausted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var connectErr *connect.Error
+			if !errors.As(ToConnect(tt.err), &connectErr) {
+				t.Fatalf("ToConnect(%v)가 connect 에러가 아님", tt.err)
+			}
+			if connectErr.Code() != tt.want {
+				t.Errorf("code = %s, want %s", connectErr.Code(), tt.want)
+			}
+		})
+	}
+}
+
+func TestToConnectNil(t *testing.T) {
+	if err := ToConnect(nil); err != nil {
+		t.Errorf("ToConnect(nil) = %v, want nil", err)
+	}
+}
+
+func TestToConnectDetails(t *testing.T) {
+	errInProgress := apperr.New(apperr.ErrConflict, "처리 중입니다")
+
+	tests := []struct {
+		name      string
+		err       error
+		wantField string
+		wantDelay time.Duration
+	}{
+		{name: "상세 정보 없음", err: errInProgress},
+		{name: "필드", err: apperr.WithField("email", apperr.New(apperr.ErrInvalidInput, "이메일 형식이 아닙니다")), wantField: "email"},
+		{name: "재시도 대기 시간", err: apperr.WithRetryDelay(time.Second, errInProgress), wantDelay: time.Second},
+		{
+			name:      "필드와 재시도 대기 시간",
+			err:       fmt.Errorf("생성 실패: %w", apperr.WithRetryDelay(2*time.Second, apperr.WithField("email", errInProgress))),
+			wantField: "email",
+			wantDelay: 2 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var connectErr *connect.Error
+			if !errors.As(ToConnect(tt.err), &connectErr) {
+				t.Fatalf("ToConnect(%v)가 connect 에러가 아님", tt.err)
+			}
+
+			var (
+				field string
+				delay time.Duration
+			)
+			for _, detail := range connectErr.Details() {
+				value, err := detail.Value()
+				if err != nil {
+					t.Fatalf("detail.Value: %v", err)
+				}
+				switch v := value.(type) {
+				case *errdetails.BadRequest:
+					if len(v.GetFieldViolations()) != 1 {
+						t.Fatalf("FieldViolations = %v, want 1개", v.GetFieldViolations())
+					}
+					field = v.GetFieldViolations()[0].GetField()
+				case *errdetails.RetryInfo:
+					delay = v.GetRetryDelay().AsDuration()
+				default:
+					t.Errorf("예상하지 못한 상세 정보 %T", v)
+				}
+			}
+			if field != tt.wantField {
+				t.Errorf("BadRequest 필드 = %q, want %q", field, tt.wantField)
+			}
+			if delay != tt.wantDelay {
+				t.Errorf("RetryInfo 대기 시간 = %s, want %s", delay, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestInvalidFields(t *testing.T) {
+	err := InvalidFields([]FieldViolation{
+		{Field: "email", Description: "이메일 형식이 아닙니다"},
+		{Description: "시작일이 종료일보다 늦습니다"},
+	})
+
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeInvalidArgument {
+		t.Fatalf("InvalidFields = %v, want InvalidArgument", err)
+	}
+	if want := "잘못된 입력입니다: email: 이메일 형식이 아닙니다, 시작일이 종료일보다 늦습니다"; connectErr.Message() != want {
+		t.Errorf("message = %q, want %q", connectErr.Message(), want)
+	}
+	if len(connectErr.Details()) != 1 {
+		t.Fatalf("Details = %d개, want 1개", len(connectErr.Details()))
+	}
+	value, err := connectErr.Details()[0].Value()
+	if err != nil {
+		t.Fatalf("detail.Value: %v", err)
+	}
+	badRequest, ok := value.(*errdetails.BadRequest)
+	if !ok || len(badRequest.GetFieldViolations()) != 2 || badRequest.GetFieldViolations()[1].GetField() != "" {
+		t.Errorf("BadRequest = %v, want 필드 위반 2개 (메시지 규칙은 필드 없음)", value)
+	}
+}
@@ -0,0 +1,410 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/apperr"
+	"Acho-mj/2025_Golang_MSA/backend/internal/logging"
+	"Acho-mj/2025_Golang_MSA/backend/internal/retry"
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+)
+
+const (
+	// 실행 중인 인스턴스가 saga를 점유하는 시간
+	// 단계 상태를 저장할 때와, 단계가 실행되는 동안 leaseTTL/3마다 연장하므로 오래 걸리는 단계도 다른 인스턴스가 가져가지 않음
+	DefaultLeaseTTL = 30 * time.Second
+	// lease가 만료된 saga를 찾는 주기
+	DefaultRecoveryInterval = 15 * time.Second
+	// 한 번에 재개하는 saga 수
+	recoveryBatchSize = 25
+)
+
+var (
+	// 다른 인스턴스가 saga를 가져가 이어서 실행하고 있는 경우
+	ErrLeaseLost = apperr.New(apperr.ErrConflict, "다른 인스턴스가 saga를 실행하고 있습니다")
+	// 재개한 saga가 보상까지 끝난 경우 (원래 에러는 메시지로만 남아 있음)
+	ErrCompensated = errors.New("saga가 실패하여 보상을 마쳤습니다")
+	// Execute가 saga를 끝내지 못하고 반환한 경우 (상태 저장 실패, 보상 실패, lease 상실)
+	// saga는 진행 중으로 남아 복구 루프(또는 lease를 가져간 인스턴스)가 끝까지 실행하고 Definition.Recovered를 호출함
+	ErrInFlight = apperr.New(apperr.ErrUnavailable, "saga가 아직 끝나지 않았습니다")
+)
+
+// Step: saga의 한 단계
+// 장애 후 재개하면 이미 실행된 Action이 다시 호출되거나, 실패로 기록된 Action에도 Compensate가 호출될 수 있으므로
+// Action과 Compensate는 여러 번 호출되어도 결과가 같아야 함
+type Step struct {
+	Name   string
+	Action func(ctx context.Context, sagaID string, payload []byte) error
+	// nil이면 되돌릴 작업이 없는 단계
+	Compensate func(ctx context.Context, sagaID string, payload []byte) error
+}
+
+// Definition: saga 종류별 단계 목록
+type Definition struct {
+	Type  string
+	Steps []Step
+	// Recovered: 재개한 saga가 끝났을 때 호출 (요청 경로에서 끝난 saga에는 호출하지 않음)
+	// err이 nil이면 모든 단계가 완료된 것이고, 아니면 보상까지 끝난 것
+	Recovered func(ctx context.Context, sagaID string, payload []byte, err error)
+}
+
+// Orchestrator: saga 단계를 순서대로 실행하고 단계마다 상태를 저장
+// 단계가 실패하면 완료된 단계를 역순으로 보상하고, 실행 중 파드가 죽으면 lease 만료 후 다른 인스턴스가 이어서 실행
+type Orchestrator struct {
+	repo     storage.SagaRepository
+	owner    string
+	leaseTTL time.Duration
+	now      func() time.Time
+
+	mu          sync.RWMutex
+	definitions map[string]Definition
+}
+
+// NewOrchestrator: owner는 lease를 가진 인스턴스를 구분하기 위한 이름 (보통 파드 이름)
+func NewOrchestrator(repo storage.SagaRepository, owner string, leaseTTL time.Duration) (*Orchestrator, error) {
+	if repo == nil {
+		return nil, errors.New("saga repository가 nil입니다")
+	}
+	if owner == "" {
+		return nil, errors.New("saga owner가 비어 있습니다")
+	}
+	if leaseTTL <= 0 {
+		leaseTTL = DefaultLeaseTTL
+	}
+
+	return &Orchestrator{
+		repo:        repo,
+		owner:       owner,
+		leaseTTL:    leaseTTL,
+		now:         time.Now,
+		definitions: make(map[string]Definition),
+	}, nil
+}
+
+// Register: saga 종류를 등록 (재개할 수 있도록 실행 전에 등록되어 있어야 함)
+func (o *Orchestrator) Register(def Definition) error {
+	if def.Type == "" {
+		return errors.New("saga 종류가 비어 있습니다")
+	}
+	if len(def.Steps) == 0 {
+		return fmt.Errorf("saga %s에 단계가 없습니다", def.Type)
+	}
+	names := make(map[string]struct{}, len(def.Steps))
+	for _, step := range def.Steps {
+		if step.Name == "" || step.Action == nil {
+			return fmt.Errorf("saga %s 단계의 이름과 Action은 필수입니다", def.Type)
+		}
+		if _, ok := names[step.Name]; ok {
+			return fmt.Errorf("saga %s 단계 이름이 중복되었습니다: %s", def.Type, step.Name)
+		}
+		names[step.Name] = struct{}{}
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.definitions[def.Type]; ok {
+		return fmt.Errorf("이미 등록된 saga 종류입니다: %s", def.Type)
+	}
+	o.definitions[def.Type] = def
+	return nil
+}
+
+// Execute: saga를 생성하고 끝까지 실행
+// 단계가 실패하면 보상을 실행하고 실패한 단계의 에러를 그대로 반환
+// saga를 끝내지 못하면(보상 실패 등) ErrInFlight로 감싼 에러를 반환하며,
+// saga는 진행 중으로 남아 lease 만료 후 복구 루프가 이어서 실행함
+func (o *Orchestrator) Execute(ctx context.Context, sagaType, sagaID string, payload []byte) error {
+	def, err := o.definition(sagaType)
+	if err != nil {
+		return err
+	}
+
+	steps := make([]storage.SagaStepRecord, 0, len(def.Steps))
+	for _, step := range def.Steps {
+		steps = append(steps, storage.SagaStepRecord{
+			Name:   step.Name,
+			Status: storage.SagaStepPending,
+		})
+	}
+	record := &storage.SagaRecord{
+		SagaID:         sagaID,
+		Type:           sagaType,
+		Status:         storage.SagaStatusRunning,
+		Steps:          steps,
+		Payload:        string(payload),
+		LeaseOwner:     o.owner,
+		LeaseExpiresAt: o.leaseDeadline(),
+	}
+	if err := o.repo.CreateSaga(ctx, record); err != nil {
+		return fmt.Errorf("saga 생성 실패: %w", err)
+	}
+
+	finished, err := o.run(ctx, def, record)
+	if err != nil && !finished {
+		return fmt.Errorf("%w: %w", ErrInFlight, err)
+	}
+	return err
+}
+
+// Recover: lease가 만료된 진행 중 saga를 이어서 실행하고 재개한 saga 수를 반환
+func (o *Orchestrator) Recover(ctx context.Context) (int, error) {
+	stalled, err := o.repo.ListStalledSagas(ctx, o.now(), recoveryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("중단된 saga 조회 실패: %w", err)
+	}
+
+	resumed := 0
+	for _, candidate := range stalled {
+		ok, err := o.resume(ctx, candidate.SagaID)
+		if err != nil {
+			if !errors.Is(err, ErrLeaseLost) {
+				logging.FromContext(ctx).Error("saga 재개 실패", "saga_id", candidate.SagaID, "error", err)
+			}
+			continue
+		}
+		if ok {
+			resumed++
+		}
+	}
+
+	return resumed, nil
+}
+
+// RunRecovery: ctx가 끝날 때까지 interval마다 Recover를 실행 (시작하자마자 한 번 실행)
+func (o *Orchestrator) RunRecovery(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultRecoveryInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		resumed, err := o.Recover(ctx)
+		if err != nil {
+			logging.FromContext(ctx).Error("saga 복구 실패", "error", err)
+		} else if resumed > 0 {
+			logging.FromContext(ctx).Info("중단된 saga를 재개했습니다", "count", resumed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// resume: GSI 조회 결과는 오래된 값일 수 있으므로 다시 읽어 확인한 뒤 lease를 가져와 실행
+func (o *Orchestrator) resume(ctx context.Context, sagaID string) (bool, error) {
+	record, err := o.repo.GetSaga(ctx, sagaID)
+	if err != nil {
+		return false, err
+	}
+	if !record.IsInFlight() || record.LeaseExpiresAt >= o.now().UnixMilli() {
+		return false, nil
+	}
+	def, err := o.definition(record.Type)
+	if err != nil {
+		return false, err
+	}
+
+	previousOwner := record.LeaseOwner
+	if err := o.save(ctx, record); err != nil {
+		return false, err
+	}
+	logging.FromContext(ctx).Info("saga를 이어서 실행합니다", "saga_id", record.SagaID, "saga_type", record.Type, "previous_owner", previousOwner, "status", record.Status)
+
+	finished, err := o.run(ctx, def, record)
+	if !finished {
+		return true, err
+	}
+	if def.Recovered != nil {
+		def.Recovered(context.WithoutCancel(ctx), record.SagaID, []byte(record.Payload), err)
+	}
+	return true, nil
+}
+
+// run: 상태에 따라 남은 단계를 실행하거나 보상을 이어서 실행
+// finished는 saga가 끝난 상태(completed/failed)로 저장되었는지 여부
+func (o *Orchestrator) run(ctx context.Context, def Definition, record *storage.SagaRecord) (finished bool, err error) {
+	steps, err := resolveSteps(def, record)
+	if err != nil {
+		return false, err
+	}
+	// 요청이 취소되어도 상태 저장과 보상은 끝까지 수행
+	persistCtx := context.WithoutCancel(ctx)
+
+	if record.Status == storage.SagaStatusCompensating {
+		return o.compensate(persistCtx, steps, record, fmt.Errorf("%w: %s", ErrCompensated, record.Error))
+	}
+
+	payload := []byte(record.Payload)
+	for i := range record.Steps {
+		if record.Steps[i].Status == storage.SagaStepDone {
+			continue
+		}
+		err := o.withLease(ctx, persistCtx, record, func(ctx context.Context, sagaID string) error {
+			return steps[i].Action(ctx, sagaID, payload)
+		})
+		if errors.Is(err, ErrLeaseLost) {
+			// 다른 인스턴스가 이어서 실행하고 있으므로 결과를 기록하지 않음
+			return false, err
+		}
+		if err != nil {
+			record.Steps[i].Status = storage.SagaStepFailed
+			record.Steps[i].Error = retry.ErrorMessage(err)
+			record.Status = storage.SagaStatusCompensating
+			record.Error = record.Steps[i].Error
+			if saveErr := o.save(persistCtx, record); saveErr != nil {
+				return false, errors.Join(err, saveErr)
+			}
+			return o.compensate(persistCtx, steps, record, err)
+		}
+
+		record.Steps[i].Status = storage.SagaStepDone
+		if err := o.save(persistCtx, record); err != nil {
+			return false, err
+		}
+	}
+
+	record.Status = storage.SagaStatusCompleted
+	if err := o.save(persistCtx, record); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// compensate: 실행했거나 실행하다 실패한 단계를 역순으로 되돌림
+// 보상에 실패하면 기록만 하고 cause를 반환 (saga는 compensating으로 남아 복구 루프가 다시 시도)
+func (o *Orchestrator) compensate(ctx context.Context, steps []Step, record *storage.SagaRecord, cause error) (bool, error) {
+	payload := []byte(record.Payload)
+	for i := len(record.Steps) - 1; i >= 0; i-- {
+		// 저장할 때마다 record.Steps가 새 slice로 바뀌므로 포인터를 잡아 두지 않고 index로 접근
+		if status := record.Steps[i].Status; status != storage.SagaStepDone && status != storage.SagaStepFailed {
+			continue
+		}
+		if steps[i].Compensate != nil {
+			err := o.withLease(ctx, ctx, record, func(ctx context.Context, sagaID string) error {
+				return steps[i].Compensate(ctx, sagaID, payload)
+			})
+			if errors.Is(err, ErrLeaseLost) {
+				return false, err
+			}
+			if err != nil {
+				logging.FromContext(ctx).Error("saga 단계 보상 실패", "saga_id", record.SagaID, "step", record.Steps[i].Name, "error", err)
+				record.Steps[i].Error = retry.ErrorMessage(err)
+				if saveErr := o.save(ctx, record); saveErr != nil {
+					logging.FromContext(ctx).Error("saga 상태 저장 실패", "saga_id", record.SagaID, "error", saveErr)
+				}
+				return false, cause
+			}
+		}
+		record.Steps[i].Status = storage.SagaStepCompensated
+		if err := o.save(ctx, record); err != nil {
+			logging.FromContext(ctx).Error("saga 상태 저장 실패", "saga_id", record.SagaID, "error", err)
+			return false, cause
+		}
+	}
+
+	record.Status = storage.SagaStatusFailed
+	if err := o.save(ctx, record); err != nil {
+		logging.FromContext(ctx).Error("saga 상태 저장 실패", "saga_id", record.SagaID, "error", err)
+		return false, cause
+	}
+	return true, cause
+}
+
+// withLease: fn(단계의 Action 또는 Compensate)이 실행되는 동안 leaseTTL/3마다 lease를 연장
+// 연장하다 lease를 잃으면(다른 인스턴스가 saga를 가져간 경우) fn의 ctx를 취소하고 ErrLeaseLost를 반환
+// fn이 실행되는 동안 record는 연장하는 goroutine만 바꾸므로 fn에는 saga ID를 따로 넘김
+func (o *Orchestrator) withLease(ctx, persistCtx context.Context, record *storage.SagaRecord, fn func(ctx context.Context, sagaID string) error) error {
+	stepCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	sagaID := record.SagaID
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	var leaseErr error
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(o.leaseTTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			if err := o.save(persistCtx, record); err != nil {
+				if errors.Is(err, ErrLeaseLost) {
+					leaseErr = err
+					cancel(err)
+					return
+				}
+				// 일시적인 실패는 다음 주기에 다시 연장 (lease가 만료되기 전에 두 번 더 시도함)
+				logging.FromContext(ctx).Warn("saga lease 연장 실패", "saga_id", sagaID, "error", err)
+			}
+		}
+	}()
+
+	err := fn(stepCtx, sagaID)
+	close(stop)
+	<-stopped
+	if leaseErr != nil {
+		return leaseErr
+	}
+	return err
+}
+
+// save: lease를 연장하며 저장 (다른 인스턴스가 먼저 저장했으면 ErrLeaseLost)
+func (o *Orchestrator) save(ctx context.Context, record *storage.SagaRecord) error {
+	record.LeaseOwner = o.owner
+	record.LeaseExpiresAt = o.leaseDeadline()
+	if err := o.repo.UpdateSaga(ctx, record); err != nil {
+		if errors.Is(err, storage.ErrSagaConflict) {
+			return fmt.Errorf("%w: %s", ErrLeaseLost, record.SagaID)
+		}
+		return fmt.Errorf("saga 상태 저장 실패: %w", err)
+	}
+	return nil
+}
+
+func (o *Orchestrator) definition(sagaType string) (Definition, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	def, ok := o.definitions[sagaType]
+	if !ok {
+		return Definition{}, fmt.Errorf("등록되지 않은 saga 종류입니다: %s", sagaType)
+	}
+	return def, nil
+}
+
+func (o *Orchestrator) leaseDeadline() int64 {
+	return o.now().Add(o.leaseTTL).UnixMilli()
+}
+
+// resolveSteps: 저장된 단계 이름으로 실행할 단계를 찾음
+// 배포 사이에 단계 구성이 바뀌어도 saga를 시작할 때 기록한 단계를 그대로 따름
+func resolveSteps(def Definition, record *storage.SagaRecord) ([]Step, error) {
+	byName := make(map[string]Step, len(def.Steps))
+	for _, step := range def.Steps {
+		byName[step.Name] = step
+	}
+
+	steps := make([]Step, 0, len(record.Steps))
+	for _, saved := range record.Steps {
+		step, ok := byName[saved.Name]
+		if !ok {
+			return nil, fmt.Errorf("saga %s에 알 수 없는 단계가 있습니다: %s", def.Type, saved.Name)
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
@@ -0,0 +1,264 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+)
+
+const testSagaType = "test-saga"
+
+// sagaHarness: 메모리 저장소와 직접 움직일 수 있는 시계를 쓰는 orchestrator
+type sagaHarness struct {
+	orchestrator *Orchestrator
+	repo         *storage.MemorySagaStorage
+	now          time.Time
+	// 실행된 Action/Compensate 순서 ("reserve", "undo:reserve" 등)
+	calls []string
+	// 단계 이름별로 돌려줄 에러 (Action은 이름, Compensate는 "undo:"+이름)
+	failures map[string]error
+	// Recovered가 호출될 때마다 받은 에러
+	recovered []error
+}
+
+func newSagaHarness(t *testing.T, names ...string) *sagaHarness {
+	t.Helper()
+	repo := storage.NewMemorySagaStorage()
+	// 테스트 중에는 lease 연장이 끼어들지 않도록 lease를 길게 잡음
+	orchestrator, err := NewOrchestrator(repo, "pod-a", time.Hour)
+	if err != nil {
+		t.Fatalf("NewOrchestrator: %v", err)
+	}
+	h := &sagaHarness{
+		orchestrator: orchestrator,
+		repo:         repo,
+		now:          time.Unix(1735787045, 0),
+		failures:     make(map[string]error),
+	}
+	orchestrator.now = func() time.Time { return h.now }
+
+	def := Definition{
+		Type: testSagaType,
+		Recovered: func(ctx context.Context, sagaID string, payload []byte, err error) {
+			h.recovered = append(h.recovered, err)
+		},
+	}
+	for _, name := range names {
+		def.Steps = append(def.Steps, Step{
+			Name:       name,
+			Action:     h.step(name),
+			Compensate: h.step("undo:" + name),
+		})
+	}
+	if err := orchestrator.Register(def); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	return h
+}
+
+func (h *sagaHarness) step(call string) func(ctx context.Context, sagaID string, payload []byte) error {
+	return func(ctx context.Context, sagaID string, payload []byte) error {
+		h.calls = append(h.calls, call)
+		return h.failures[call]
+	}
+}
+
+// assertSaga: 저장된 saga 상태와 단계 상태를 확인
+func (h *sagaHarness) assertSaga(t *testing.T, sagaID, wantStatus string, wantSteps ...string) {
+	t.Helper()
+	record, err := h.repo.GetSaga(context.Background(), sagaID)
+	if err != nil {
+		t.Fatalf("GetSaga: %v", err)
+	}
+	var steps []string
+	for _, step := range record.Steps {
+		steps = append(steps, step.Status)
+	}
+	if record.Status != wantStatus || !slices.Equal(steps, wantSteps) {
+		t.Fatalf("saga 상태 = %s %v, want %s %v", record.Status, steps, wantStatus, wantSteps)
+	}
+}
+
+func TestOrchestratorRegister(t *testing.T) {
+	noop := func(ctx context.Context, sagaID string, payload []byte) error { return nil }
+
+	tests := []struct {
+		name string
+		def  Definition
+	}{
+		{name: "종류 없음", def: Definition{Steps: []Step{{Name: "a", Action: noop}}}},
+		{name: "단계 없음", def: Definition{Type: "other"}},
+		{name: "단계 이름 없음", def: Definition{Type: "other", Steps: []Step{{Action: noop}}}},
+		{name: "Action 없음", def: Definition{Type: "other", Steps: []Step{{Name: "a"}}}},
+		{name: "단계 이름 중복", def: Definition{Type: "other", Steps: []Step{{Name: "a", Action: noop}, {Name: "a", Action: noop}}}},
+		{name: "이미 등록된 종류", def: Definition{Type: testSagaType, Steps: []Step{{Name: "a", Action: noop}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newSagaHarness(t, "reserve")
+			if err := h.orchestrator.Register(tt.def); err == nil {
+				t.Error("Register: want error")
+			}
+		})
+	}
+}
+
+func TestOrchestratorExecute(t *testing.T) {
+	errPay := errors.New("결제 실패")
+
+	tests := []struct {
+		name       string
+		failures   map[string]error
+		wantErr    error
+		wantCalls  []string
+		wantStatus string
+		wantSteps  []string
+	}{
+		{
+			name:       "모든 단계 성공",
+			wantCalls:  []string{"reserve", "pay", "confirm"},
+			wantStatus: storage.SagaStatusCompleted,
+			wantSteps:  []string{storage.SagaStepDone, storage.SagaStepDone, storage.SagaStepDone},
+		},
+		{
+			// 실패한 단계도 일부 실행되었을 수 있으므로 함께 보상
+			name:       "중간 단계 실패는 역순으로 보상",
+			failures:   map[string]error{"pay": errPay},
+			wantErr:    errPay,
+			wantCalls:  []string{"reserve", "pay", "undo:pay", "undo:reserve"},
+			wantStatus: storage.SagaStatusFailed,
+			wantSteps:  []string{storage.SagaStepCompensated, storage.SagaStepCompensated, storage.SagaStepPending},
+		},
+		{
+			name:       "첫 단계 실패",
+			failures:   map[string]error{"reserve": errPay},
+			wantErr:    errPay,
+			wantCalls:  []string{"reserve", "undo:reserve"},
+			wantStatus: storage.SagaStatusFailed,
+			wantSteps:  []string{storage.SagaStepCompensated, storage.SagaStepPending, storage.SagaStepPending},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newSagaHarness(t, "reserve", "pay", "confirm")
+			for call, err := range tt.failures {
+				h.failures[call] = err
+			}
+
+			err := h.orchestrator.Execute(context.Background(), testSagaType, "saga_1", []byte(`{}`))
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+			if tt.wantErr != nil && (!errors.Is(err, tt.wantErr) || errors.Is(err, ErrInFlight)) {
+				t.Fatalf("Execute error = %v, want 단계 에러 %v (ErrInFlight 아님)", err, tt.wantErr)
+			}
+			if !slices.Equal(h.calls, tt.wantCalls) {
+				t.Errorf("실행 순서 = %v, want %v", h.calls, tt.wantCalls)
+			}
+			h.assertSaga(t, "saga_1", tt.wantStatus, tt.wantSteps...)
+			if len(h.recovered) != 0 {
+				t.Errorf("요청 경로에서 끝난 saga에 Recovered가 호출됨: %v", h.recovered)
+			}
+		})
+	}
+}
+
+func TestOrchestratorExecuteUnknownType(t *testing.T) {
+	h := newSagaHarness(t, "reserve")
+	if err := h.orchestrator.Execute(context.Background(), "unknown", "saga_1", nil); err == nil {
+		t.Fatal("등록되지 않은 종류의 Execute: want error")
+	}
+}
+
+func TestOrchestratorRecoverCompensation(t *testing.T) {
+	ctx := context.Background()
+	h := newSagaHarness(t, "reserve", "pay", "confirm")
+	errPay := errors.New("결제 실패")
+	errUndo := errors.New("재고 서비스 장애")
+	h.failures["pay"] = errPay
+	h.failures["undo:reserve"] = errUndo
+
+	// 보상이 실패하면 saga를 끝내지 못하고 compensating으로 남김
+	err := h.orchestrator.Execute(ctx, testSagaType, "saga_1", []byte(`{}`))
+	if !errors.Is(err, ErrInFlight) || !errors.Is(err, errPay) {
+		t.Fatalf("Execute error = %v, want ErrInFlight와 단계 에러", err)
+	}
+	h.assertSaga(t, "saga_1", storage.SagaStatusCompensating,
+		storage.SagaStepDone, storage.SagaStepCompensated, storage.SagaStepPending)
+
+	// lease가 남아 있는 동안은 다른 인스턴스가 실행 중일 수 있으므로 재개하지 않음
+	if resumed, err := h.orchestrator.Recover(ctx); err != nil || resumed != 0 {
+		t.Fatalf("lease 만료 전 Recover = %d, %v, want 0", resumed, err)
+	}
+
+	h.calls = nil
+	delete(h.failures, "undo:reserve")
+	h.now = h.now.Add(2 * time.Hour)
+	resumed, err := h.orchestrator.Recover(ctx)
+	if err != nil || resumed != 1 {
+		t.Fatalf("Recover = %d, %v, want 1", resumed, err)
+	}
+
+	// 이미 보상한 단계는 건너뛰고 남은 보상만 실행
+	if want := []string{"undo:reserve"}; !slices.Equal(h.calls, want) {
+		t.Errorf("재개 후 실행 순서 = %v, want %v", h.calls, want)
+	}
+	h.assertSaga(t, "saga_1", storage.SagaStatusFailed,
+		storage.SagaStepCompensated, storage.SagaStepCompensated, storage.SagaStepPending)
+	if len(h.recovered) != 1 || !errors.Is(h.recovered[0], ErrCompensated) {
+		t.Errorf("Recovered 호출 = %v, want ErrCompensated 한 번", h.recovered)
+	}
+
+	// 끝난 saga는 다시 재개하지 않음
+	if resumed, err := h.orchestrator.Recover(ctx); err != nil || resumed != 0 {
+		t.Errorf("끝난 뒤 Recover = %d, %v, want 0", resumed, err)
+	}
+}
+
+func TestOrchestratorRecoverRunning(t *testing.T) {
+	ctx := context.Background()
+	h := newSagaHarness(t, "reserve", "pay", "confirm")
+
+	// 다른 파드가 첫 단계를 마친 뒤 죽은 상태
+	record := &storage.SagaRecord{
+		SagaID: "saga_1",
+		Type:   testSagaType,
+		Status: storage.SagaStatusRunning,
+		Steps: []storage.SagaStepRecord{
+			{Name: "reserve", Status: storage.SagaStepDone},
+			{Name: "pay", Status: storage.SagaStepPending},
+			{Name: "confirm", Status: storage.SagaStepPending},
+		},
+		Payload:        `{}`,
+		LeaseOwner:     "pod-b",
+		LeaseExpiresAt: h.now.Add(-time.Second).UnixMilli(),
+	}
+	if err := h.repo.CreateSaga(ctx, record); err != nil {
+		t.Fatalf("CreateSaga: %v", err)
+	}
+
+	resumed, err := h.orchestrator.Recover(ctx)
+	if err != nil || resumed != 1 {
+		t.Fatalf("Recover = %d, %v, want 1", resumed, err)
+	}
+	if want := []string{"pay", "confirm"}; !slices.Equal(h.calls, want) {
+		t.Errorf("재개 후 실행 순서 = %v, want %v", h.calls, want)
+	}
+	h.assertSaga(t, "saga_1", storage.SagaStatusCompleted,
+		storage.SagaStepDone, storage.SagaStepDone, storage.SagaStepDone)
+	if len(h.recovered) != 1 || h.recovered[0] != nil {
+		t.Errorf("Recovered 호출 = %v, want nil 에러 한 번", h.recovered)
+	}
+
+	saved, err := h.repo.GetSaga(ctx, "saga_1")
+	if err != nil {
+		t.Fatalf("GetSaga: %v", err)
+	}
+	if saved.LeaseOwner != "pod-a" {
+		t.Errorf("LeaseOwner = %s, want pod-a", saved.LeaseOwner)
+	}
+}
@@ -0,0 +1,380 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"connectrpc.com/grpchealth"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/config"
+	"Acho-mj/2025_Golang_MSA/backend/internal/health"
+	"Acho-mj/2025_Golang_MSA/backend/internal/logging"
+	"Acho-mj/2025_Golang_MSA/backend/internal/metrics"
+	"Acho-mj/2025_Golang_MSA/backend/internal/tracing"
+	"Acho-mj/2025_Golang_MSA/backend/internal/validation"
+)
+
+// Options: HTTP 서버와 종료 절차 설정
+type Options struct {
+	Addr string
+	// /metrics를 서비스하는 주소 (서비스 포트와 분리해 클러스터 내부의 수집기만 접근하도록 함)
+	MetricsAddr       string
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	// 종료 신호를 받은 뒤 readiness만 실패로 바꾸고 요청을 계속 받는 시간
+	// (Kubernetes가 파드를 endpoint에서 빼는 동안 들어오는 요청을 놓치지 않기 위함)
+	DrainPeriod time.Duration
+	// 진행 중인 요청과 백그라운드 작업이 모두 끝나기를 기다리는 최대 시간
+	ShutdownTimeout time.Duration
+	// readiness 확인 하나의 제한 시간과 결과를 재사용하는 시간
+	HealthCheckTimeout  time.Duration
+	HealthCheckCacheTTL time.Duration
+}
+
+func OptionsFrom(cfg *config.Config) Options {
+	return Options{
+		Addr:              ":" + cfg.Port,
+		MetricsAddr:       ":" + cfg.MetricsPort,
+		ReadHeaderTimeout: cfg.HTTPReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTPReadTimeout,
+		IdleTimeout:       cfg.HTTPIdleTimeout,
+		MaxHeaderBytes:    cfg.HTTPMaxHeaderBytes,
+		DrainPeriod:       cfg.ShutdownDrainPeriod,
+		ShutdownTimeout:   cfg.ShutdownTimeout,
+
+		HealthCheckTimeout:  cfg.HealthCheckTimeout,
+		HealthCheckCacheTTL: cfg.HealthCheckCacheTTL,
+	}
+}
+
+// Server: Connect 핸들러를 서비스하는 HTTP 서버
+// HTTP/1.1과 h2c(TLS 없는 HTTP/2)를 함께 받으므로 gRPC 클라이언트도 그대로 호출할 수 있음
+// SIGTERM/SIGINT를 받으면 readiness 실패 → drain → 진행 중인 요청 완료 → 백그라운드 작업 종료 순서로 멈춤
+//
+// 기본으로 등록하는 엔드포인트
+//   - /livez (/healthz): 프로세스가 요청을 받을 수 있으면 항상 200
+//   - /readyz: Check로 등록한 확인을 모두 통과하면 200, 아니면 503 (확인별 ok/fail만 JSON으로 반환하고 실패 원인은 로그로 남김)
+//   - grpc.health.v1.Health: /readyz와 같은 기준으로 SERVING/NOT_SERVING
+//   - /metrics: Prometheus 지표 (MetricsAddr에서만 서비스)
+type Server struct {
+	name string
+	opts Options
+	mux  *http.ServeMux
+	// MetricsAddr에서 서비스하는 핸들러 (/metrics)
+	metricsMux *http.ServeMux
+	checks     *health.Checker
+	// Connect 핸들러용 tracing interceptor
+	tracing connect.Interceptor
+	// Connect 핸들러용 요청 검증(buf.validate) interceptor
+	validation connect.Interceptor
+
+	// 종료가 시작되면 false (readiness 실패)
+	ready atomic.Bool
+
+	mu sync.Mutex
+	// Handle로 등록한 Connect 서비스 이름 (grpc health 요청의 service 값)
+	services map[string]struct{}
+
+	// Go로 시작한 백그라운드 작업용 ctx (HTTP 서버가 멈춘 뒤 취소됨)
+	workerCtx    context.Context
+	cancelWorker context.CancelFunc
+	workers      sync.WaitGroup
+	// OnShutdown으로 등록한 정리 작업 (백그라운드 작업이 끝난 뒤 실행)
+	onShutdown []func(ctx context.Context) error
+}
+
+func New(name string, opts Options) (*Server, error) {
+	if name == "" {
+		return nil, errors.New("서버 이름이 필요합니다")
+	}
+	if opts.Addr == "" {
+		return nil, errors.New("서버 주소가 필요합니다")
+	}
+	if opts.MetricsAddr == "" || opts.MetricsAddr == opts.Addr {
+		return nil, errors.New("지표 서버 주소가 필요하며 서버 주소와 달라야 합니다")
+	}
+
+	checks, err := health.NewChecker(opts.HealthCheckTimeout, opts.HealthCheckCacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	tracingInterceptor, err := tracing.NewInterceptor()
+	if err != nil {
+		return nil, err
+	}
+	validationInterceptor, err := validation.NewInterceptor()
+	if err != nil {
+		return nil, err
+	}
+
+	workerCtx, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		name:         name,
+		opts:         opts,
+		mux:          http.NewServeMux(),
+		metricsMux:   http.NewServeMux(),
+		checks:       checks,
+		tracing:      tracingInterceptor,
+		validation:   validationInterceptor,
+		services:     make(map[string]struct{}),
+		workerCtx:    workerCtx,
+		cancelWorker: cancel,
+	}
+	s.ready.Store(true)
+
+	live := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}
+	s.mux.HandleFunc("/livez", live)
+	// 기존 프로브 설정과의 호환을 위해 /healthz도 liveness로 유지
+	s.mux.HandleFunc("/healthz", live)
+	s.mux.HandleFunc("/readyz", s.serveReadyz)
+	s.mux.Handle(grpchealth.NewHandler(grpcHealth{s}))
+	s.metricsMux.Handle("/metrics", metrics.Handler())
+
+	return s, nil
+}
+
+// Handle: path에 핸들러 등록 (Run 전에 등록해야 함)
+// Connect 핸들러 경로("/user.UserService/")면 grpc health의 service 이름으로도 등록함
+func (s *Server) Handle(path string, handler http.Handler) {
+	s.mux.Handle(path, handler)
+
+	if service := strings.Trim(path, "/"); service != "" && !strings.Contains(service, "/") {
+		s.mu.Lock()
+		s.services[service] = struct{}{}
+		s.mu.Unlock()
+	}
+}
+
+// HandlerOptions: Connect 서비스 핸들러를 만들 때 넘기는 공통 옵션 (tracing, 요청 로그, 지표, 요청 검증 interceptor)
+// 요청 검증은 가장 안쪽에 두어 검증에 실패한 요청도 로그와 지표에 남김
+// interceptors(예: 운영용 RPC 인증)는 지표와 요청 검증 사이에 들어감
+func (s *Server) HandlerOptions(interceptors ...connect.Interceptor) []connect.HandlerOption {
+	chain := []connect.Interceptor{s.tracing, logging.ServerInterceptor(), metrics.ServerInterceptor()}
+	chain = append(chain, interceptors...)
+	chain = append(chain, s.validation)
+	return []connect.HandlerOption{
+		connect.WithInterceptors(chain...),
+	}
+}
+
+// Check: readiness 확인 등록 (DynamoDB 테이블, 다른 서비스 등 요청 처리에 필요한 자원)
+func (s *Server) Check(name string, check health.Check) {
+	s.checks.Register(name, check)
+}
+
+// Go: 백그라운드 작업 시작 (outbox relay, saga 복구 등)
+// fn에 넘기는 ctx는 HTTP 서버가 진행 중인 요청을 모두 처리한 뒤 취소되므로,
+// 종료 직전에 처리된 요청이 남긴 작업(outbox 이벤트 등)도 멈추기 전에 처리할 기회가 있음
+func (s *Server) Go(fn func(ctx context.Context)) {
+	s.workers.Add(1)
+	go func() {
+		defer s.workers.Done()
+		fn(s.workerCtx)
+	}()
+}
+
+// OnShutdown: 종료 절차 마지막(백그라운드 작업이 끝난 뒤)에 실행할 정리 작업 등록 (남은 span 내보내기 등)
+// 먼저 등록한 작업부터 실행하고, 넘기는 ctx는 ShutdownTimeout이 지나면 취소됨
+func (s *Server) OnShutdown(fn func(ctx context.Context) error) {
+	s.mu.Lock()
+	s.onShutdown = append(s.onShutdown, fn)
+	s.mu.Unlock()
+}
+
+// Ready: readiness 상태 (종료가 시작되면 false)
+func (s *Server) Ready() bool {
+	return s.ready.Load()
+}
+
+// Readiness: 종료 중이면 확인을 실행하지 않고 실패로 보고
+func (s *Server) Readiness(ctx context.Context) health.Report {
+	if !s.ready.Load() {
+		return health.Report{
+			Status: health.StatusFail,
+			Checks: map[string]health.Result{
+				"shutdown": {Status: health.StatusFail, Error: "종료 중입니다", CheckedAt: time.Now()},
+			},
+		}
+	}
+	return s.checks.Run(ctx)
+}
+
+func (s *Server) serveReadyz(w http.ResponseWriter, r *http.Request) {
+	report := s.Readiness(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	if !report.OK() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// grpcHealth: grpc.health.v1.Health 응답 (grpchealth.Checker)
+type grpcHealth struct {
+	s *Server
+}
+
+// Check: service가 비어 있으면 서버 전체, 아니면 등록된 Connect 서비스의 상태
+// 서비스별 확인은 따로 두지 않으므로 모두 readiness 결과를 따름
+func (h grpcHealth) Check(ctx context.Context, req *grpchealth.CheckRequest) (*grpchealth.CheckResponse, error) {
+	s := h.s
+	if req.Service != "" {
+		s.mu.Lock()
+		_, ok := s.services[req.Service]
+		s.mu.Unlock()
+		if !ok {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("등록되지 않은 서비스입니다: %s", req.Service))
+		}
+	}
+
+	if !s.Readiness(ctx).OK() {
+		return &grpchealth.CheckResponse{Status: grpchealth.StatusNotServing}, nil
+	}
+	return &grpchealth.CheckResponse{Status: grpchealth.StatusServing}, nil
+}
+
+// Run: 종료 신호를 받을 때까지 요청을 처리하고, 종료 절차를 마친 뒤 반환
+func (s *Server) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	return s.serve(ctx)
+}
+
+func (s *Server) serve(ctx context.Context) error {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+
+	srv := &http.Server{
+		Addr:              s.opts.Addr,
+		Handler:           s.mux,
+		Protocols:         protocols,
+		ReadHeaderTimeout: s.opts.ReadHeaderTimeout,
+		ReadTimeout:       s.opts.ReadTimeout,
+		IdleTimeout:       s.opts.IdleTimeout,
+		MaxHeaderBytes:    s.opts.MaxHeaderBytes,
+		// 응답 제한 시간은 RPC마다 다르므로 WriteTimeout 대신 클라이언트 deadline(Connect-Timeout-Ms, grpc-timeout)을 따름
+	}
+
+	metricsSrv := &http.Server{
+		Addr:              s.opts.MetricsAddr,
+		Handler:           s.metricsMux,
+		ReadHeaderTimeout: s.opts.ReadHeaderTimeout,
+		ReadTimeout:       s.opts.ReadTimeout,
+		IdleTimeout:       s.opts.IdleTimeout,
+		MaxHeaderBytes:    s.opts.MaxHeaderBytes,
+	}
+
+	listener, err := net.Listen("tcp", s.opts.Addr)
+	if err != nil {
+		_ = s.finish(context.Background())
+		return fmt.Errorf("%s 주소 %s listen 실패: %w", s.name, s.opts.Addr, err)
+	}
+	metricsListener, err := net.Listen("tcp", s.opts.MetricsAddr)
+	if err != nil {
+		_ = listener.Close()
+		_ = s.finish(context.Background())
+		return fmt.Errorf("%s 지표 주소 %s listen 실패: %w", s.name, s.opts.MetricsAddr, err)
+	}
+	slog.Info("서버 시작", "server", s.name, "addr", listener.Addr().String(), "metrics_addr", metricsListener.Addr().String())
+
+	serveErr := make(chan error, 2)
+	go func() {
+		serveErr <- srv.Serve(listener)
+	}()
+	go func() {
+		serveErr <- metricsSrv.Serve(metricsListener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		_ = srv.Close()
+		_ = metricsSrv.Close()
+		_ = s.finish(context.Background())
+		return fmt.Errorf("%s 서버 종료: %w", s.name, err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("종료 신호를 받아 drain 후 종료합니다", "server", s.name, "drain_period", s.opts.DrainPeriod.String())
+	s.ready.Store(false)
+	time.Sleep(s.opts.DrainPeriod)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.opts.ShutdownTimeout)
+	defer cancel()
+	shutdownErr := srv.Shutdown(shutdownCtx)
+	if shutdownErr != nil {
+		// 제한 시간 안에 끝나지 않은 요청은 연결을 끊음
+		_ = srv.Close()
+		shutdownErr = fmt.Errorf("%s 진행 중인 요청 종료 대기 실패: %w", s.name, shutdownErr)
+	}
+	// 지표는 요청 처리가 끝날 때까지 수집할 수 있도록 마지막에 닫음
+	_ = metricsSrv.Close()
+
+	if err := s.finish(shutdownCtx); err != nil {
+		return errors.Join(shutdownErr, err)
+	}
+	if shutdownErr != nil {
+		return shutdownErr
+	}
+	slog.Info("종료 완료", "server", s.name)
+	return nil
+}
+
+// finish: 백그라운드 작업을 멈추고 OnShutdown으로 등록한 정리 작업 실행
+func (s *Server) finish(ctx context.Context) error {
+	errs := []error{s.stopWorkers(ctx)}
+
+	ctx, cancel := context.WithTimeout(ctx, s.opts.ShutdownTimeout)
+	defer cancel()
+
+	s.mu.Lock()
+	onShutdown := slices.Clone(s.onShutdown)
+	s.mu.Unlock()
+	for _, fn := range onShutdown {
+		if err := fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s 정리 작업 실패: %w", s.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// stopWorkers: 백그라운드 작업을 취소하고 ctx가 끝나거나 ShutdownTimeout이 지날 때까지 끝나기를 기다림
+func (s *Server) stopWorkers(ctx context.Context) error {
+	s.cancelWorker()
+
+	ctx, cancel := context.WithTimeout(ctx, s.opts.ShutdownTimeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%s 백그라운드 작업이 제한 시간 안에 끝나지 않았습니다", s.name)
+	}
+}
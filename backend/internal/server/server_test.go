@@ -0,0 +1,241 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/grpchealth"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/health"
+)
+
+// freeAddr: 테스트 서버가 listen할 빈 로컬 주소
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+	return addr
+}
+
+func newTestServer(t *testing.T, drain time.Duration) *Server {
+	t.Helper()
+	s, err := New("test", Options{
+		Addr:                freeAddr(t),
+		MetricsAddr:         freeAddr(t),
+		DrainPeriod:         drain,
+		ShutdownTimeout:     time.Second,
+		HealthCheckTimeout:  time.Second,
+		HealthCheckCacheTTL: time.Nanosecond,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return s
+}
+
+// readyz: /readyz 응답 코드와 확인별 상태
+func readyz(t *testing.T, handler http.Handler) (int, map[string]string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var report struct {
+		Checks map[string]struct {
+			Status string `json:"status"`
+		} `json:"checks"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("readyz 응답 파싱 실패: %v (%s)", err, rec.Body.String())
+	}
+	checks := make(map[string]string, len(report.Checks))
+	for name, result := range report.Checks {
+		checks[name] = result.Status
+	}
+	return rec.Code, checks
+}
+
+func grpcStatus(t *testing.T, s *Server, service string) grpchealth.Status {
+	t.Helper()
+	resp, err := grpcHealth{s}.Check(context.Background(), &grpchealth.CheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("grpc health Check(%q): %v", service, err)
+	}
+	return resp.Status
+}
+
+func TestServerReadiness(t *testing.T) {
+	tests := []struct {
+		name       string
+		checkErr   error
+		shutdown   bool
+		wantCode   int
+		wantChecks map[string]string
+		wantGRPC   grpchealth.Status
+	}{
+		{
+			name:       "확인 통과",
+			wantCode:   http.StatusOK,
+			wantChecks: map[string]string{"dynamodb": health.StatusOK},
+			wantGRPC:   grpchealth.StatusServing,
+		},
+		{
+			name:       "확인 실패",
+			checkErr:   errors.New("테이블 없음"),
+			wantCode:   http.StatusServiceUnavailable,
+			wantChecks: map[string]string{"dynamodb": health.StatusFail},
+			wantGRPC:   grpchealth.StatusNotServing,
+		},
+		{
+			// 종료 중에는 확인을 실행하지 않음
+			name:       "종료 중",
+			shutdown:   true,
+			wantCode:   http.StatusServiceUnavailable,
+			wantChecks: map[string]string{"shutdown": health.StatusFail},
+			wantGRPC:   grpchealth.StatusNotServing,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, 0)
+			s.Handle("/user.UserService/", http.NotFoundHandler())
+			s.Check("dynamodb", func(ctx context.Context) error { return tt.checkErr })
+			if tt.shutdown {
+				s.ready.Store(false)
+			}
+
+			code, checks := readyz(t, s.mux)
+			if code != tt.wantCode {
+				t.Errorf("/readyz code = %d, want %d", code, tt.wantCode)
+			}
+			if len(checks) != len(tt.wantChecks) {
+				t.Errorf("/readyz checks = %v, want %v", checks, tt.wantChecks)
+			}
+			for name, want := range tt.wantChecks {
+				if checks[name] != want {
+					t.Errorf("/readyz %s = %q, want %q", name, checks[name], want)
+				}
+			}
+
+			// 서버 전체와 등록된 서비스 모두 readiness를 따름
+			for _, service := range []string{"", "user.UserService"} {
+				if got := grpcStatus(t, s, service); got != tt.wantGRPC {
+					t.Errorf("grpc health(%q) = %s, want %s", service, got, tt.wantGRPC)
+				}
+			}
+
+			rec := httptest.NewRecorder()
+			s.mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/livez", nil))
+			if rec.Code != http.StatusOK {
+				t.Errorf("/livez code = %d, want 200", rec.Code)
+			}
+		})
+	}
+}
+
+func TestServerGRPCHealthUnknownService(t *testing.T) {
+	s := newTestServer(t, 0)
+	if _, err := (grpcHealth{s}).Check(context.Background(), &grpchealth.CheckRequest{Service: "unknown.Service"}); err == nil {
+		t.Error("등록되지 않은 서비스의 grpc health: want error")
+	}
+}
+
+func TestServerDrain(t *testing.T) {
+	const drain = 300 * time.Millisecond
+	s := newTestServer(t, drain)
+
+	var (
+		mu sync.Mutex
+		// 종료 절차에서 일어난 일의 순서
+		events []string
+	)
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+	s.Handle("/slow", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// drain이 끝나 Shutdown이 시작된 뒤에 끝나는 요청
+		time.Sleep(drain)
+		record("request")
+		w.WriteHeader(http.StatusOK)
+	}))
+	s.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		record("worker")
+	})
+	s.OnShutdown(func(ctx context.Context) error {
+		record("on_shutdown")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() { result <- s.serve(ctx) }()
+
+	base := "http://" + s.opts.Addr
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		resp, err := http.Get(base + "/livez")
+		if err == nil {
+			_ = resp.Body.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("서버가 시작되지 않음: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	deadline = time.Now().Add(drain / 2)
+	for s.Ready() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// drain 중에는 readiness만 실패하고 새 요청은 계속 받음
+	resp, err := http.Get(base + "/readyz")
+	if err != nil {
+		t.Fatalf("drain 중 /readyz: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("drain 중 /readyz code = %d, want 503", resp.StatusCode)
+	}
+
+	slow := make(chan int, 1)
+	go func() {
+		resp, err := http.Get(base + "/slow")
+		if err != nil {
+			slow <- 0
+			return
+		}
+		_ = resp.Body.Close()
+		slow <- resp.StatusCode
+	}()
+
+	if err := <-result; err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+	if code := <-slow; code != http.StatusOK {
+		t.Errorf("drain 중 시작한 요청 code = %d, want 200", code)
+	}
+
+	// 진행 중인 요청이 끝난 뒤 백그라운드 작업을 멈추고, 그 뒤에 정리 작업을 실행
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{"request", "worker", "on_shutdown"}; !slices.Equal(events, want) {
+		t.Errorf("종료 순서 = %v, want %v", events, want)
+	}
+}
@@ -7,14 +7,50 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awscfg "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
 
 	"Acho-mj/2025_Golang_MSA/backend/internal/config"
+	"Acho-mj/2025_Golang_MSA/backend/internal/metrics"
+	"Acho-mj/2025_Golang_MSA/backend/internal/tracing"
 )
 
 // AWS DynamoDb에 접근하기 위한 클라이언트 객체 초기화 (연결)
 func NewDynamoClient(ctx context.Context, cfg *config.Config) (*dynamodb.Client, error) {
+	awsCfg, err := loadAWSConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return dynamodb.NewFromConfig(awsCfg, func(o *dynamodb.Options) {
+		if cfg.AWSEndpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.AWSEndpoint)
+		}
+		// operation별 요청 수, 시간, 처리량 제한, 소비 용량 지표
+		// 요청 처리 중인 호출은 operation마다 span으로 기록
+		o.APIOptions = append(o.APIOptions, metrics.DynamoDBMiddleware, tracing.DynamoDBMiddleware)
+	}), nil
+}
+
+// DynamoDB Streams 클라이언트 초기화 (AWS_ENDPOINT가 있으면 DynamoDB Local처럼 같은 endpoint를 사용)
+func NewStreamsClient(ctx context.Context, cfg *config.Config) (*dynamodbstreams.Client, error) {
+	awsCfg, err := loadAWSConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.AWSEndpoint != "" {
+		return dynamodbstreams.NewFromConfig(awsCfg, func(o *dynamodbstreams.Options) {
+			o.BaseEndpoint = aws.String(cfg.AWSEndpoint)
+		}), nil
+	}
+
+	return dynamodbstreams.NewFromConfig(awsCfg), nil
+}
+
+func loadAWSConfig(ctx context.Context, cfg *config.Config) (aws.Config, error) {
 	if cfg == nil {
-		return nil, fmt.Errorf("config가 nil입니다")
+		return aws.Config{}, fmt.Errorf("config가 nil입니다")
 	}
 
 	// AWS SDK의 설정 로딩 옵션들을 모아둔 슬라이스
@@ -24,14 +60,24 @@ func NewDynamoClient(ctx context.Context, cfg *config.Config) (*dynamodb.Client,
 
 	awsCfg, err := awscfg.LoadDefaultConfig(ctx, loadOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("AWS 설정 로드 실패: %w", err)
+		return aws.Config{}, fmt.Errorf("AWS 설정 로드 실패: %w", err)
 	}
+	return awsCfg, nil
+}
 
-	if cfg.AWSEndpoint != "" {
-		return dynamodb.NewFromConfig(awsCfg, func(o *dynamodb.Options) {
-			o.BaseEndpoint = aws.String(cfg.AWSEndpoint)
-		}), nil
+// PingTable: 테이블을 읽고 쓸 수 있는 상태인지 DescribeTable로 확인 (readiness 확인용)
+// 자격 증명이나 권한, 테이블 이름이 잘못되었으면 에러를 반환
+func PingTable(ctx context.Context, client *dynamodb.Client, table string) error {
+	out, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(table),
+	})
+	if err != nil {
+		return fmt.Errorf("테이블 %s: %w", table, dynamoError("DescribeTable", err))
+	}
+	switch status := out.Table.TableStatus; status {
+	case types.TableStatusActive, types.TableStatusUpdating:
+		return nil
+	default:
+		return fmt.Errorf("테이블 %s 상태가 %s입니다", table, status)
 	}
-
-	return dynamodb.NewFromConfig(awsCfg), nil
 }
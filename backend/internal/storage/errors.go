@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/apperr"
+	"Acho-mj/2025_Golang_MSA/backend/internal/logging"
+)
+
+// 저장소 에러 (모두 apperr의 분류 중 하나에 속함)
+var (
+	ErrInvalidArgument = apperr.New(apperr.ErrInvalidInput, "저장소 요청 값이 올바르지 않습니다")
+
+	ErrUserNotFound       = apperr.New(apperr.ErrNotFound, "사용자를 찾을 수 없습니다")
+	ErrUserAlreadyExists  = apperr.New(apperr.ErrAlreadyExists, "이미 존재하는 사용자입니다")
+	ErrEmailAlreadyExists = apperr.New(apperr.ErrAlreadyExists, "이미 사용 중인 이메일입니다")
+	// 조회한 이후 다른 요청이 사용자를 먼저 변경한 경우
+	ErrUserConflict = apperr.New(apperr.ErrConflict, "사용자가 동시에 변경되었습니다")
+
+	ErrOrderNotFound      = apperr.New(apperr.ErrNotFound, "주문을 찾을 수 없습니다")
+	ErrOrderAlreadyExists = apperr.New(apperr.ErrAlreadyExists, "이미 존재하는 주문입니다")
+	// 조회한 이후 다른 요청이 주문 상태를 먼저 변경한 경우
+	ErrOrderStatusConflict = apperr.New(apperr.ErrConflict, "주문 상태가 이미 변경되었습니다")
+
+	ErrProductNotFound      = apperr.New(apperr.ErrNotFound, "상품을 찾을 수 없습니다")
+	ErrProductAlreadyExists = apperr.New(apperr.ErrAlreadyExists, "이미 존재하는 상품입니다")
+
+	// 가용 재고보다 많이 차감하려는 경우
+	ErrInsufficientStock        = apperr.New(apperr.ErrFailedPrecondition, "재고가 부족합니다")
+	ErrReservationNotFound      = apperr.New(apperr.ErrNotFound, "재고 예약을 찾을 수 없습니다")
+	ErrReservationAlreadyExists = apperr.New(apperr.ErrAlreadyExists, "이미 존재하는 재고 예약입니다")
+	// 조회한 이후 다른 요청이 예약 상태를 먼저 변경한 경우
+	ErrReservationConflict = apperr.New(apperr.ErrConflict, "재고 예약 상태가 이미 변경되었습니다")
+
+	ErrSagaNotFound      = apperr.New(apperr.ErrNotFound, "saga를 찾을 수 없습니다")
+	ErrSagaAlreadyExists = apperr.New(apperr.ErrAlreadyExists, "이미 존재하는 saga입니다")
+	// 읽은 이후 다른 인스턴스가 saga를 먼저 저장한 경우
+	ErrSagaConflict = apperr.New(apperr.ErrConflict, "saga가 동시에 변경되었습니다")
+
+	// 이미 발행되었거나 다른 relay가 발행 중인 이벤트
+	ErrOutboxEventClaimed = apperr.New(apperr.ErrConflict, "이미 처리 중이거나 발행된 이벤트입니다")
+
+	ErrCheckpointNotFound = apperr.New(apperr.ErrNotFound, "스트림 checkpoint를 찾을 수 없습니다")
+	// 다른 인스턴스가 shard를 처리 중인 경우
+	ErrShardLeaseHeld = apperr.New(apperr.ErrConflict, "다른 인스턴스가 처리 중인 shard입니다")
+	// 처리하는 동안 lease가 만료되어 다른 인스턴스가 shard를 가져간 경우
+	ErrShardLeaseLost = apperr.New(apperr.ErrConflict, "shard lease를 잃었습니다")
+
+	// 같은 idempotency key로 이미 기록된 요청이 있는 경우
+	ErrIdempotencyKeyExists = apperr.New(apperr.ErrAlreadyExists, "이미 사용된 idempotency key입니다")
+	// 완료하려는 pending 기록을 lease 만료 후 다른 요청이 가져갔거나 이미 정리된 경우
+	ErrIdempotencyClaimLost = apperr.New(apperr.ErrConflict, "idempotency 기록을 다른 요청이 가져갔습니다")
+)
+
+// 재시도하면 성공할 수 있는 DynamoDB 에러 코드
+var unavailableCodes = map[string]struct{}{
+	"ProvisionedThroughputExceededException": {},
+	"RequestLimitExceeded":                   {},
+	"ThrottlingException":                    {},
+	"InternalServerError":                    {},
+	"ServiceUnavailable":                     {},
+}
+
+// dynamoError: DynamoDB 호출 실패를 분류
+// 스로틀링, 서버 장애, 네트워크 오류는 apperr.ErrUnavailable, 트랜잭션 충돌은 apperr.ErrConflict로 분류
+func dynamoError(op string, err error) error {
+	switch {
+	case isTransactionConflict(err):
+		return fmt.Errorf("%s 실패: %w: %w", op, apperr.ErrConflict, err)
+	case isUnavailable(err):
+		return fmt.Errorf("%s 실패: %w: %w", op, apperr.ErrUnavailable, err)
+	}
+	return fmt.Errorf("%s 실패: %w", op, err)
+}
+
+// transactError: TransactWriteItems 실패를 분류하고, 트랜잭션이 취소되었으면 항목별 취소 사유를 요청 로그에 남김
+// (처리하지 않은 조건 실패나 충돌이 어느 항목에서 났는지는 에러 메시지만으로 알 수 없음)
+func transactError(ctx context.Context, err error) error {
+	var canceled *types.TransactionCanceledException
+	if errors.As(err, &canceled) {
+		reasons := make([]string, len(canceled.CancellationReasons))
+		for i, reason := range canceled.CancellationReasons {
+			reasons[i] = aws.ToString(reason.Code)
+		}
+		logging.FromContext(ctx).Warn("DynamoDB 트랜잭션 취소", "reasons", reasons)
+	}
+	return dynamoError("TransactWriteItems", err)
+}
+
+func isUnavailable(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		if _, ok := unavailableCodes[apiErr.ErrorCode()]; ok {
+			return true
+		}
+	}
+
+	var sendErr *smithyhttp.RequestSendError
+	if errors.As(err, &sendErr) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func isTransactionConflict(err error) bool {
+	var tce *types.TransactionConflictException
+	if errors.As(err, &tce) {
+		return true
+	}
+
+	var canceled *types.TransactionCanceledException
+	if errors.As(err, &canceled) {
+		for _, reason := range canceled.CancellationReasons {
+			if aws.ToString(reason.Code) == "TransactionConflict" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,266 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	IdempotencyStatusPending   = "pending"
+	IdempotencyStatusCompleted = "completed"
+)
+
+// IdempotencyRecord: idempotency key별 요청 처리 기록
+// expires_at(epoch 초)은 테이블의 TTL 속성으로 설정되어 만료 후 자동 삭제됨
+type IdempotencyRecord struct {
+	Key         string    `dynamodbav:"idempotency_key"`
+	RequestHash string    `dynamodbav:"request_hash"`
+	Status      string    `dynamodbav:"status"`
+	ResourceID  string    `dynamodbav:"resource_id"`
+	CreatedAt   time.Time `dynamodbav:"created_at"`
+	ExpiresAt   int64     `dynamodbav:"expires_at"`
+	// pending 기록을 예약한 요청을 구분하는 값
+	Token string `dynamodbav:"token,omitempty"`
+	// pending 기록의 점유 만료 시각 (epoch 밀리초)
+	// 지나면 요청을 처리하던 인스턴스가 죽은 것으로 보고 같은 key의 다음 요청이 기록을 가져감 (0이면 만료되지 않음)
+	LeaseExpiresAt int64 `dynamodbav:"lease_expires_at,omitempty"`
+	// 완료할 때 기록한 첫 응답 (재시도에 그대로 돌려줌, 이 속성이 생기기 전에 완료된 기록은 비어 있음)
+	Response []byte `dynamodbav:"response,omitempty"`
+}
+
+// IdempotencyClaim: pending 기록을 완료하거나 지울 때 확인하는 조건 (빈 값은 확인하지 않음)
+type IdempotencyClaim struct {
+	// Reserve할 때 기록한 Token (lease 만료 후 다른 요청이 가져간 기록은 건드리지 않음)
+	Token string
+	// 요청 경로 밖(재개된 saga 등)에서 정리할 때는 Token을 모르므로 요청 해시와 Bind한 리소스 ID로 확인
+	RequestHash string
+	ResourceID  string
+}
+
+// condition: status가 pending이고 claim의 값이 모두 일치하는 조건
+func (c IdempotencyClaim) condition() expression.ConditionBuilder {
+	cond := expression.Name("status").Equal(expression.Value(IdempotencyStatusPending))
+	if c.Token != "" {
+		cond = cond.And(expression.Name("token").Equal(expression.Value(c.Token)))
+	}
+	if c.RequestHash != "" {
+		cond = cond.And(expression.Name("request_hash").Equal(expression.Value(c.RequestHash)))
+	}
+	if c.ResourceID != "" {
+		cond = cond.And(expression.Name("resource_id").Equal(expression.Value(c.ResourceID)))
+	}
+	return cond
+}
+
+// matches: condition과 같은 조건 (메모리 저장소용)
+func (c IdempotencyClaim) matches(record IdempotencyRecord) bool {
+	return record.Status == IdempotencyStatusPending &&
+		(c.Token == "" || record.Token == c.Token) &&
+		(c.RequestHash == "" || record.RequestHash == c.RequestHash) &&
+		(c.ResourceID == "" || record.ResourceID == c.ResourceID)
+}
+
+type IdempotencyStorage struct {
+	client    *dynamodb.Client
+	tableName string
+}
+
+func NewIdempotencyStorage(client *dynamodb.Client, tableName string) (*IdempotencyStorage, error) {
+	if client == nil {
+		return nil, errors.New("dynamodb client가 nil입니다")
+	}
+	if tableName == "" {
+		return nil, errors.New("tableName이 비어 있습니다")
+	}
+
+	return &IdempotencyStorage{
+		client:    client,
+		tableName: tableName,
+	}, nil
+}
+
+// Reserve: key가 없거나, 기록이 만료되었거나, pending 기록의 lease가 만료된 경우에만 pending 기록을 생성
+// 이미 유효한 기록이 있으면 ErrIdempotencyKeyExists와 함께 기존 기록을 반환
+func (s *IdempotencyStorage) Reserve(ctx context.Context, record *IdempotencyRecord) (*IdempotencyRecord, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("IdempotencyStorage가 초기화되지 않았습니다")
+	}
+	if record == nil || record.Key == "" {
+		return nil, fmt.Errorf("%w: idempotency key가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	av, err := attributevalue.MarshalMap(record)
+	if err != nil {
+		return nil, fmt.Errorf("idempotency 기록 marshal 실패: %w", err)
+	}
+
+	// TTL 삭제는 지연될 수 있으므로 만료 시각이 지난 기록은 덮어쓸 수 있도록 함
+	// lease가 지난 pending 기록은 처리하던 인스턴스가 죽은 것이므로 가져감 (lease가 없는 기록은 TTL까지 유지)
+	now := time.Now()
+	expr, err := expression.NewBuilder().
+		WithCondition(expression.Or(
+			expression.AttributeNotExists(expression.Name("idempotency_key")),
+			expression.Name("expires_at").LessThan(expression.Value(now.Unix())),
+			expression.And(
+				expression.Name("status").Equal(expression.Value(IdempotencyStatusPending)),
+				expression.Name("lease_expires_at").LessThan(expression.Value(now.UnixMilli())),
+			),
+		)).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                           aws.String(s.tableName),
+		Item:                                av,
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			var existing IdempotencyRecord
+			if err := attributevalue.UnmarshalMap(ccfe.Item, &existing); err != nil {
+				return nil, fmt.Errorf("idempotency 기록 언마샬 실패: %w", err)
+			}
+			return &existing, fmt.Errorf("%w: %s", ErrIdempotencyKeyExists, record.Key)
+		}
+		return nil, dynamoError("PutItem", err)
+	}
+
+	return nil, nil
+}
+
+// Bind: 요청 경로 밖에서 끝날 수 있는 작업(saga)을 시작하기 전에 pending 기록에 리소스 ID를 묶음
+// lease를 없애므로 작업이 끝나 Complete/Release할 때까지 같은 key의 다른 요청이 기록을 가져가지 못함
+// claim과 맞는 pending 기록이 없으면 ErrIdempotencyClaimLost
+func (s *IdempotencyStorage) Bind(ctx context.Context, key string, claim IdempotencyClaim, resourceID string) error {
+	if s == nil || s.client == nil {
+		return errors.New("IdempotencyStorage가 초기화되지 않았습니다")
+	}
+	if key == "" || resourceID == "" {
+		return fmt.Errorf("%w: idempotency key 또는 resourceID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.
+			Set(expression.Name("resource_id"), expression.Value(resourceID)).
+			Remove(expression.Name("lease_expires_at"))).
+		WithCondition(claim.condition()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"idempotency_key": &types.AttributeValueMemberS{Value: key},
+		},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return fmt.Errorf("%w: %s", ErrIdempotencyClaimLost, key)
+		}
+		return dynamoError("UpdateItem", err)
+	}
+
+	return nil
+}
+
+// Complete: pending 기록에 생성된 리소스 ID와 응답을 기록하여 이후 재시도에서 재사용할 수 있게 함
+// claim과 맞는 pending 기록이 없으면 ErrIdempotencyClaimLost
+func (s *IdempotencyStorage) Complete(ctx context.Context, key string, claim IdempotencyClaim, resourceID string, response []byte) error {
+	if s == nil || s.client == nil {
+		return errors.New("IdempotencyStorage가 초기화되지 않았습니다")
+	}
+	if key == "" || resourceID == "" {
+		return fmt.Errorf("%w: idempotency key 또는 resourceID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	update := expression.
+		Set(expression.Name("status"), expression.Value(IdempotencyStatusCompleted)).
+		Set(expression.Name("resource_id"), expression.Value(resourceID)).
+		Remove(expression.Name("lease_expires_at"))
+	if len(response) > 0 {
+		update = update.Set(expression.Name("response"), expression.Value(response))
+	}
+	expr, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(claim.condition()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"idempotency_key": &types.AttributeValueMemberS{Value: key},
+		},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return fmt.Errorf("%w: %s", ErrIdempotencyClaimLost, key)
+		}
+		return dynamoError("UpdateItem", err)
+	}
+
+	return nil
+}
+
+// Release: 요청 처리에 실패한 경우 pending 기록을 삭제하여 같은 key로 다시 시도할 수 있게 함
+// claim과 맞는 pending 기록이 없으면(이미 지웠거나 다른 요청이 가져간 경우) 아무것도 하지 않음
+func (s *IdempotencyStorage) Release(ctx context.Context, key string, claim IdempotencyClaim) error {
+	if s == nil || s.client == nil {
+		return errors.New("IdempotencyStorage가 초기화되지 않았습니다")
+	}
+	if key == "" {
+		return fmt.Errorf("%w: idempotency key가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	expr, err := expression.NewBuilder().WithCondition(claim.condition()).Build()
+	if err != nil {
+		return fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	_, err = s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"idempotency_key": &types.AttributeValueMemberS{Value: key},
+		},
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return nil
+		}
+		return dynamoError("DeleteItem", err)
+	}
+
+	return nil
+}
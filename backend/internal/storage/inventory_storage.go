@@ -0,0 +1,409 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// 예약 아이템은 재고 테이블에 product_id = "RESERVATION#<예약 ID>"로 함께 저장
+const reservationKeyPrefix = "RESERVATION#"
+
+// 예약 상태
+const (
+	ReservationStatusReserved  = "reserved"
+	ReservationStatusCommitted = "committed"
+	ReservationStatusReleased  = "released"
+)
+
+// MaxReservationLines: 한 번에 예약할 수 있는 상품 수
+// TransactWriteItems 한도(100개)에서 예약 아이템 1개를 뺀 값
+const MaxReservationLines = 99
+
+type InventoryStorage struct {
+	client    *dynamodb.Client
+	tableName string
+}
+
+// StockRecord: 상품별 재고
+type StockRecord struct {
+	ProductID string `dynamodbav:"product_id"`
+	// 주문 가능한 재고
+	Quantity int64 `dynamodbav:"quantity"`
+	// 예약되었지만 아직 확정되지 않은 수량
+	Reserved  int64     `dynamodbav:"reserved"`
+	UpdatedAt time.Time `dynamodbav:"updated_at"`
+}
+
+type ReservationRecord struct {
+	ReservationID string            `dynamodbav:"reservation_id"`
+	Lines         []ReservationLine `dynamodbav:"lines"`
+	Status        string            `dynamodbav:"status"`
+	CreatedAt     time.Time         `dynamodbav:"created_at"`
+	UpdatedAt     time.Time         `dynamodbav:"updated_at"`
+}
+
+type ReservationLine struct {
+	ProductID string `dynamodbav:"product_id"`
+	Quantity  int32  `dynamodbav:"quantity"`
+}
+
+func NewInventoryStorage(client *dynamodb.Client, tableName string) (*InventoryStorage, error) {
+	if client == nil {
+		return nil, errors.New("dynamodb client가 nil입니다")
+	}
+	if tableName == "" {
+		return nil, errors.New("tableName이 비어 있습니다")
+	}
+
+	return &InventoryStorage{
+		client:    client,
+		tableName: tableName,
+	}, nil
+}
+
+// GetStock: 재고가 등록되지 않은 상품은 수량 0으로 반환
+func (s *InventoryStorage) GetStock(ctx context.Context, productID string) (*StockRecord, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("InventoryStorage가 초기화되지 않았습니다")
+	}
+	if err := validateStockKey(productID); err != nil {
+		return nil, err
+	}
+
+	out, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(s.tableName),
+		Key:            stockKey(productID),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, dynamoError("GetItem", err)
+	}
+	if out.Item == nil {
+		return &StockRecord{ProductID: productID}, nil
+	}
+
+	var record StockRecord
+	if err := attributevalue.UnmarshalMap(out.Item, &record); err != nil {
+		return nil, fmt.Errorf("재고 언마샬 실패: %w", err)
+	}
+
+	return &record, nil
+}
+
+// AdjustStock: 가용 재고에 delta를 더함 (차감 후 재고가 음수가 되면 ErrInsufficientStock)
+func (s *InventoryStorage) AdjustStock(ctx context.Context, productID string, delta int64) (*StockRecord, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("InventoryStorage가 초기화되지 않았습니다")
+	}
+	if err := validateStockKey(productID); err != nil {
+		return nil, err
+	}
+	if delta == 0 {
+		return nil, fmt.Errorf("%w: delta가 0입니다", ErrInvalidArgument)
+	}
+
+	quantity := expression.Name("quantity")
+	builder := expression.NewBuilder().WithUpdate(
+		expression.Set(quantity, expression.Plus(quantity.IfNotExists(expression.Value(0)), expression.Value(delta))).
+			Set(expression.Name("reserved"), expression.Name("reserved").IfNotExists(expression.Value(0))).
+			Set(expression.Name("updated_at"), expression.Value(time.Now().UTC())),
+	)
+	if delta < 0 {
+		builder = builder.WithCondition(quantity.GreaterThanEqual(expression.Value(-delta)))
+	}
+	expr, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	out, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(s.tableName),
+		Key:                       stockKey(productID),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ReturnValues:              types.ReturnValueAllNew,
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return nil, fmt.Errorf("%w: %s", ErrInsufficientStock, productID)
+		}
+		return nil, dynamoError("UpdateItem", err)
+	}
+
+	var record StockRecord
+	if err := attributevalue.UnmarshalMap(out.Attributes, &record); err != nil {
+		return nil, fmt.Errorf("업데이트 결과 언마샬 실패: %w", err)
+	}
+
+	return &record, nil
+}
+
+// Reserve: 예약 아이템 기록과 모든 상품의 재고 차감(quantity >= 예약 수량 조건)을 하나의 트랜잭션으로 처리
+// 재고가 부족한 상품이 하나라도 있으면 아무것도 차감되지 않음
+func (s *InventoryStorage) Reserve(ctx context.Context, record *ReservationRecord) error {
+	if s == nil || s.client == nil {
+		return errors.New("InventoryStorage가 초기화되지 않았습니다")
+	}
+	if err := validateReservation(record); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = now
+	}
+	record.UpdatedAt = now
+	record.Status = ReservationStatusReserved
+
+	item, err := marshalReservation(record)
+	if err != nil {
+		return err
+	}
+
+	transactItems := make([]types.TransactWriteItem, 0, len(record.Lines)+1)
+	transactItems = append(transactItems, types.TransactWriteItem{
+		Put: &types.Put{
+			TableName:           aws.String(s.tableName),
+			Item:                item,
+			ConditionExpression: aws.String("attribute_not_exists(product_id)"),
+		},
+	})
+	for _, line := range record.Lines {
+		n := int64(line.Quantity)
+		expr, err := expression.NewBuilder().
+			WithUpdate(expression.
+				Set(expression.Name("quantity"), expression.Name("quantity").Minus(expression.Value(n))).
+				Set(expression.Name("reserved"), expression.Plus(expression.Name("reserved").IfNotExists(expression.Value(0)), expression.Value(n))).
+				Set(expression.Name("updated_at"), expression.Value(now))).
+			WithCondition(expression.Name("quantity").GreaterThanEqual(expression.Value(n))).
+			Build()
+		if err != nil {
+			return fmt.Errorf("expression 빌드 실패: %w", err)
+		}
+		transactItems = append(transactItems, types.TransactWriteItem{
+			Update: &types.Update{
+				TableName:                 aws.String(s.tableName),
+				Key:                       stockKey(line.ProductID),
+				UpdateExpression:          expr.Update(),
+				ConditionExpression:       expr.Condition(),
+				ExpressionAttributeNames:  expr.Names(),
+				ExpressionAttributeValues: expr.Values(),
+			},
+		})
+	}
+
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if err != nil {
+		switch idx := failedConditionIndex(err); {
+		case idx == 0:
+			return fmt.Errorf("%w: %s", ErrReservationAlreadyExists, record.ReservationID)
+		case idx > 0:
+			return &StockShortageError{LineIndex: idx - 1, ProductID: record.Lines[idx-1].ProductID}
+		}
+		return transactError(ctx, err)
+	}
+
+	return nil
+}
+
+func (s *InventoryStorage) GetReservation(ctx context.Context, reservationID string) (*ReservationRecord, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("InventoryStorage가 초기화되지 않았습니다")
+	}
+	if reservationID == "" {
+		return nil, fmt.Errorf("%w: reservationID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	out, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(s.tableName),
+		Key:            stockKey(reservationKey(reservationID)),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, dynamoError("GetItem", err)
+	}
+	if out.Item == nil {
+		return nil, fmt.Errorf("%w: %s", ErrReservationNotFound, reservationID)
+	}
+
+	var record ReservationRecord
+	if err := attributevalue.UnmarshalMap(out.Item, &record); err != nil {
+		return nil, fmt.Errorf("예약 언마샬 실패: %w", err)
+	}
+
+	return &record, nil
+}
+
+// TransitionReservation: 예약 상태를 current.Status에서 to로 바꾸고 재고를 함께 조정
+// reserved -> committed: reserved 차감
+// reserved -> released: reserved 차감, quantity 복구
+// committed -> released: quantity 복구
+// 조회 이후 다른 요청이 상태를 바꿨다면 ErrReservationConflict
+func (s *InventoryStorage) TransitionReservation(ctx context.Context, current *ReservationRecord, to string) (*ReservationRecord, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("InventoryStorage가 초기화되지 않았습니다")
+	}
+	if current == nil || current.ReservationID == "" {
+		return nil, fmt.Errorf("%w: 예약 정보가 비어 있습니다", ErrInvalidArgument)
+	}
+	restock, unreserve, err := reservationStockChange(current.Status, to)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.
+			Set(expression.Name("status"), expression.Value(to)).
+			Set(expression.Name("updated_at"), expression.Value(now))).
+		WithCondition(expression.Name("status").Equal(expression.Value(current.Status))).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	transactItems := make([]types.TransactWriteItem, 0, len(current.Lines)+1)
+	transactItems = append(transactItems, types.TransactWriteItem{
+		Update: &types.Update{
+			TableName:                 aws.String(s.tableName),
+			Key:                       stockKey(reservationKey(current.ReservationID)),
+			UpdateExpression:          expr.Update(),
+			ConditionExpression:       expr.Condition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+		},
+	})
+	for _, line := range current.Lines {
+		n := int64(line.Quantity)
+		update := expression.Set(expression.Name("updated_at"), expression.Value(now))
+		if restock {
+			update = update.Set(expression.Name("quantity"), expression.Name("quantity").Plus(expression.Value(n)))
+		}
+		if unreserve {
+			update = update.Set(expression.Name("reserved"), expression.Name("reserved").Minus(expression.Value(n)))
+		}
+		lineExpr, err := expression.NewBuilder().WithUpdate(update).Build()
+		if err != nil {
+			return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+		}
+		transactItems = append(transactItems, types.TransactWriteItem{
+			Update: &types.Update{
+				TableName:                 aws.String(s.tableName),
+				Key:                       stockKey(line.ProductID),
+				UpdateExpression:          lineExpr.Update(),
+				ExpressionAttributeNames:  lineExpr.Names(),
+				ExpressionAttributeValues: lineExpr.Values(),
+			},
+		})
+	}
+
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if err != nil {
+		if failedConditionIndex(err) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrReservationConflict, current.ReservationID)
+		}
+		return nil, transactError(ctx, err)
+	}
+
+	updated := *current
+	updated.Lines = append([]ReservationLine(nil), current.Lines...)
+	updated.Status = to
+	updated.UpdatedAt = now
+	return &updated, nil
+}
+
+// StockShortageError: 예약 중 재고가 부족한 상품
+type StockShortageError struct {
+	LineIndex int
+	ProductID string
+}
+
+func (e *StockShortageError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInsufficientStock.Error(), e.ProductID)
+}
+
+func (e *StockShortageError) Unwrap() error { return ErrInsufficientStock }
+
+// reservationStockChange: 상태 변경에 따라 quantity 복구(restock), reserved 차감(unreserve) 여부를 결정
+func reservationStockChange(from, to string) (restock, unreserve bool, err error) {
+	switch {
+	case from == ReservationStatusReserved && to == ReservationStatusCommitted:
+		return false, true, nil
+	case from == ReservationStatusReserved && to == ReservationStatusReleased:
+		return true, true, nil
+	case from == ReservationStatusCommitted && to == ReservationStatusReleased:
+		return true, false, nil
+	}
+	return false, false, fmt.Errorf("%w: 예약 상태를 %s에서 %s로 바꿀 수 없습니다", ErrInvalidArgument, from, to)
+}
+
+func validateReservation(record *ReservationRecord) error {
+	if record == nil {
+		return fmt.Errorf("%w: ReservationRecord가 nil입니다", ErrInvalidArgument)
+	}
+	if record.ReservationID == "" {
+		return fmt.Errorf("%w: ReservationRecord.ReservationID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if len(record.Lines) == 0 || len(record.Lines) > MaxReservationLines {
+		return fmt.Errorf("%w: 예약 상품 수는 1~%d개여야 합니다", ErrInvalidArgument, MaxReservationLines)
+	}
+	// 같은 트랜잭션에서 한 아이템을 두 번 수정할 수 없으므로 상품 중복을 허용하지 않음
+	seen := make(map[string]struct{}, len(record.Lines))
+	for _, line := range record.Lines {
+		if err := validateStockKey(line.ProductID); err != nil {
+			return err
+		}
+		if line.Quantity <= 0 {
+			return fmt.Errorf("%w: 예약 수량은 0보다 커야 합니다: %s", ErrInvalidArgument, line.ProductID)
+		}
+		if _, ok := seen[line.ProductID]; ok {
+			return fmt.Errorf("%w: 중복된 상품입니다: %s", ErrInvalidArgument, line.ProductID)
+		}
+		seen[line.ProductID] = struct{}{}
+	}
+	return nil
+}
+
+func validateStockKey(productID string) error {
+	if productID == "" {
+		return fmt.Errorf("%w: productID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if strings.HasPrefix(productID, reservationKeyPrefix) {
+		return fmt.Errorf("%w: 사용할 수 없는 productID입니다: %s", ErrInvalidArgument, productID)
+	}
+	return nil
+}
+
+func marshalReservation(record *ReservationRecord) (map[string]types.AttributeValue, error) {
+	av, err := attributevalue.MarshalMap(record)
+	if err != nil {
+		return nil, fmt.Errorf("예약 marshal 실패: %w", err)
+	}
+	av["product_id"] = &types.AttributeValueMemberS{Value: reservationKey(record.ReservationID)}
+	return av, nil
+}
+
+func stockKey(productID string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"product_id": &types.AttributeValueMemberS{Value: productID},
+	}
+}
+
+func reservationKey(reservationID string) string {
+	return reservationKeyPrefix + reservationID
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoryIdempotencyStorage: IdempotencyStorage와 같은 조건을 따르는 메모리 저장소
+// 만료된 기록은 TTL 삭제 대신 Reserve 시점에 덮어씀
+type MemoryIdempotencyStorage struct {
+	mu      sync.Mutex
+	records map[string]IdempotencyRecord
+}
+
+func NewMemoryIdempotencyStorage() *MemoryIdempotencyStorage {
+	return &MemoryIdempotencyStorage{
+		records: make(map[string]IdempotencyRecord),
+	}
+}
+
+func (s *MemoryIdempotencyStorage) Reserve(ctx context.Context, record *IdempotencyRecord) (*IdempotencyRecord, error) {
+	if record == nil || record.Key == "" {
+		return nil, fmt.Errorf("%w: idempotency key가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if existing, ok := s.records[record.Key]; ok && existing.ExpiresAt >= now.Unix() {
+		leaseExpired := existing.Status == IdempotencyStatusPending && existing.LeaseExpiresAt != 0 && existing.LeaseExpiresAt < now.UnixMilli()
+		if !leaseExpired {
+			existing.Response = bytes.Clone(existing.Response)
+			return &existing, fmt.Errorf("%w: %s", ErrIdempotencyKeyExists, record.Key)
+		}
+	}
+	s.records[record.Key] = *record
+
+	return nil, nil
+}
+
+func (s *MemoryIdempotencyStorage) Bind(ctx context.Context, key string, claim IdempotencyClaim, resourceID string) error {
+	if key == "" || resourceID == "" {
+		return fmt.Errorf("%w: idempotency key 또는 resourceID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok || !claim.matches(record) {
+		return fmt.Errorf("%w: %s", ErrIdempotencyClaimLost, key)
+	}
+	record.ResourceID = resourceID
+	record.LeaseExpiresAt = 0
+	s.records[key] = record
+
+	return nil
+}
+
+func (s *MemoryIdempotencyStorage) Complete(ctx context.Context, key string, claim IdempotencyClaim, resourceID string, response []byte) error {
+	if key == "" || resourceID == "" {
+		return fmt.Errorf("%w: idempotency key 또는 resourceID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok || !claim.matches(record) {
+		return fmt.Errorf("%w: %s", ErrIdempotencyClaimLost, key)
+	}
+	record.Status = IdempotencyStatusCompleted
+	record.ResourceID = resourceID
+	record.Response = bytes.Clone(response)
+	record.LeaseExpiresAt = 0
+	s.records[key] = record
+
+	return nil
+}
+
+func (s *MemoryIdempotencyStorage) Release(ctx context.Context, key string, claim IdempotencyClaim) error {
+	if key == "" {
+		return fmt.Errorf("%w: idempotency key가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok && claim.matches(record) {
+		delete(s.records, key)
+	}
+
+	return nil
+}
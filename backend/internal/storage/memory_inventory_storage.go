@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoryInventoryStorage: DynamoDB 없이 실행할 때 사용하는 메모리 저장소
+// InventoryStorage와 같은 조건(재고 부족 시 전체 예약 실패, 예약 상태 조건부 변경)을 따름
+type MemoryInventoryStorage struct {
+	mu           sync.Mutex
+	stocks       map[string]StockRecord
+	reservations map[string]ReservationRecord
+}
+
+func NewMemoryInventoryStorage() *MemoryInventoryStorage {
+	return &MemoryInventoryStorage{
+		stocks:       make(map[string]StockRecord),
+		reservations: make(map[string]ReservationRecord),
+	}
+}
+
+func (s *MemoryInventoryStorage) GetStock(ctx context.Context, productID string) (*StockRecord, error) {
+	if err := validateStockKey(productID); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.stocks[productID]
+	if !ok {
+		return &StockRecord{ProductID: productID}, nil
+	}
+	return &record, nil
+}
+
+func (s *MemoryInventoryStorage) AdjustStock(ctx context.Context, productID string, delta int64) (*StockRecord, error) {
+	if err := validateStockKey(productID); err != nil {
+		return nil, err
+	}
+	if delta == 0 {
+		return nil, fmt.Errorf("%w: delta가 0입니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := s.stocks[productID]
+	if record.Quantity+delta < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInsufficientStock, productID)
+	}
+	record.ProductID = productID
+	record.Quantity += delta
+	record.UpdatedAt = time.Now().UTC()
+	s.stocks[productID] = record
+
+	return &record, nil
+}
+
+func (s *MemoryInventoryStorage) Reserve(ctx context.Context, record *ReservationRecord) error {
+	if err := validateReservation(record); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = now
+	}
+	record.UpdatedAt = now
+	record.Status = ReservationStatusReserved
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.reservations[record.ReservationID]; ok {
+		return fmt.Errorf("%w: %s", ErrReservationAlreadyExists, record.ReservationID)
+	}
+	// 모든 상품의 재고를 먼저 확인한 뒤 차감 (하나라도 부족하면 아무것도 차감하지 않음)
+	for i, line := range record.Lines {
+		if s.stocks[line.ProductID].Quantity < int64(line.Quantity) {
+			return &StockShortageError{LineIndex: i, ProductID: line.ProductID}
+		}
+	}
+	for _, line := range record.Lines {
+		stock := s.stocks[line.ProductID]
+		stock.Quantity -= int64(line.Quantity)
+		stock.Reserved += int64(line.Quantity)
+		stock.UpdatedAt = now
+		s.stocks[line.ProductID] = stock
+	}
+	s.reservations[record.ReservationID] = cloneReservation(*record)
+
+	return nil
+}
+
+func (s *MemoryInventoryStorage) GetReservation(ctx context.Context, reservationID string) (*ReservationRecord, error) {
+	if reservationID == "" {
+		return nil, fmt.Errorf("%w: reservationID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.reservations[reservationID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrReservationNotFound, reservationID)
+	}
+	clone := cloneReservation(record)
+	return &clone, nil
+}
+
+func (s *MemoryInventoryStorage) TransitionReservation(ctx context.Context, current *ReservationRecord, to string) (*ReservationRecord, error) {
+	if current == nil || current.ReservationID == "" {
+		return nil, fmt.Errorf("%w: 예약 정보가 비어 있습니다", ErrInvalidArgument)
+	}
+	restock, unreserve, err := reservationStockChange(current.Status, to)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.reservations[current.ReservationID]
+	if !ok || record.Status != current.Status {
+		return nil, fmt.Errorf("%w: %s", ErrReservationConflict, current.ReservationID)
+	}
+	for _, line := range record.Lines {
+		stock := s.stocks[line.ProductID]
+		if restock {
+			stock.Quantity += int64(line.Quantity)
+		}
+		if unreserve {
+			stock.Reserved -= int64(line.Quantity)
+		}
+		stock.UpdatedAt = now
+		s.stocks[line.ProductID] = stock
+	}
+	record.Status = to
+	record.UpdatedAt = now
+	s.reservations[record.ReservationID] = record
+
+	clone := cloneReservation(record)
+	return &clone, nil
+}
+
+func cloneReservation(record ReservationRecord) ReservationRecord {
+	record.Lines = append([]ReservationLine(nil), record.Lines...)
+	return record
+}
@@ -0,0 +1,196 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MemoryOrderStorage: DynamoDB 없이 실행할 때 사용하는 메모리 저장소
+// OrderStorage와 같은 조건(중복 생성 거부)을 따름
+type MemoryOrderStorage struct {
+	mu     sync.RWMutex
+	orders map[string]OrderRecord
+	outbox *MemoryOutboxStorage
+}
+
+func NewMemoryOrderStorage(outbox *MemoryOutboxStorage) *MemoryOrderStorage {
+	return &MemoryOrderStorage{
+		orders: make(map[string]OrderRecord),
+		outbox: outbox,
+	}
+}
+
+func (s *MemoryOrderStorage) GetOrderByID(ctx context.Context, orderID string) (*OrderRecord, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("%w: orderID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	record, ok := s.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrOrderNotFound, orderID)
+	}
+
+	return cloneOrderRecord(record), nil
+}
+
+// ListOrdersByUser: OrderStorage와 같이 최신순으로 조회하고 DynamoDB 형식의 NextKey를 돌려줌
+func (s *MemoryOrderStorage) ListOrdersByUser(ctx context.Context, q OrderQuery) (*OrderPage, error) {
+	if q.UserID == "" {
+		return nil, fmt.Errorf("%w: userID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if q.Limit <= 0 {
+		return nil, fmt.Errorf("%w: limit은 0보다 커야 합니다", ErrInvalidArgument)
+	}
+
+	var startAt time.Time
+	var startID string
+	if q.StartKey != nil {
+		var err error
+		startAt, startID, err = parseOrderKey(q.StartKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.RLock()
+	matched := make([]OrderRecord, 0)
+	for _, record := range s.orders {
+		if record.UserID != q.UserID {
+			continue
+		}
+		if q.Status != "" && record.Status != q.Status {
+			continue
+		}
+		if !q.CreatedFrom.IsZero() && record.CreatedAt.Before(q.CreatedFrom) {
+			continue
+		}
+		if !q.CreatedTo.IsZero() && record.CreatedAt.After(q.CreatedTo) {
+			continue
+		}
+		matched = append(matched, *cloneOrderRecord(record))
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		return orderBefore(matched[i], matched[j].CreatedAt, matched[j].OrderID)
+	})
+
+	// StartKey 다음 주문부터 조회
+	start := 0
+	if q.StartKey != nil {
+		cursor := OrderRecord{OrderID: startID, CreatedAt: startAt}
+		start = sort.Search(len(matched), func(i int) bool {
+			return orderBefore(cursor, matched[i].CreatedAt, matched[i].OrderID)
+		})
+	}
+
+	page := &OrderPage{}
+	end := start + int(q.Limit)
+	if end < len(matched) {
+		last := matched[end-1]
+		page.NextKey = map[string]types.AttributeValue{
+			"order_id":   &types.AttributeValueMemberS{Value: last.OrderID},
+			"user_id":    &types.AttributeValueMemberS{Value: last.UserID},
+			"created_at": &types.AttributeValueMemberS{Value: orderCreatedAt(last.CreatedAt)},
+		}
+	} else {
+		end = len(matched)
+	}
+	page.Orders = matched[start:end]
+
+	return page, nil
+}
+
+func (s *MemoryOrderStorage) CreateOrder(ctx context.Context, record *OrderRecord, event *OutboxRecord) error {
+	if record == nil {
+		return fmt.Errorf("%w: OrderRecord가 nil입니다", ErrInvalidArgument)
+	}
+	if record.OrderID == "" {
+		return fmt.Errorf("%w: OrderRecord.OrderID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now().UTC()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.orders[record.OrderID]; ok {
+		return fmt.Errorf("%w: %s", ErrOrderAlreadyExists, record.OrderID)
+	}
+	if event != nil {
+		if err := s.outbox.append(event); err != nil {
+			return err
+		}
+	}
+	s.orders[record.OrderID] = *cloneOrderRecord(*record)
+
+	return nil
+}
+
+func (s *MemoryOrderStorage) UpdateOrderStatus(ctx context.Context, orderID, from, to string, events ...*OutboxRecord) (*OrderRecord, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("%w: orderID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if from == "" || to == "" {
+		return nil, fmt.Errorf("%w: 주문 상태가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrOrderNotFound, orderID)
+	}
+	if record.Status != from {
+		return nil, fmt.Errorf("%w: %s", ErrOrderStatusConflict, orderID)
+	}
+	if len(events) > 0 {
+		if err := s.outbox.append(events...); err != nil {
+			return nil, err
+		}
+	}
+	record.Status = to
+	s.orders[orderID] = record
+
+	return cloneOrderRecord(record), nil
+}
+
+// orderBefore: 최신순 정렬에서 record가 (createdAt, orderID)보다 앞에 오는지 여부
+func orderBefore(record OrderRecord, createdAt time.Time, orderID string) bool {
+	if !record.CreatedAt.Equal(createdAt) {
+		return record.CreatedAt.After(createdAt)
+	}
+	return record.OrderID > orderID
+}
+
+func parseOrderKey(key map[string]types.AttributeValue) (time.Time, string, error) {
+	orderID, ok := key["order_id"].(*types.AttributeValueMemberS)
+	if !ok {
+		return time.Time{}, "", fmt.Errorf("%w: StartKey에 order_id가 없습니다", ErrInvalidArgument)
+	}
+	createdAt, ok := key["created_at"].(*types.AttributeValueMemberS)
+	if !ok {
+		return time.Time{}, "", fmt.Errorf("%w: StartKey에 created_at이 없습니다", ErrInvalidArgument)
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt.Value)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%w: StartKey의 created_at이 올바르지 않습니다: %v", ErrInvalidArgument, err)
+	}
+	return t, orderID.Value, nil
+}
+
+// cloneOrderRecord: Items 슬라이스까지 복사해 저장된 값과 공유하지 않도록 함
+func cloneOrderRecord(record OrderRecord) *OrderRecord {
+	record.Items = append([]OrderLine(nil), record.Items...)
+	return &record
+}
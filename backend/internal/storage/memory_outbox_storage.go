@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryOutboxStorage: OutboxStorage와 같은 조건을 따르는 메모리 저장소 (발행된 이벤트는 보관하지 않음)
+// 메모리 사용자/주문 저장소가 엔티티를 저장할 때 append로 함께 기록함
+type MemoryOutboxStorage struct {
+	mu     sync.Mutex
+	events map[string]OutboxRecord
+}
+
+func NewMemoryOutboxStorage() *MemoryOutboxStorage {
+	return &MemoryOutboxStorage{
+		events: make(map[string]OutboxRecord),
+	}
+}
+
+// append: 엔티티 저장과 같은 잠금 구간에서 호출됨
+// 하나라도 기록할 수 없으면 아무것도 기록하지 않음 (nil은 건너뜀)
+func (s *MemoryOutboxStorage) append(events ...*OutboxRecord) error {
+	if s == nil {
+		return fmt.Errorf("%w: outbox 저장소가 없습니다", ErrInvalidArgument)
+	}
+	records := make([]OutboxRecord, 0, len(events))
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+		record, err := pendingOutboxRecord(event)
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, record := range records {
+		if _, ok := s.events[record.EventID]; ok {
+			return fmt.Errorf("%w: 이미 존재하는 이벤트입니다: %s", ErrInvalidArgument, record.EventID)
+		}
+		for _, other := range records[:i] {
+			if other.EventID == record.EventID {
+				return fmt.Errorf("%w: 이미 존재하는 이벤트입니다: %s", ErrInvalidArgument, record.EventID)
+			}
+		}
+	}
+	for _, record := range records {
+		s.events[record.EventID] = record
+	}
+	return nil
+}
+
+func (s *MemoryOutboxStorage) ListPendingEvents(ctx context.Context, source, afterEventID string, limit int32) ([]OutboxRecord, error) {
+	if source == "" {
+		return nil, fmt.Errorf("%w: source가 비어 있습니다", ErrInvalidArgument)
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: limit은 0보다 커야 합니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var records []OutboxRecord
+	for _, record := range s.events {
+		if record.PendingSource == source && record.EventID > afterEventID {
+			records = append(records, cloneOutboxRecord(record))
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].EventID < records[j].EventID
+	})
+	if len(records) > int(limit) {
+		records = records[:limit]
+	}
+
+	return records, nil
+}
+
+func (s *MemoryOutboxStorage) ClaimEvent(ctx context.Context, eventID string, now, until time.Time) error {
+	return s.update(eventID, func(record *OutboxRecord) bool {
+		if record.AvailableAt > now.UnixMilli() {
+			return false
+		}
+		record.AvailableAt = until.UnixMilli()
+		return true
+	})
+}
+
+// MarkEventPublished: TTL이 없으므로 발행된 이벤트는 바로 지움
+func (s *MemoryOutboxStorage) MarkEventPublished(ctx context.Context, eventID string) error {
+	if eventID == "" {
+		return fmt.Errorf("%w: eventID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.events[eventID]; !ok || record.PendingSource == "" {
+		return fmt.Errorf("%w: %s", ErrOutboxEventClaimed, eventID)
+	}
+	delete(s.events, eventID)
+	return nil
+}
+
+func (s *MemoryOutboxStorage) MarkEventFailed(ctx context.Context, eventID string, retryAt time.Time, reason string) error {
+	return s.update(eventID, func(record *OutboxRecord) bool {
+		record.AvailableAt = retryAt.UnixMilli()
+		record.LastError = reason
+		record.Attempts++
+		return true
+	})
+}
+
+// MarkEventDeadLettered: OutboxStorage와 같이 발행 대기 목록에서 빼고 남겨 둠
+func (s *MemoryOutboxStorage) MarkEventDeadLettered(ctx context.Context, eventID, reason string) error {
+	return s.update(eventID, func(record *OutboxRecord) bool {
+		record.PendingSource = ""
+		record.DeadLetteredAt = time.Now().UnixMilli()
+		record.LastError = reason
+		record.Attempts++
+		return true
+	})
+}
+
+// update: 발행 대기 중인 이벤트에만 fn을 적용 (fn이 false를 반환하면 점유 중인 것으로 봄)
+func (s *MemoryOutboxStorage) update(eventID string, fn func(record *OutboxRecord) bool) error {
+	if eventID == "" {
+		return fmt.Errorf("%w: eventID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.events[eventID]
+	if !ok || record.PendingSource == "" || !fn(&record) {
+		return fmt.Errorf("%w: %s", ErrOutboxEventClaimed, eventID)
+	}
+	s.events[eventID] = record
+	return nil
+}
+
+func cloneOutboxRecord(record OutboxRecord) OutboxRecord {
+	record.Payload = append([]byte(nil), record.Payload...)
+	return record
+}
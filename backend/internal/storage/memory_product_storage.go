@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoryProductStorage: DynamoDB 없이 실행할 때 사용하는 메모리 저장소
+// ProductStorage와 같은 조건(중복 생성 거부, 없는 상품 수정 거부)을 따름
+type MemoryProductStorage struct {
+	mu       sync.RWMutex
+	products map[string]ProductRecord
+}
+
+func NewMemoryProductStorage() *MemoryProductStorage {
+	return &MemoryProductStorage{
+		products: make(map[string]ProductRecord),
+	}
+}
+
+func (s *MemoryProductStorage) GetProductByID(ctx context.Context, productID string) (*ProductRecord, error) {
+	if productID == "" {
+		return nil, fmt.Errorf("%w: productID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	record, ok := s.products[productID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, productID)
+	}
+
+	return &record, nil
+}
+
+func (s *MemoryProductStorage) CreateProduct(ctx context.Context, record *ProductRecord) error {
+	if record == nil {
+		return fmt.Errorf("%w: ProductRecord가 nil입니다", ErrInvalidArgument)
+	}
+	if record.ProductID == "" {
+		return fmt.Errorf("%w: ProductRecord.ProductID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now().UTC()
+	}
+	if record.UpdatedAt.IsZero() {
+		record.UpdatedAt = record.CreatedAt
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.products[record.ProductID]; ok {
+		return fmt.Errorf("%w: %s", ErrProductAlreadyExists, record.ProductID)
+	}
+	s.products[record.ProductID] = *record
+
+	return nil
+}
+
+func (s *MemoryProductStorage) UpdateProduct(ctx context.Context, productID string, update ProductUpdate) (*ProductRecord, error) {
+	if productID == "" {
+		return nil, fmt.Errorf("%w: productID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if update.empty() {
+		return nil, fmt.Errorf("%w: 업데이트할 필드가 없습니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.products[productID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, productID)
+	}
+	if update.Name != nil {
+		record.Name = *update.Name
+	}
+	if update.Price != nil {
+		record.Price = *update.Price
+	}
+	if update.Available != nil {
+		record.Available = *update.Available
+	}
+	record.UpdatedAt = time.Now().UTC()
+	s.products[productID] = record
+
+	return &record, nil
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemorySagaStorage: SagaStorage와 같은 조건(중복 생성 거부, version 조건부 저장)을 따르는 메모리 저장소
+// 프로세스가 재시작되면 기록이 사라지므로 재시작 후 재개는 DynamoDB 저장소에서만 동작
+type MemorySagaStorage struct {
+	mu    sync.Mutex
+	sagas map[string]SagaRecord
+}
+
+func NewMemorySagaStorage() *MemorySagaStorage {
+	return &MemorySagaStorage{
+		sagas: make(map[string]SagaRecord),
+	}
+}
+
+func (s *MemorySagaStorage) GetSaga(ctx context.Context, sagaID string) (*SagaRecord, error) {
+	if sagaID == "" {
+		return nil, fmt.Errorf("%w: sagaID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.sagas[sagaID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSagaNotFound, sagaID)
+	}
+	clone := cloneSaga(record)
+	return &clone, nil
+}
+
+func (s *MemorySagaStorage) CreateSaga(ctx context.Context, record *SagaRecord) error {
+	if err := validateSaga(record); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sagas[record.SagaID]; ok {
+		return fmt.Errorf("%w: %s", ErrSagaAlreadyExists, record.SagaID)
+	}
+	next := prepareSaga(*record, 1)
+	s.sagas[record.SagaID] = next
+	*record = cloneSaga(next)
+
+	return nil
+}
+
+func (s *MemorySagaStorage) UpdateSaga(ctx context.Context, record *SagaRecord) error {
+	if err := validateSaga(record); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.sagas[record.SagaID]
+	if !ok || current.Version != record.Version {
+		return fmt.Errorf("%w: %s", ErrSagaConflict, record.SagaID)
+	}
+	next := prepareSaga(*record, record.Version+1)
+	s.sagas[record.SagaID] = next
+	*record = cloneSaga(next)
+
+	return nil
+}
+
+// ListStalledSagas: SagaStorage와 같이 lease가 오래전에 만료된 순으로 조회
+func (s *MemorySagaStorage) ListStalledSagas(ctx context.Context, before time.Time, limit int32) ([]SagaRecord, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: limit은 0보다 커야 합니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var records []SagaRecord
+	for _, record := range s.sagas {
+		if record.InFlight != "" && record.LeaseExpiresAt < before.UnixMilli() {
+			records = append(records, record)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].LeaseExpiresAt < records[j].LeaseExpiresAt
+	})
+	if len(records) > int(limit) {
+		records = records[:limit]
+	}
+	for i := range records {
+		records[i] = cloneSaga(records[i])
+	}
+
+	return records, nil
+}
+
+func cloneSaga(record SagaRecord) SagaRecord {
+	record.Steps = append([]SagaStepRecord(nil), record.Steps...)
+	return record
+}
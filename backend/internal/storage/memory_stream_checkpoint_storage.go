@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoryStreamCheckpointStorage: StreamCheckpointStorage와 같은 lease 조건을 따르는 메모리 저장소
+// 프로세스가 재시작되면 처리 위치가 사라지므로 재시작 후에는 consumer 설정에 따라 처음(또는 최신)부터 다시 읽음
+type MemoryStreamCheckpointStorage struct {
+	mu          sync.Mutex
+	checkpoints map[string]StreamCheckpointRecord
+}
+
+func NewMemoryStreamCheckpointStorage() *MemoryStreamCheckpointStorage {
+	return &MemoryStreamCheckpointStorage{
+		checkpoints: make(map[string]StreamCheckpointRecord),
+	}
+}
+
+func (s *MemoryStreamCheckpointStorage) GetCheckpoint(ctx context.Context, consumer, shardID string) (*StreamCheckpointRecord, error) {
+	if consumer == "" || shardID == "" {
+		return nil, fmt.Errorf("%w: consumer와 shardID는 필수입니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.checkpoints[checkpointID(consumer, shardID)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCheckpointNotFound, checkpointID(consumer, shardID))
+	}
+	return &record, nil
+}
+
+func (s *MemoryStreamCheckpointStorage) AcquireShardLease(ctx context.Context, consumer, streamARN, shardID, owner string, now, until time.Time) (*StreamCheckpointRecord, error) {
+	if err := validateShardLease(consumer, shardID, owner); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := checkpointID(consumer, shardID)
+	record, ok := s.checkpoints[id]
+	if ok && record.LeaseOwner != owner && record.LeaseExpiresAt >= now.UnixMilli() {
+		return nil, fmt.Errorf("%w: %s", ErrShardLeaseHeld, shardID)
+	}
+	record.CheckpointID = id
+	record.Consumer = consumer
+	record.StreamARN = streamARN
+	record.ShardID = shardID
+	record.LeaseOwner = owner
+	record.LeaseExpiresAt = until.UnixMilli()
+	record.UpdatedAt = now.UTC()
+	s.checkpoints[id] = record
+
+	return &record, nil
+}
+
+func (s *MemoryStreamCheckpointStorage) SaveCheckpoint(ctx context.Context, checkpoint StreamCheckpoint) error {
+	if err := validateShardLease(checkpoint.Consumer, checkpoint.ShardID, checkpoint.Owner); err != nil {
+		return err
+	}
+
+	return s.update(checkpoint.Consumer, checkpoint.ShardID, checkpoint.Owner, func(record *StreamCheckpointRecord) {
+		if checkpoint.SequenceNumber != "" {
+			record.SequenceNumber = checkpoint.SequenceNumber
+		}
+		record.Finished = checkpoint.Finished
+		record.LeaseExpiresAt = checkpoint.LeaseUntil.UnixMilli()
+		record.UpdatedAt = time.Now().UTC()
+	})
+}
+
+func (s *MemoryStreamCheckpointStorage) ReleaseShardLease(ctx context.Context, consumer, shardID, owner string) error {
+	if err := validateShardLease(consumer, shardID, owner); err != nil {
+		return err
+	}
+
+	return s.update(consumer, shardID, owner, func(record *StreamCheckpointRecord) {
+		record.LeaseExpiresAt = 0
+	})
+}
+
+// update: owner가 lease를 가진 checkpoint만 변경
+func (s *MemoryStreamCheckpointStorage) update(consumer, shardID, owner string, fn func(record *StreamCheckpointRecord)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := checkpointID(consumer, shardID)
+	record, ok := s.checkpoints[id]
+	if !ok || record.LeaseOwner != owner {
+		return fmt.Errorf("%w: %s", ErrShardLeaseLost, shardID)
+	}
+	fn(&record)
+	s.checkpoints[id] = record
+
+	return nil
+}
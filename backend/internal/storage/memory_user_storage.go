@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MemoryUserStorage: DynamoDB 없이 실행할 때 사용하는 메모리 저장소
+// UserStorage와 같은 조건(중복 생성 거부, 이메일 중복 거부, 없는 사용자 수정/삭제 거부)을 따름
+type MemoryUserStorage struct {
+	mu    sync.RWMutex
+	users map[string]UserItem
+	// 정규화된 이메일 -> userID (DynamoDB의 이메일 가드 아이템 역할)
+	emails map[string]string
+	outbox *MemoryOutboxStorage
+}
+
+func NewMemoryUserStorage(outbox *MemoryOutboxStorage) *MemoryUserStorage {
+	return &MemoryUserStorage{
+		users:  make(map[string]UserItem),
+		emails: make(map[string]string),
+		outbox: outbox,
+	}
+}
+
+func (s *MemoryUserStorage) GetUserByID(ctx context.Context, userID string) (*UserItem, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("%w: userID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, ok := s.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+
+	// 호출자가 내부 상태를 바꾸지 못하도록 복사본을 반환
+	return &user, nil
+}
+
+func (s *MemoryUserStorage) GetUserByEmail(ctx context.Context, email string) (*UserItem, error) {
+	normalized := normalizeEmail(email)
+	if normalized == "" {
+		return nil, fmt.Errorf("%w: email이 비어 있습니다", ErrInvalidArgument)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	userID, ok := s.emails[normalized]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, email)
+	}
+	user := s.users[userID]
+
+	return &user, nil
+}
+
+// ListUsers: user_id 순으로 순회하고 DynamoDB 형식의 NextKey를 돌려줌
+func (s *MemoryUserStorage) ListUsers(ctx context.Context, q UserScan) (*UserPage, error) {
+	if q.Limit <= 0 {
+		return nil, fmt.Errorf("%w: limit은 0보다 커야 합니다", ErrInvalidArgument)
+	}
+
+	var startID string
+	if q.StartKey != nil {
+		key, ok := q.StartKey["user_id"].(*types.AttributeValueMemberS)
+		if !ok {
+			return nil, fmt.Errorf("%w: StartKey에 user_id가 없습니다", ErrInvalidArgument)
+		}
+		startID = key.Value
+	}
+	emailPrefix := normalizeEmail(q.EmailPrefix)
+
+	s.mu.RLock()
+	matched := make([]UserItem, 0)
+	for _, user := range s.users {
+		if q.StartKey != nil && user.UserID <= startID {
+			continue
+		}
+		if !strings.HasPrefix(user.Name, q.NamePrefix) || !strings.HasPrefix(user.EmailNormalized, emailPrefix) {
+			continue
+		}
+		matched = append(matched, user)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].UserID < matched[j].UserID
+	})
+
+	page := &UserPage{Users: matched}
+	if len(matched) > int(q.Limit) {
+		page.Users = matched[:q.Limit]
+		page.NextKey = map[string]types.AttributeValue{
+			"user_id": &types.AttributeValueMemberS{Value: page.Users[q.Limit-1].UserID},
+		}
+	}
+
+	return page, nil
+}
+
+func (s *MemoryUserStorage) CreateUser(ctx context.Context, item *UserItem, event *OutboxRecord) error {
+	if item == nil {
+		return fmt.Errorf("%w: UserItem이 nil입니다", ErrInvalidArgument)
+	}
+	if item.UserID == "" {
+		return fmt.Errorf("%w: UserItem.UserID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = time.Now().UTC()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[item.UserID]; ok {
+		return fmt.Errorf("%w: %s", ErrUserAlreadyExists, item.UserID)
+	}
+	key := normalizeEmail(item.Email)
+	if _, ok := s.emails[key]; ok {
+		return fmt.Errorf("%w: %s", ErrEmailAlreadyExists, item.Email)
+	}
+	if event != nil {
+		if err := s.outbox.append(event); err != nil {
+			return err
+		}
+	}
+	item.EmailNormalized = key
+	s.users[item.UserID] = *item
+	s.emails[key] = item.UserID
+
+	return nil
+}
+
+func (s *MemoryUserStorage) UpdateUser(ctx context.Context, userID string, email, name *string) (*UserItem, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("%w: userID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if email == nil && name == nil {
+		return nil, fmt.Errorf("%w: 업데이트할 필드가 없습니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+	if email != nil {
+		oldKey, newKey := normalizeEmail(user.Email), normalizeEmail(*email)
+		if oldKey != newKey {
+			if _, ok := s.emails[newKey]; ok {
+				return nil, fmt.Errorf("%w: %s", ErrEmailAlreadyExists, *email)
+			}
+			delete(s.emails, oldKey)
+			s.emails[newKey] = userID
+		}
+		user.Email = *email
+		user.EmailNormalized = newKey
+	}
+	if name != nil {
+		user.Name = *name
+	}
+	s.users[userID] = user
+
+	return &user, nil
+}
+
+func (s *MemoryUserStorage) UpdateUserStatus(ctx context.Context, userID, from, to, reason string, event *OutboxRecord) (*UserItem, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("%w: userID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if to == "" {
+		return nil, fmt.Errorf("%w: 계정 상태가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+	if user.Status != from {
+		return nil, fmt.Errorf("%w: %s", ErrUserConflict, userID)
+	}
+	if event != nil {
+		if err := s.outbox.append(event); err != nil {
+			return nil, err
+		}
+	}
+	user.Status = to
+	user.StatusReason = reason
+	s.users[userID] = user
+
+	return &user, nil
+}
+
+func (s *MemoryUserStorage) DeleteUser(ctx context.Context, id string, event *OutboxRecord) error {
+	if id == "" {
+		return fmt.Errorf("%w: id가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUserNotFound, id)
+	}
+	if event != nil {
+		if err := s.outbox.append(event); err != nil {
+			return err
+		}
+	}
+	delete(s.users, id)
+	delete(s.emails, normalizeEmail(user.Email))
+
+	return nil
+}
@@ -8,39 +8,83 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
+// 사용자별 주문 목록 조회에 사용하는 GSI (파티션 키 user_id, 정렬 키 created_at)
+const orderUserIndexName = "user_id-created_at-index"
+
+// GSI 정렬 키(created_at)로 저장하고 조회할 때 쓰는 시각 형식 (UTC, 소수점 아래 9자리 고정)
+// time.Time의 기본 형식(RFC3339Nano)은 끝의 0을 잘라 길이가 달라지므로 문자열 순서가 시간 순서와 어긋남
+// (예: "...05Z"가 "...05.5Z"보다 뒤로 정렬됨)
+const orderCreatedAtLayout = "2006-01-02T15:04:05.000000000Z"
+
+// orderCreatedAt: 정렬 키에 쓰는 created_at 값 (RFC3339Nano로 읽을 수 있어 언마샬은 그대로 사용)
+func orderCreatedAt(t time.Time) string {
+	return t.UTC().Format(orderCreatedAtLayout)
+}
+
 type OrderStorage struct {
 	client    *dynamodb.Client
 	tableName string
+	// 주문 이벤트를 함께 기록할 outbox 테이블
+	outboxTableName string
 }
 
+// OrderRecord: 금액 필드는 주문 생성 시점에 계산해 저장하며 이후 수정하지 않음
+// (상품 가격이 바뀌어도 기존 주문의 금액은 그대로 유지)
 type OrderRecord struct {
 	OrderID   string      `dynamodbav:"order_id"`
 	UserID    string      `dynamodbav:"user_id"`
 	Items     []OrderLine `dynamodbav:"items"`
 	Status    string      `dynamodbav:"status"`
 	CreatedAt time.Time   `dynamodbav:"created_at"`
+	Currency  string      `dynamodbav:"currency"`
+	Subtotal  int64       `dynamodbav:"subtotal"`
+	Tax       int64       `dynamodbav:"tax"`
+	Total     int64       `dynamodbav:"total"`
 }
 
 type OrderLine struct {
 	ProductID string `dynamodbav:"product_id"`
 	Quantity  int32  `dynamodbav:"quantity"`
+	UnitPrice int64  `dynamodbav:"unit_price"`
+	LineTotal int64  `dynamodbav:"line_total"`
+}
+
+// OrderQuery: 사용자별 주문 목록 조회 조건
+type OrderQuery struct {
+	UserID string
+	// 비어 있으면 상태와 관계없이 조회
+	Status string
+	// zero 값이면 해당 방향으로 범위 제한 없음 (양 끝 포함)
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	Limit       int32
+	// 이전 페이지의 NextKey (첫 페이지는 nil)
+	StartKey map[string]types.AttributeValue
 }
 
-func NewOrderStorage(client *dynamodb.Client, tableName string) (*OrderStorage, error) {
+// OrderPage: 주문 목록 한 페이지 (NextKey가 nil이면 마지막 페이지)
+type OrderPage struct {
+	Orders  []OrderRecord
+	NextKey map[string]types.AttributeValue
+}
+
+func NewOrderStorage(client *dynamodb.Client, tableName, outboxTableName string) (*OrderStorage, error) {
 	if client == nil {
 		return nil, errors.New("dynamodb client가 nil입니다")
 	}
-	if tableName == "" {
+	if tableName == "" || outboxTableName == "" {
 		return nil, errors.New("tableName이 비어 있습니다")
 	}
 
 	return &OrderStorage{
-		client:    client,
-		tableName: tableName,
+		client:          client,
+		tableName:       tableName,
+		outboxTableName: outboxTableName,
 	}, nil
 }
 
@@ -49,7 +93,7 @@ func (s *OrderStorage) GetOrderByID(ctx context.Context, orderID string) (*Order
 		return nil, errors.New("OrderStorage가 초기화되지 않았습니다")
 	}
 	if orderID == "" {
-		return nil, errors.New("orderID가 비어 있습니다")
+		return nil, fmt.Errorf("%w: orderID가 비어 있습니다", ErrInvalidArgument)
 	}
 
 	out, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
@@ -60,10 +104,10 @@ func (s *OrderStorage) GetOrderByID(ctx context.Context, orderID string) (*Order
 		ConsistentRead: aws.Bool(true),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("GetItem 실패: %w", err)
+		return nil, dynamoError("GetItem", err)
 	}
 	if out.Item == nil {
-		return nil, fmt.Errorf("주문 %s를 찾을 수 없습니다", orderID)
+		return nil, fmt.Errorf("%w: %s", ErrOrderNotFound, orderID)
 	}
 
 	var record OrderRecord
@@ -74,15 +118,75 @@ func (s *OrderStorage) GetOrderByID(ctx context.Context, orderID string) (*Order
 	return &record, nil
 }
 
-func (s *OrderStorage) CreateOrder(ctx context.Context, record *OrderRecord) error {
+// ListOrdersByUser: user_id/created_at GSI를 최신순으로 조회
+// status 조건은 FilterExpression이므로 Limit만큼 읽은 뒤 걸러져 한 페이지가 Limit보다 적을 수 있음
+func (s *OrderStorage) ListOrdersByUser(ctx context.Context, q OrderQuery) (*OrderPage, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("OrderStorage가 초기화되지 않았습니다")
+	}
+	if q.UserID == "" {
+		return nil, fmt.Errorf("%w: userID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if q.Limit <= 0 {
+		return nil, fmt.Errorf("%w: limit은 0보다 커야 합니다", ErrInvalidArgument)
+	}
+
+	keyCond := expression.Key("user_id").Equal(expression.Value(q.UserID))
+	createdAt := expression.Key("created_at")
+	switch {
+	case !q.CreatedFrom.IsZero() && !q.CreatedTo.IsZero():
+		keyCond = keyCond.And(createdAt.Between(expression.Value(orderCreatedAt(q.CreatedFrom)), expression.Value(orderCreatedAt(q.CreatedTo))))
+	case !q.CreatedFrom.IsZero():
+		keyCond = keyCond.And(createdAt.GreaterThanEqual(expression.Value(orderCreatedAt(q.CreatedFrom))))
+	case !q.CreatedTo.IsZero():
+		keyCond = keyCond.And(createdAt.LessThanEqual(expression.Value(orderCreatedAt(q.CreatedTo))))
+	}
+
+	builder := expression.NewBuilder().WithKeyCondition(keyCond)
+	if q.Status != "" {
+		builder = builder.WithFilter(expression.Name("status").Equal(expression.Value(q.Status)))
+	}
+	expr, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	out, err := s.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		IndexName:                 aws.String(orderUserIndexName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ExclusiveStartKey:         q.StartKey,
+		Limit:                     aws.Int32(q.Limit),
+		ScanIndexForward:          aws.Bool(false),
+	})
+	if err != nil {
+		return nil, dynamoError("Query", err)
+	}
+
+	var records []OrderRecord
+	if err := attributevalue.UnmarshalListOfMaps(out.Items, &records); err != nil {
+		return nil, fmt.Errorf("주문 목록 언마샬 실패: %w", err)
+	}
+
+	return &OrderPage{
+		Orders:  records,
+		NextKey: out.LastEvaluatedKey,
+	}, nil
+}
+
+// CreateOrder: event가 있으면 주문과 같은 트랜잭션으로 outbox에 기록
+func (s *OrderStorage) CreateOrder(ctx context.Context, record *OrderRecord, event *OutboxRecord) error {
 	if s == nil || s.client == nil {
 		return errors.New("OrderStorage가 초기화되지 않았습니다")
 	}
 	if record == nil {
-		return errors.New("OrderRecord가 nil입니다")
+		return fmt.Errorf("%w: OrderRecord가 nil입니다", ErrInvalidArgument)
 	}
 	if record.OrderID == "" {
-		return errors.New("OrderRecord.OrderID가 비어 있습니다")
+		return fmt.Errorf("%w: OrderRecord.OrderID가 비어 있습니다", ErrInvalidArgument)
 	}
 	if record.CreatedAt.IsZero() {
 		record.CreatedAt = time.Now().UTC()
@@ -92,19 +196,200 @@ func (s *OrderStorage) CreateOrder(ctx context.Context, record *OrderRecord) err
 	if err != nil {
 		return fmt.Errorf("주문 marshal 실패: %w", err)
 	}
+	av["created_at"] = &types.AttributeValueMemberS{Value: orderCreatedAt(record.CreatedAt)}
+
+	transactItems := []types.TransactWriteItem{
+		{
+			Put: &types.Put{
+				TableName:           aws.String(s.tableName),
+				Item:                av,
+				ConditionExpression: aws.String("attribute_not_exists(order_id)"),
+			},
+		},
+	}
+	if event != nil {
+		put, err := outboxPut(s.outboxTableName, event)
+		if err != nil {
+			return err
+		}
+		transactItems = append(transactItems, put)
+	}
 
-	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName:           aws.String(s.tableName),
-		Item:                av,
-		ConditionExpression: aws.String("attribute_not_exists(order_id)"),
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
 	})
 	if err != nil {
-		var ccfe *types.ConditionalCheckFailedException
-		if errors.As(err, &ccfe) {
-			return fmt.Errorf("이미 존재하는 주문: %s", record.OrderID)
+		if failedConditionIndex(err) == 0 {
+			return fmt.Errorf("%w: %s", ErrOrderAlreadyExists, record.OrderID)
 		}
-		return fmt.Errorf("PutItem 실패: %w", err)
+		return transactError(ctx, err)
 	}
 
 	return nil
 }
+
+// UpdateOrderStatus: 현재 상태가 from일 때만 to로 변경 (동시에 들어온 상태 변경이 서로 덮어쓰지 않도록 함)
+// events는 상태 변경과 같은 트랜잭션으로 outbox에 기록 (nil은 건너뜀)
+func (s *OrderStorage) UpdateOrderStatus(ctx context.Context, orderID, from, to string, events ...*OutboxRecord) (*OrderRecord, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("OrderStorage가 초기화되지 않았습니다")
+	}
+	if orderID == "" {
+		return nil, fmt.Errorf("%w: orderID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if from == "" || to == "" {
+		return nil, fmt.Errorf("%w: 주문 상태가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.Set(expression.Name("status"), expression.Value(to))).
+		WithCondition(expression.Name("status").Equal(expression.Value(from))).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	transactItems := []types.TransactWriteItem{
+		{
+			Update: &types.Update{
+				TableName: aws.String(s.tableName),
+				Key: map[string]types.AttributeValue{
+					"order_id": &types.AttributeValueMemberS{Value: orderID},
+				},
+				UpdateExpression:                    expr.Update(),
+				ConditionExpression:                 expr.Condition(),
+				ExpressionAttributeNames:            expr.Names(),
+				ExpressionAttributeValues:           expr.Values(),
+				ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+			},
+		},
+	}
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+		put, err := outboxPut(s.outboxTableName, event)
+		if err != nil {
+			return nil, err
+		}
+		transactItems = append(transactItems, put)
+	}
+
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if err != nil {
+		var canceled *types.TransactionCanceledException
+		if failedConditionIndex(err) == 0 && errors.As(err, &canceled) {
+			// 조건 실패 시 기존 아이템이 없으면 주문 자체가 없는 경우
+			if canceled.CancellationReasons[0].Item == nil {
+				return nil, fmt.Errorf("%w: %s", ErrOrderNotFound, orderID)
+			}
+			return nil, fmt.Errorf("%w: %s", ErrOrderStatusConflict, orderID)
+		}
+		return nil, transactError(ctx, err)
+	}
+
+	// TransactWriteItems는 변경된 아이템을 돌려주지 않으므로 다시 읽음
+	return s.GetOrderByID(ctx, orderID)
+}
+
+// OrderBackfillPage: BackfillOrderCreatedAt 한 번의 결과 (NextKey가 nil이면 마지막 페이지)
+type OrderBackfillPage struct {
+	// created_at을 고정 길이 형식으로 다시 기록한 주문 수
+	Updated int
+	// 이미 고정 길이 형식이거나 Scan 이후 삭제되어 건너뛴 주문 수
+	Skipped int
+	NextKey map[string]types.AttributeValue
+}
+
+// BackfillOrderCreatedAt: 고정 길이 형식(orderCreatedAtLayout) 전에 저장한 주문의 created_at을 같은 시각의 고정 길이 값으로 다시 기록
+// 이전 값은 끝의 0이 잘려 길이가 달라 GSI에서 새 주문과 시간 순서대로 정렬되지 않음
+// startKey부터 limit개를 Scan하며, 이미 고정 길이인 주문은 건너뛰므로 여러 번 실행해도 됨
+func (s *OrderStorage) BackfillOrderCreatedAt(ctx context.Context, startKey map[string]types.AttributeValue, limit int32) (*OrderBackfillPage, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("OrderStorage가 초기화되지 않았습니다")
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: limit은 0보다 커야 합니다", ErrInvalidArgument)
+	}
+
+	expr, err := expression.NewBuilder().
+		WithProjection(expression.NamesList(expression.Name("order_id"), expression.Name("created_at"))).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+	out, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+		TableName:                aws.String(s.tableName),
+		ProjectionExpression:     expr.Projection(),
+		ExpressionAttributeNames: expr.Names(),
+		ExclusiveStartKey:        startKey,
+		Limit:                    aws.Int32(limit),
+		ConsistentRead:           aws.Bool(true),
+	})
+	if err != nil {
+		return nil, dynamoError("Scan", err)
+	}
+
+	// 저장된 문자열을 그대로 비교해야 하므로 time.Time이 아닌 문자열로 읽음
+	var orders []struct {
+		OrderID   string `dynamodbav:"order_id"`
+		CreatedAt string `dynamodbav:"created_at"`
+	}
+	if err := attributevalue.UnmarshalListOfMaps(out.Items, &orders); err != nil {
+		return nil, fmt.Errorf("주문 목록 언마샬 실패: %w", err)
+	}
+
+	page := &OrderBackfillPage{NextKey: out.LastEvaluatedKey}
+	for _, order := range orders {
+		written, err := s.backfillOrderCreatedAt(ctx, order.OrderID, order.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("주문 %s created_at 기록 실패: %w", order.OrderID, err)
+		}
+		if written {
+			page.Updated++
+		} else {
+			page.Skipped++
+		}
+	}
+
+	return page, nil
+}
+
+// backfillOrderCreatedAt: created_at이 Scan한 값 그대로일 때만 고정 길이 값으로 바꿈
+func (s *OrderStorage) backfillOrderCreatedAt(ctx context.Context, orderID, createdAt string) (bool, error) {
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return false, fmt.Errorf("created_at %q 파싱 실패: %w", createdAt, err)
+	}
+	fixed := orderCreatedAt(t)
+	if fixed == createdAt {
+		return false, nil
+	}
+
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.Set(expression.Name("created_at"), expression.Value(fixed))).
+		WithCondition(expression.Name("created_at").Equal(expression.Value(createdAt))).
+		Build()
+	if err != nil {
+		return false, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(s.tableName),
+		Key:                       map[string]types.AttributeValue{"order_id": &types.AttributeValueMemberS{Value: orderID}},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			// Scan 이후 삭제되었거나 다른 실행이 이미 바꾼 경우
+			return false, nil
+		}
+		return false, dynamoError("UpdateItem", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,270 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// 발행 대기 이벤트 조회에 사용하는 sparse GSI (파티션 키 pending_source, 정렬 키 event_id)
+// 발행이 끝난 이벤트는 pending_source 속성이 없어 인덱스에서 빠짐
+const outboxPendingIndexName = "pending_source-event_id-index"
+
+// 발행된 이벤트 보관 기간 (expires_at TTL)
+const outboxRetention = 7 * 24 * time.Hour
+
+// OutboxRecord: 엔티티와 같은 트랜잭션으로 기록되는 발행 대기 이벤트
+type OutboxRecord struct {
+	EventID   string `dynamodbav:"event_id"`
+	EventType string `dynamodbav:"event_type"`
+	// 이벤트를 기록한 서비스 (user, order). 서비스마다 자신의 이벤트만 발행함
+	Source      string `dynamodbav:"source"`
+	AggregateID string `dynamodbav:"aggregate_id"`
+	// 직렬화된 events.Event (protobuf)
+	Payload   []byte    `dynamodbav:"payload"`
+	CreatedAt time.Time `dynamodbav:"created_at"`
+	// 발행 전에만 source 값을 가짐 (저장소가 채움)
+	PendingSource string `dynamodbav:"pending_source,omitempty"`
+	// 이 시각(epoch 밀리초) 전에는 relay가 가져가지 않음 (발행 중 점유 또는 실패 후 재시도 대기)
+	AvailableAt int64  `dynamodbav:"available_at"`
+	Attempts    int    `dynamodbav:"attempts"`
+	LastError   string `dynamodbav:"last_error,omitempty"`
+	// 발행 후 설정되는 TTL 속성 (epoch 초)
+	ExpiresAt int64 `dynamodbav:"expires_at,omitempty"`
+	// 재시도 횟수를 다 써서 발행을 포기한 시각 (epoch 밀리초, 발행 대기 목록에서 빠지고 TTL 없이 남음)
+	DeadLetteredAt int64 `dynamodbav:"dead_lettered_at,omitempty"`
+}
+
+type OutboxStorage struct {
+	client    *dynamodb.Client
+	tableName string
+}
+
+func NewOutboxStorage(client *dynamodb.Client, tableName string) (*OutboxStorage, error) {
+	if client == nil {
+		return nil, errors.New("dynamodb client가 nil입니다")
+	}
+	if tableName == "" {
+		return nil, errors.New("tableName이 비어 있습니다")
+	}
+
+	return &OutboxStorage{
+		client:    client,
+		tableName: tableName,
+	}, nil
+}
+
+// ListPendingEvents: source의 발행 대기 이벤트를 기록된 순서(event_id)대로 afterEventID 다음부터 조회 (처음부터면 빈 값)
+// 재시도 대기 중인 이벤트도 포함하므로 (같은 리소스의 이벤트 순서를 지키기 위해) available_at은 호출 측이 확인
+func (s *OutboxStorage) ListPendingEvents(ctx context.Context, source, afterEventID string, limit int32) ([]OutboxRecord, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("OutboxStorage가 초기화되지 않았습니다")
+	}
+	if source == "" {
+		return nil, fmt.Errorf("%w: source가 비어 있습니다", ErrInvalidArgument)
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: limit은 0보다 커야 합니다", ErrInvalidArgument)
+	}
+
+	expr, err := expression.NewBuilder().
+		WithKeyCondition(expression.Key("pending_source").Equal(expression.Value(source))).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	// GSI의 시작 키는 인덱스 키(pending_source, event_id)와 테이블 키(event_id)로 이루어짐
+	var startKey map[string]types.AttributeValue
+	if afterEventID != "" {
+		startKey = map[string]types.AttributeValue{
+			"pending_source": &types.AttributeValueMemberS{Value: source},
+			"event_id":       &types.AttributeValueMemberS{Value: afterEventID},
+		}
+	}
+
+	out, err := s.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		IndexName:                 aws.String(outboxPendingIndexName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ExclusiveStartKey:         startKey,
+		Limit:                     aws.Int32(limit),
+	})
+	if err != nil {
+		return nil, dynamoError("Query", err)
+	}
+
+	var records []OutboxRecord
+	if err := attributevalue.UnmarshalListOfMaps(out.Items, &records); err != nil {
+		return nil, fmt.Errorf("outbox 목록 언마샬 실패: %w", err)
+	}
+
+	return records, nil
+}
+
+// ClaimEvent: 발행 대기 중이고 다른 relay가 점유하지 않은 이벤트를 until까지 점유
+// 이미 발행되었거나 점유 중이면 ErrOutboxEventClaimed
+func (s *OutboxStorage) ClaimEvent(ctx context.Context, eventID string, now, until time.Time) error {
+	if s == nil || s.client == nil {
+		return errors.New("OutboxStorage가 초기화되지 않았습니다")
+	}
+	if eventID == "" {
+		return fmt.Errorf("%w: eventID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.Set(expression.Name("available_at"), expression.Value(until.UnixMilli()))).
+		WithCondition(expression.And(
+			expression.AttributeExists(expression.Name("pending_source")),
+			expression.Name("available_at").LessThanEqual(expression.Value(now.UnixMilli())),
+		)).
+		Build()
+	if err != nil {
+		return fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	return s.updateEvent(ctx, eventID, expr)
+}
+
+// MarkEventPublished: 발행 대기 목록에서 빼고 보관 기간 후 TTL로 삭제되도록 함
+func (s *OutboxStorage) MarkEventPublished(ctx context.Context, eventID string) error {
+	if s == nil || s.client == nil {
+		return errors.New("OutboxStorage가 초기화되지 않았습니다")
+	}
+	if eventID == "" {
+		return fmt.Errorf("%w: eventID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.
+			Remove(expression.Name("pending_source")).
+			Remove(expression.Name("last_error")).
+			Set(expression.Name("expires_at"), expression.Value(time.Now().Add(outboxRetention).Unix()))).
+		WithCondition(expression.AttributeExists(expression.Name("pending_source"))).
+		Build()
+	if err != nil {
+		return fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	return s.updateEvent(ctx, eventID, expr)
+}
+
+// MarkEventFailed: 발행 실패를 기록하고 retryAt 이후에 다시 가져갈 수 있게 함
+func (s *OutboxStorage) MarkEventFailed(ctx context.Context, eventID string, retryAt time.Time, reason string) error {
+	if s == nil || s.client == nil {
+		return errors.New("OutboxStorage가 초기화되지 않았습니다")
+	}
+	if eventID == "" {
+		return fmt.Errorf("%w: eventID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.
+			Set(expression.Name("available_at"), expression.Value(retryAt.UnixMilli())).
+			Set(expression.Name("last_error"), expression.Value(reason)).
+			Add(expression.Name("attempts"), expression.Value(1))).
+		WithCondition(expression.AttributeExists(expression.Name("pending_source"))).
+		Build()
+	if err != nil {
+		return fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	return s.updateEvent(ctx, eventID, expr)
+}
+
+// MarkEventDeadLettered: 발행을 포기한 이벤트를 발행 대기 목록에서 빼고 조사할 수 있도록 TTL 없이 남김
+// (pending_source를 source 값으로 다시 설정하고 attempts를 0으로 돌리면 relay가 다시 발행함)
+func (s *OutboxStorage) MarkEventDeadLettered(ctx context.Context, eventID, reason string) error {
+	if s == nil || s.client == nil {
+		return errors.New("OutboxStorage가 초기화되지 않았습니다")
+	}
+	if eventID == "" {
+		return fmt.Errorf("%w: eventID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.
+			Remove(expression.Name("pending_source")).
+			Set(expression.Name("dead_lettered_at"), expression.Value(time.Now().UnixMilli())).
+			Set(expression.Name("last_error"), expression.Value(reason)).
+			Add(expression.Name("attempts"), expression.Value(1))).
+		WithCondition(expression.AttributeExists(expression.Name("pending_source"))).
+		Build()
+	if err != nil {
+		return fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	return s.updateEvent(ctx, eventID, expr)
+}
+
+func (s *OutboxStorage) updateEvent(ctx context.Context, eventID string, expr expression.Expression) error {
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"event_id": &types.AttributeValueMemberS{Value: eventID},
+		},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return fmt.Errorf("%w: %s", ErrOutboxEventClaimed, eventID)
+		}
+		return dynamoError("UpdateItem", err)
+	}
+	return nil
+}
+
+// outboxPut: 엔티티 저장 트랜잭션에 함께 넣을 outbox 기록
+func outboxPut(tableName string, event *OutboxRecord) (types.TransactWriteItem, error) {
+	if tableName == "" {
+		return types.TransactWriteItem{}, errors.New("outbox 테이블 이름이 비어 있습니다")
+	}
+	record, err := pendingOutboxRecord(event)
+	if err != nil {
+		return types.TransactWriteItem{}, err
+	}
+
+	av, err := attributevalue.MarshalMap(record)
+	if err != nil {
+		return types.TransactWriteItem{}, fmt.Errorf("outbox 기록 marshal 실패: %w", err)
+	}
+
+	return types.TransactWriteItem{
+		Put: &types.Put{
+			TableName:           aws.String(tableName),
+			Item:                av,
+			ConditionExpression: aws.String("attribute_not_exists(event_id)"),
+		},
+	}, nil
+}
+
+// pendingOutboxRecord: 발행 대기 상태로 저장할 복사본
+func pendingOutboxRecord(event *OutboxRecord) (OutboxRecord, error) {
+	if event == nil || event.EventID == "" {
+		return OutboxRecord{}, fmt.Errorf("%w: eventID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if event.Source == "" || event.EventType == "" {
+		return OutboxRecord{}, fmt.Errorf("%w: 이벤트 source와 종류는 필수입니다", ErrInvalidArgument)
+	}
+
+	record := *event
+	record.Payload = append([]byte(nil), event.Payload...)
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now().UTC()
+	}
+	record.PendingSource = record.Source
+	return record, nil
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type ProductStorage struct {
+	client    *dynamodb.Client
+	tableName string
+}
+
+type ProductRecord struct {
+	ProductID string `dynamodbav:"product_id"`
+	Name      string `dynamodbav:"name"`
+	// 최소 화폐 단위 정수
+	Price     int64     `dynamodbav:"price"`
+	Currency  string    `dynamodbav:"currency"`
+	Available bool      `dynamodbav:"available"`
+	CreatedAt time.Time `dynamodbav:"created_at"`
+	UpdatedAt time.Time `dynamodbav:"updated_at"`
+}
+
+// ProductUpdate: nil이 아닌 필드만 수정
+type ProductUpdate struct {
+	Name      *string
+	Price     *int64
+	Available *bool
+}
+
+func (u ProductUpdate) empty() bool {
+	return u.Name == nil && u.Price == nil && u.Available == nil
+}
+
+func NewProductStorage(client *dynamodb.Client, tableName string) (*ProductStorage, error) {
+	if client == nil {
+		return nil, errors.New("dynamodb client가 nil입니다")
+	}
+	if tableName == "" {
+		return nil, errors.New("tableName이 비어 있습니다")
+	}
+
+	return &ProductStorage{
+		client:    client,
+		tableName: tableName,
+	}, nil
+}
+
+func (s *ProductStorage) GetProductByID(ctx context.Context, productID string) (*ProductRecord, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("ProductStorage가 초기화되지 않았습니다")
+	}
+	if productID == "" {
+		return nil, fmt.Errorf("%w: productID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	// 주문 가격을 정할 때 읽으므로 방금 바뀐 가격/판매 여부도 반영되도록 강한 일관성으로 읽음
+	out, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"product_id": &types.AttributeValueMemberS{Value: productID},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, dynamoError("GetItem", err)
+	}
+	if out.Item == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, productID)
+	}
+
+	var record ProductRecord
+	if err := attributevalue.UnmarshalMap(out.Item, &record); err != nil {
+		return nil, fmt.Errorf("상품 언마샬 실패: %w", err)
+	}
+
+	return &record, nil
+}
+
+func (s *ProductStorage) CreateProduct(ctx context.Context, record *ProductRecord) error {
+	if s == nil || s.client == nil {
+		return errors.New("ProductStorage가 초기화되지 않았습니다")
+	}
+	if record == nil {
+		return fmt.Errorf("%w: ProductRecord가 nil입니다", ErrInvalidArgument)
+	}
+	if record.ProductID == "" {
+		return fmt.Errorf("%w: ProductRecord.ProductID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now().UTC()
+	}
+	if record.UpdatedAt.IsZero() {
+		record.UpdatedAt = record.CreatedAt
+	}
+
+	av, err := attributevalue.MarshalMap(record)
+	if err != nil {
+		return fmt.Errorf("상품 marshal 실패: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.tableName),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(product_id)"),
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return fmt.Errorf("%w: %s", ErrProductAlreadyExists, record.ProductID)
+		}
+		return dynamoError("PutItem", err)
+	}
+
+	return nil
+}
+
+func (s *ProductStorage) UpdateProduct(ctx context.Context, productID string, update ProductUpdate) (*ProductRecord, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("ProductStorage가 초기화되지 않았습니다")
+	}
+	if productID == "" {
+		return nil, fmt.Errorf("%w: productID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if update.empty() {
+		return nil, fmt.Errorf("%w: 업데이트할 필드가 없습니다", ErrInvalidArgument)
+	}
+
+	updateBuilder := expression.Set(expression.Name("updated_at"), expression.Value(time.Now().UTC()))
+	if update.Name != nil {
+		updateBuilder = updateBuilder.Set(expression.Name("name"), expression.Value(*update.Name))
+	}
+	if update.Price != nil {
+		updateBuilder = updateBuilder.Set(expression.Name("price"), expression.Value(*update.Price))
+	}
+	if update.Available != nil {
+		updateBuilder = updateBuilder.Set(expression.Name("available"), expression.Value(*update.Available))
+	}
+
+	expr, err := expression.NewBuilder().
+		WithUpdate(updateBuilder).
+		WithCondition(expression.AttributeExists(expression.Name("product_id"))).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	out, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(s.tableName),
+		Key:                       map[string]types.AttributeValue{"product_id": &types.AttributeValueMemberS{Value: productID}},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ReturnValues:              types.ReturnValueAllNew,
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return nil, fmt.Errorf("%w: %s", ErrProductNotFound, productID)
+		}
+		return nil, dynamoError("UpdateItem", err)
+	}
+
+	var updated ProductRecord
+	if err := attributevalue.UnmarshalMap(out.Attributes, &updated); err != nil {
+		return nil, fmt.Errorf("업데이트 결과 언마샬 실패: %w", err)
+	}
+
+	return &updated, nil
+}
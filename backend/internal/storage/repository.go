@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// UserRepository: 사용자 저장소가 구현해야 하는 동작
+// DynamoDB(UserStorage)와 메모리(MemoryUserStorage) 구현이 있음
+type UserRepository interface {
+	GetUserByID(ctx context.Context, userID string) (*UserItem, error)
+	GetUserByEmail(ctx context.Context, email string) (*UserItem, error)
+	ListUsers(ctx context.Context, q UserScan) (*UserPage, error)
+	// event가 nil이 아니면 사용자와 같은 트랜잭션으로 outbox에 기록
+	CreateUser(ctx context.Context, item *UserItem, event *OutboxRecord) error
+	UpdateUser(ctx context.Context, userID string, email, name *string) (*UserItem, error)
+	// 현재 상태가 from일 때만 변경. event가 nil이 아니면 같은 트랜잭션으로 outbox에 기록
+	UpdateUserStatus(ctx context.Context, userID, from, to, reason string, event *OutboxRecord) (*UserItem, error)
+	// event가 nil이 아니면 삭제와 같은 트랜잭션으로 outbox에 기록
+	DeleteUser(ctx context.Context, id string, event *OutboxRecord) error
+}
+
+// OrderRepository: 주문 저장소가 구현해야 하는 동작
+// DynamoDB(OrderStorage)와 메모리(MemoryOrderStorage) 구현이 있음
+type OrderRepository interface {
+	GetOrderByID(ctx context.Context, orderID string) (*OrderRecord, error)
+	ListOrdersByUser(ctx context.Context, q OrderQuery) (*OrderPage, error)
+	// event가 nil이 아니면 주문과 같은 트랜잭션으로 outbox에 기록
+	CreateOrder(ctx context.Context, record *OrderRecord, event *OutboxRecord) error
+	// events는 상태 변경과 같은 트랜잭션으로 outbox에 기록 (nil은 건너뜀)
+	UpdateOrderStatus(ctx context.Context, orderID, from, to string, events ...*OutboxRecord) (*OrderRecord, error)
+}
+
+// ProductRepository: 상품 저장소가 구현해야 하는 동작
+// DynamoDB(ProductStorage)와 메모리(MemoryProductStorage) 구현이 있음
+type ProductRepository interface {
+	GetProductByID(ctx context.Context, productID string) (*ProductRecord, error)
+	CreateProduct(ctx context.Context, record *ProductRecord) error
+	UpdateProduct(ctx context.Context, productID string, update ProductUpdate) (*ProductRecord, error)
+}
+
+// InventoryRepository: 재고/재고 예약 저장소
+// DynamoDB(InventoryStorage)와 메모리(MemoryInventoryStorage) 구현이 있음
+type InventoryRepository interface {
+	GetStock(ctx context.Context, productID string) (*StockRecord, error)
+	AdjustStock(ctx context.Context, productID string, delta int64) (*StockRecord, error)
+	Reserve(ctx context.Context, record *ReservationRecord) error
+	GetReservation(ctx context.Context, reservationID string) (*ReservationRecord, error)
+	TransitionReservation(ctx context.Context, current *ReservationRecord, to string) (*ReservationRecord, error)
+}
+
+// IdempotencyRepository: idempotency key 기록 저장소
+// DynamoDB(IdempotencyStorage)와 메모리(MemoryIdempotencyStorage) 구현이 있음
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, record *IdempotencyRecord) (*IdempotencyRecord, error)
+	// pending 기록에 리소스 ID를 묶고 lease를 없앰 (이후에는 Complete/Release로만 정리됨)
+	Bind(ctx context.Context, key string, claim IdempotencyClaim, resourceID string) error
+	// response는 재시도에 돌려줄 첫 응답 (비어 있으면 기록하지 않음)
+	Complete(ctx context.Context, key string, claim IdempotencyClaim, resourceID string, response []byte) error
+	Release(ctx context.Context, key string, claim IdempotencyClaim) error
+}
+
+// SagaRepository: saga 진행 상태 저장소
+// DynamoDB(SagaStorage)와 메모리(MemorySagaStorage) 구현이 있음
+type SagaRepository interface {
+	GetSaga(ctx context.Context, sagaID string) (*SagaRecord, error)
+	CreateSaga(ctx context.Context, record *SagaRecord) error
+	UpdateSaga(ctx context.Context, record *SagaRecord) error
+	ListStalledSagas(ctx context.Context, before time.Time, limit int32) ([]SagaRecord, error)
+}
+
+// OutboxRepository: 발행 대기 이벤트 저장소 (이벤트 기록은 사용자/주문 저장소가 함께 수행)
+// DynamoDB(OutboxStorage)와 메모리(MemoryOutboxStorage) 구현이 있음
+type OutboxRepository interface {
+	ListPendingEvents(ctx context.Context, source, afterEventID string, limit int32) ([]OutboxRecord, error)
+	ClaimEvent(ctx context.Context, eventID string, now, until time.Time) error
+	MarkEventPublished(ctx context.Context, eventID string) error
+	MarkEventFailed(ctx context.Context, eventID string, retryAt time.Time, reason string) error
+	MarkEventDeadLettered(ctx context.Context, eventID, reason string) error
+}
+
+// StreamCheckpointRepository: 스트림 consumer의 shard별 처리 위치와 lease 저장소
+// DynamoDB(StreamCheckpointStorage)와 메모리(MemoryStreamCheckpointStorage) 구현이 있음
+type StreamCheckpointRepository interface {
+	GetCheckpoint(ctx context.Context, consumer, shardID string) (*StreamCheckpointRecord, error)
+	AcquireShardLease(ctx context.Context, consumer, streamARN, shardID, owner string, now, until time.Time) (*StreamCheckpointRecord, error)
+	SaveCheckpoint(ctx context.Context, checkpoint StreamCheckpoint) error
+	ReleaseShardLease(ctx context.Context, consumer, shardID, owner string) error
+}
+
+var (
+	_ UserRepository  = (*UserStorage)(nil)
+	_ UserRepository  = (*MemoryUserStorage)(nil)
+	_ OrderRepository = (*OrderStorage)(nil)
+	_ OrderRepository = (*MemoryOrderStorage)(nil)
+
+	_ ProductRepository = (*ProductStorage)(nil)
+	_ ProductRepository = (*MemoryProductStorage)(nil)
+
+	_ InventoryRepository = (*InventoryStorage)(nil)
+	_ InventoryRepository = (*MemoryInventoryStorage)(nil)
+
+	_ IdempotencyRepository = (*IdempotencyStorage)(nil)
+	_ IdempotencyRepository = (*MemoryIdempotencyStorage)(nil)
+
+	_ SagaRepository = (*SagaStorage)(nil)
+	_ SagaRepository = (*MemorySagaStorage)(nil)
+
+	_ OutboxRepository = (*OutboxStorage)(nil)
+	_ OutboxRepository = (*MemoryOutboxStorage)(nil)
+
+	_ StreamCheckpointRepository = (*StreamCheckpointStorage)(nil)
+	_ StreamCheckpointRepository = (*MemoryStreamCheckpointStorage)(nil)
+)
@@ -0,0 +1,259 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// saga 상태
+// running -> completed
+// running -> compensating -> failed
+const (
+	SagaStatusRunning      = "running"
+	SagaStatusCompensating = "compensating"
+	SagaStatusCompleted    = "completed"
+	// 실패한 뒤 보상까지 끝난 상태
+	SagaStatusFailed = "failed"
+)
+
+// saga 단계 상태
+const (
+	SagaStepPending     = "pending"
+	SagaStepDone        = "done"
+	SagaStepFailed      = "failed"
+	SagaStepCompensated = "compensated"
+)
+
+// 진행 중인 saga 조회에 사용하는 sparse GSI (파티션 키 in_flight, 정렬 키 lease_expires_at)
+// 끝난 saga는 in_flight 속성이 없어 인덱스에서 빠짐
+const (
+	sagaInFlightIndexName = "in_flight-lease_expires_at-index"
+	sagaInFlightValue     = "Y"
+)
+
+// SagaRecord: saga 진행 상태
+// 실행 중인 인스턴스는 단계마다 상태를 저장하며 lease를 연장하고,
+// lease가 만료된 진행 중 saga는 다른 인스턴스(또는 재시작한 파드)가 이어서 실행함
+type SagaRecord struct {
+	SagaID string           `dynamodbav:"saga_id"`
+	Type   string           `dynamodbav:"saga_type"`
+	Status string           `dynamodbav:"status"`
+	Steps  []SagaStepRecord `dynamodbav:"steps"`
+	// 각 단계가 사용하는 입력 (JSON)
+	Payload string `dynamodbav:"payload"`
+	// 보상을 시작하게 만든 단계의 에러
+	Error string `dynamodbav:"error,omitempty"`
+	// 진행 중인 saga에만 저장소가 채움 (sparse GSI 파티션 키)
+	InFlight       string `dynamodbav:"in_flight,omitempty"`
+	LeaseOwner     string `dynamodbav:"lease_owner"`
+	LeaseExpiresAt int64  `dynamodbav:"lease_expires_at"` // epoch 밀리초
+	// 저장할 때마다 1씩 증가 (조건부 저장으로 동시 실행을 막음)
+	Version   int64     `dynamodbav:"version"`
+	CreatedAt time.Time `dynamodbav:"created_at"`
+	UpdatedAt time.Time `dynamodbav:"updated_at"`
+}
+
+type SagaStepRecord struct {
+	Name   string `dynamodbav:"name"`
+	Status string `dynamodbav:"status"`
+	Error  string `dynamodbav:"error,omitempty"`
+}
+
+// IsInFlight: 아직 끝나지 않은(이어서 실행해야 하는) 상태인지
+func (r *SagaRecord) IsInFlight() bool {
+	return r.Status == SagaStatusRunning || r.Status == SagaStatusCompensating
+}
+
+type SagaStorage struct {
+	client    *dynamodb.Client
+	tableName string
+}
+
+func NewSagaStorage(client *dynamodb.Client, tableName string) (*SagaStorage, error) {
+	if client == nil {
+		return nil, errors.New("dynamodb client가 nil입니다")
+	}
+	if tableName == "" {
+		return nil, errors.New("tableName이 비어 있습니다")
+	}
+
+	return &SagaStorage{
+		client:    client,
+		tableName: tableName,
+	}, nil
+}
+
+func (s *SagaStorage) GetSaga(ctx context.Context, sagaID string) (*SagaRecord, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("SagaStorage가 초기화되지 않았습니다")
+	}
+	if sagaID == "" {
+		return nil, fmt.Errorf("%w: sagaID가 비어 있습니다", ErrInvalidArgument)
+	}
+
+	out, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"saga_id": &types.AttributeValueMemberS{Value: sagaID},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, dynamoError("GetItem", err)
+	}
+	if out.Item == nil {
+		return nil, fmt.Errorf("%w: %s", ErrSagaNotFound, sagaID)
+	}
+
+	var record SagaRecord
+	if err := attributevalue.UnmarshalMap(out.Item, &record); err != nil {
+		return nil, fmt.Errorf("saga 언마샬 실패: %w", err)
+	}
+
+	return &record, nil
+}
+
+// CreateSaga: 같은 ID의 saga가 없을 때만 생성하고 record.Version을 1로 설정
+func (s *SagaStorage) CreateSaga(ctx context.Context, record *SagaRecord) error {
+	if s == nil || s.client == nil {
+		return errors.New("SagaStorage가 초기화되지 않았습니다")
+	}
+	if err := validateSaga(record); err != nil {
+		return err
+	}
+
+	next := prepareSaga(*record, 1)
+	av, err := attributevalue.MarshalMap(next)
+	if err != nil {
+		return fmt.Errorf("saga marshal 실패: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.tableName),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(saga_id)"),
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return fmt.Errorf("%w: %s", ErrSagaAlreadyExists, record.SagaID)
+		}
+		return dynamoError("PutItem", err)
+	}
+
+	*record = next
+	return nil
+}
+
+// UpdateSaga: 저장된 version이 record.Version과 같을 때만 덮어쓰고 version을 1 올림
+// 그 사이 다른 인스턴스가 저장했다면 ErrSagaConflict
+func (s *SagaStorage) UpdateSaga(ctx context.Context, record *SagaRecord) error {
+	if s == nil || s.client == nil {
+		return errors.New("SagaStorage가 초기화되지 않았습니다")
+	}
+	if err := validateSaga(record); err != nil {
+		return err
+	}
+
+	next := prepareSaga(*record, record.Version+1)
+	av, err := attributevalue.MarshalMap(next)
+	if err != nil {
+		return fmt.Errorf("saga marshal 실패: %w", err)
+	}
+
+	expr, err := expression.NewBuilder().
+		WithCondition(expression.Name("version").Equal(expression.Value(record.Version))).
+		Build()
+	if err != nil {
+		return fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(s.tableName),
+		Item:                      av,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return fmt.Errorf("%w: %s", ErrSagaConflict, record.SagaID)
+		}
+		return dynamoError("PutItem", err)
+	}
+
+	*record = next
+	return nil
+}
+
+// ListStalledSagas: lease가 before 이전에 만료된 진행 중 saga를 오래된 순으로 조회
+// GSI 조회는 최종적 일관성이므로 이어서 실행하기 전에 GetSaga로 다시 확인해야 함
+func (s *SagaStorage) ListStalledSagas(ctx context.Context, before time.Time, limit int32) ([]SagaRecord, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("SagaStorage가 초기화되지 않았습니다")
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: limit은 0보다 커야 합니다", ErrInvalidArgument)
+	}
+
+	keyCond := expression.Key("in_flight").Equal(expression.Value(sagaInFlightValue)).
+		And(expression.Key("lease_expires_at").LessThan(expression.Value(before.UnixMilli())))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	out, err := s.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		IndexName:                 aws.String(sagaInFlightIndexName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Limit:                     aws.Int32(limit),
+	})
+	if err != nil {
+		return nil, dynamoError("Query", err)
+	}
+
+	var records []SagaRecord
+	if err := attributevalue.UnmarshalListOfMaps(out.Items, &records); err != nil {
+		return nil, fmt.Errorf("saga 목록 언마샬 실패: %w", err)
+	}
+
+	return records, nil
+}
+
+func validateSaga(record *SagaRecord) error {
+	if record == nil || record.SagaID == "" {
+		return fmt.Errorf("%w: sagaID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if record.Type == "" || record.Status == "" {
+		return fmt.Errorf("%w: saga 종류와 상태는 필수입니다", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// prepareSaga: 저장할 version과 시각, 상태에 따른 in_flight 값을 채운 복사본
+func prepareSaga(record SagaRecord, version int64) SagaRecord {
+	now := time.Now().UTC()
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = now
+	}
+	record.UpdatedAt = now
+	record.Version = version
+	record.InFlight = ""
+	if record.IsInFlight() {
+		record.InFlight = sagaInFlightValue
+	}
+	record.Steps = append([]SagaStepRecord(nil), record.Steps...)
+	return record
+}
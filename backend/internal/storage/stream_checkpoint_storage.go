@@ -0,0 +1,240 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// 끝까지 처리한 shard의 checkpoint 보관 기간 (expires_at TTL)
+// 스트림 레코드는 24시간 보관되므로 그 이후에는 자식 shard가 부모 checkpoint를 확인할 일이 없음
+const streamCheckpointRetention = 48 * time.Hour
+
+// StreamCheckpointRecord: 스트림 consumer가 shard별로 마지막으로 처리한 위치와 shard lease
+// 한 shard는 lease를 가진 인스턴스 하나만 처리하며, 처리 위치를 저장할 때마다 lease를 연장함
+type StreamCheckpointRecord struct {
+	// "<consumer>#<shard ID>"
+	CheckpointID string `dynamodbav:"checkpoint_id"`
+	Consumer     string `dynamodbav:"consumer"`
+	StreamARN    string `dynamodbav:"stream_arn"`
+	ShardID      string `dynamodbav:"shard_id"`
+	// 마지막으로 처리한 레코드의 sequence number (아직 처리한 레코드가 없으면 빈 값)
+	SequenceNumber string `dynamodbav:"sequence_number,omitempty"`
+	// shard를 끝까지 처리함 (자식 shard를 시작할 수 있음)
+	Finished       bool      `dynamodbav:"finished"`
+	LeaseOwner     string    `dynamodbav:"lease_owner"`
+	LeaseExpiresAt int64     `dynamodbav:"lease_expires_at"` // epoch 밀리초
+	UpdatedAt      time.Time `dynamodbav:"updated_at"`
+	// shard를 끝까지 처리한 뒤 설정되는 TTL 속성 (epoch 초)
+	ExpiresAt int64 `dynamodbav:"expires_at,omitempty"`
+}
+
+// StreamCheckpoint: 처리 위치 저장 요청
+type StreamCheckpoint struct {
+	Consumer       string
+	ShardID        string
+	Owner          string
+	SequenceNumber string
+	Finished       bool
+	// 연장할 lease 만료 시각
+	LeaseUntil time.Time
+}
+
+type StreamCheckpointStorage struct {
+	client    *dynamodb.Client
+	tableName string
+}
+
+func NewStreamCheckpointStorage(client *dynamodb.Client, tableName string) (*StreamCheckpointStorage, error) {
+	if client == nil {
+		return nil, errors.New("dynamodb client가 nil입니다")
+	}
+	if tableName == "" {
+		return nil, errors.New("tableName이 비어 있습니다")
+	}
+
+	return &StreamCheckpointStorage{
+		client:    client,
+		tableName: tableName,
+	}, nil
+}
+
+func (s *StreamCheckpointStorage) GetCheckpoint(ctx context.Context, consumer, shardID string) (*StreamCheckpointRecord, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("StreamCheckpointStorage가 초기화되지 않았습니다")
+	}
+	if consumer == "" || shardID == "" {
+		return nil, fmt.Errorf("%w: consumer와 shardID는 필수입니다", ErrInvalidArgument)
+	}
+
+	out, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"checkpoint_id": &types.AttributeValueMemberS{Value: checkpointID(consumer, shardID)},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, dynamoError("GetItem", err)
+	}
+	if out.Item == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCheckpointNotFound, checkpointID(consumer, shardID))
+	}
+
+	var record StreamCheckpointRecord
+	if err := attributevalue.UnmarshalMap(out.Item, &record); err != nil {
+		return nil, fmt.Errorf("checkpoint 언마샬 실패: %w", err)
+	}
+
+	return &record, nil
+}
+
+// AcquireShardLease: 비어 있거나 만료되었거나 이미 owner가 가진 shard lease를 until까지 가져오고 checkpoint를 반환
+// 처음 가져오는 shard면 처리 위치가 빈 checkpoint를 만들며, 다른 인스턴스가 lease를 가지고 있으면 ErrShardLeaseHeld
+func (s *StreamCheckpointStorage) AcquireShardLease(ctx context.Context, consumer, streamARN, shardID, owner string, now, until time.Time) (*StreamCheckpointRecord, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("StreamCheckpointStorage가 초기화되지 않았습니다")
+	}
+	if err := validateShardLease(consumer, shardID, owner); err != nil {
+		return nil, err
+	}
+
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.
+			Set(expression.Name("consumer"), expression.Value(consumer)).
+			Set(expression.Name("stream_arn"), expression.Value(streamARN)).
+			Set(expression.Name("shard_id"), expression.Value(shardID)).
+			Set(expression.Name("finished"), expression.Name("finished").IfNotExists(expression.Value(false))).
+			Set(expression.Name("lease_owner"), expression.Value(owner)).
+			Set(expression.Name("lease_expires_at"), expression.Value(until.UnixMilli())).
+			Set(expression.Name("updated_at"), expression.Value(now.UTC()))).
+		WithCondition(expression.Or(
+			expression.AttributeNotExists(expression.Name("checkpoint_id")),
+			expression.Name("lease_owner").Equal(expression.Value(owner)),
+			expression.Name("lease_expires_at").LessThan(expression.Value(now.UnixMilli())),
+		)).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	out, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"checkpoint_id": &types.AttributeValueMemberS{Value: checkpointID(consumer, shardID)},
+		},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ReturnValues:              types.ReturnValueAllNew,
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return nil, fmt.Errorf("%w: %s", ErrShardLeaseHeld, shardID)
+		}
+		return nil, dynamoError("UpdateItem", err)
+	}
+
+	var record StreamCheckpointRecord
+	if err := attributevalue.UnmarshalMap(out.Attributes, &record); err != nil {
+		return nil, fmt.Errorf("checkpoint 언마샬 실패: %w", err)
+	}
+
+	return &record, nil
+}
+
+// SaveCheckpoint: 처리 위치를 저장하고 lease를 연장
+// 그 사이 다른 인스턴스가 lease를 가져갔으면 ErrShardLeaseLost
+func (s *StreamCheckpointStorage) SaveCheckpoint(ctx context.Context, checkpoint StreamCheckpoint) error {
+	if s == nil || s.client == nil {
+		return errors.New("StreamCheckpointStorage가 초기화되지 않았습니다")
+	}
+	if err := validateShardLease(checkpoint.Consumer, checkpoint.ShardID, checkpoint.Owner); err != nil {
+		return err
+	}
+
+	update := expression.
+		Set(expression.Name("finished"), expression.Value(checkpoint.Finished)).
+		Set(expression.Name("lease_expires_at"), expression.Value(checkpoint.LeaseUntil.UnixMilli())).
+		Set(expression.Name("updated_at"), expression.Value(time.Now().UTC()))
+	if checkpoint.SequenceNumber != "" {
+		update = update.Set(expression.Name("sequence_number"), expression.Value(checkpoint.SequenceNumber))
+	}
+	if checkpoint.Finished {
+		update = update.Set(expression.Name("expires_at"), expression.Value(time.Now().Add(streamCheckpointRetention).Unix()))
+	}
+
+	expr, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(expression.Name("lease_owner").Equal(expression.Value(checkpoint.Owner))).
+		Build()
+	if err != nil {
+		return fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	return s.updateLease(ctx, checkpoint.Consumer, checkpoint.ShardID, expr)
+}
+
+// ReleaseShardLease: owner가 가진 lease를 바로 만료시켜 다른 인스턴스가 이어서 처리할 수 있게 함
+func (s *StreamCheckpointStorage) ReleaseShardLease(ctx context.Context, consumer, shardID, owner string) error {
+	if s == nil || s.client == nil {
+		return errors.New("StreamCheckpointStorage가 초기화되지 않았습니다")
+	}
+	if err := validateShardLease(consumer, shardID, owner); err != nil {
+		return err
+	}
+
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.Set(expression.Name("lease_expires_at"), expression.Value(0))).
+		WithCondition(expression.Name("lease_owner").Equal(expression.Value(owner))).
+		Build()
+	if err != nil {
+		return fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	return s.updateLease(ctx, consumer, shardID, expr)
+}
+
+func (s *StreamCheckpointStorage) updateLease(ctx context.Context, consumer, shardID string, expr expression.Expression) error {
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"checkpoint_id": &types.AttributeValueMemberS{Value: checkpointID(consumer, shardID)},
+		},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return fmt.Errorf("%w: %s", ErrShardLeaseLost, shardID)
+		}
+		return dynamoError("UpdateItem", err)
+	}
+	return nil
+}
+
+func validateShardLease(consumer, shardID, owner string) error {
+	if consumer == "" || shardID == "" {
+		return fmt.Errorf("%w: consumer와 shardID는 필수입니다", ErrInvalidArgument)
+	}
+	if owner == "" {
+		return fmt.Errorf("%w: lease owner가 비어 있습니다", ErrInvalidArgument)
+	}
+	return nil
+}
+
+func checkpointID(consumer, shardID string) string {
+	return consumer + "#" + shardID
+}
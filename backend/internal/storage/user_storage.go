@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -13,13 +14,27 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
-var ErrUserNotFound = errors.New("사용자를 찾을 수 없습니다")
+// 이메일 중복을 막기 위해 사용자 테이블에 함께 저장하는 가드 아이템의 키 접두사
+// 가드 아이템의 user_id는 "EMAIL#<정규화된 이메일>"이고 owner_user_id에 소유자를 기록함
+const emailGuardPrefix = "EMAIL#"
+
+// 정규화된 이메일(email_normalized)을 파티션 키로 사용하는 GSI 이름
+// 가드 아이템에는 email_normalized가 없으므로 인덱스에 포함되지 않음
+const userEmailIndexName = "email_normalized-index"
+
+// ListUsers 한 번에 실행하는 Scan 호출 수 상한
+// 필터 때문에 페이지가 덜 찼더라도 이 횟수를 넘기면 NextKey와 함께 반환하여 테이블 전체를 한 번에 읽지 않도록 함
+const maxScanRounds = 5
 
 type UserStorage struct {
 	// client 객체가 있어야 DB쿼리를 AWS에 보낼 수 있음
 	client *dynamodb.Client
 	// 어떤 테이블에서 작업을 할지 명시함
 	tableName string
+	// 사용자 이벤트를 함께 기록할 outbox 테이블
+	outboxTableName string
+	// 가드 아이템이 없는 사용자(가드 도입 이전에 생성)가 남아 있을 수 있어 이메일 GSI도 함께 확인하는지
+	legacyEmailCheck bool
 }
 
 // 실제 테이블 구조와 1:1 대응
@@ -28,30 +43,80 @@ type UserItem struct {
 	Email     string    `dynamodbav:"email"`
 	Name      string    `dynamodbav:"name"`
 	CreatedAt time.Time `dynamodbav:"created_at"`
+	// 이메일 조회용 GSI 키 (소문자, 앞뒤 공백 제거)
+	EmailNormalized string `dynamodbav:"email_normalized"`
+	// 계정 상태 (active, suspended, closed). 상태가 추가되기 전에 만든 사용자는 비어 있음 (active로 취급)
+	Status string `dynamodbav:"status,omitempty"`
+	// 정지/해지 사유 (active로 돌아오면 지워짐)
+	StatusReason string `dynamodbav:"status_reason,omitempty"`
+}
+
+// 이메일 가드 아이템 구조
+type emailGuardItem struct {
+	Key         string `dynamodbav:"user_id"`
+	OwnerUserID string `dynamodbav:"owner_user_id"`
+}
+
+// UserScan: 사용자 목록 조회 조건
+type UserScan struct {
+	// 비어 있으면 필터 없음 (EmailPrefix는 대소문자 구분 없음)
+	NamePrefix  string
+	EmailPrefix string
+	Limit       int32
+	// 이전 페이지의 NextKey (첫 페이지는 nil)
+	StartKey map[string]types.AttributeValue
+}
+
+// EmailBackfillPage: BackfillEmailGuards 한 번의 결과 (NextKey가 nil이면 마지막 페이지)
+type EmailBackfillPage struct {
+	// 가드 아이템과 email_normalized를 기록한 사용자 수
+	Updated int
+	// Scan 이후 이메일이 바뀌었거나 삭제되어 건너뛴 사용자 수 (변경한 요청이 가드를 정리함)
+	Skipped int
+	// 다른 사용자가 같은 이메일의 가드를 가지고 있어 채우지 못한 사용자 ID (운영자가 직접 정리해야 함)
+	Duplicates []string
+	NextKey    map[string]types.AttributeValue
+}
+
+// UserPage: 사용자 목록 한 페이지 (NextKey가 nil이면 마지막 페이지)
+type UserPage struct {
+	Users   []UserItem
+	NextKey map[string]types.AttributeValue
 }
 
 // UserStorage 객체를 생성하고 초기화
-func NewUserStorage(client *dynamodb.Client, tableName string) (*UserStorage, error) {
+func NewUserStorage(client *dynamodb.Client, tableName, outboxTableName string) (*UserStorage, error) {
 	if client == nil {
 		return nil, errors.New("dynamodb client가 nil입니다")
 	}
-	if tableName == "" {
+	if tableName == "" || outboxTableName == "" {
 		return nil, errors.New("tableName이 비어 있습니다")
 	}
 
 	return &UserStorage{
-		client:    client,
-		tableName: tableName,
+		client:          client,
+		tableName:       tableName,
+		outboxTableName: outboxTableName,
 	}, nil
 }
 
+// SetLegacyEmailCheck: 사용자를 만들거나 이메일을 바꿀 때 가드 아이템과 함께 이메일 GSI도 확인할지 설정
+// 가드 아이템이 없는 사용자가 남아 있는 동안(BackfillEmailGuards를 끝까지 실행하기 전) 켜 둠
+// GSI는 결과적 일관성이라 동시에 만든 같은 이메일까지 막지는 못하며, 그런 사용자는 backfill이 Duplicates로 보고함
+func (s *UserStorage) SetLegacyEmailCheck(enabled bool) {
+	s.legacyEmailCheck = enabled
+}
+
 // 실제 테이블에 CRUD 로직을 수행
 func (s *UserStorage) GetUserByID(ctx context.Context, userID string) (*UserItem, error) {
 	if s == nil || s.client == nil {
 		return nil, errors.New("UserStorage가 초기화되지 않았습니다")
 	}
 	if userID == "" {
-		return nil, errors.New("userID가 비어 있습니다")
+		return nil, fmt.Errorf("%w: userID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if IsEmailGuardKey(userID) {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
 	}
 
 	out, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
@@ -62,7 +127,7 @@ func (s *UserStorage) GetUserByID(ctx context.Context, userID string) (*UserItem
 		ConsistentRead: aws.Bool(true),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("GetItem 실패: %w", err)
+		return nil, dynamoError("GetItem", err)
 	}
 	if out.Item == nil {
 		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
@@ -76,36 +141,172 @@ func (s *UserStorage) GetUserByID(ctx context.Context, userID string) (*UserItem
 	return &user, nil
 }
 
-func (s *UserStorage) CreateUser(ctx context.Context, item *UserItem) error {
+// GetUserByEmail: 이메일 GSI를 조회해 사용자를 찾음 (대소문자 구분 없음)
+// GSI는 강한 일관성 읽기를 지원하지 않으므로 방금 변경된 이메일은 잠시 조회되지 않을 수 있음
+func (s *UserStorage) GetUserByEmail(ctx context.Context, email string) (*UserItem, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("UserStorage가 초기화되지 않았습니다")
+	}
+	normalized := normalizeEmail(email)
+	if normalized == "" {
+		return nil, fmt.Errorf("%w: email이 비어 있습니다", ErrInvalidArgument)
+	}
+
+	expr, err := expression.NewBuilder().
+		WithKeyCondition(expression.Key("email_normalized").Equal(expression.Value(normalized))).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	out, err := s.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		IndexName:                 aws.String(userEmailIndexName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Limit:                     aws.Int32(1),
+	})
+	if err != nil {
+		return nil, dynamoError("Query", err)
+	}
+	if len(out.Items) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, email)
+	}
+
+	var user UserItem
+	if err := attributevalue.UnmarshalMap(out.Items[0], &user); err != nil {
+		return nil, fmt.Errorf("사용자 언마샬 실패: %w", err)
+	}
+
+	return &user, nil
+}
+
+// ListUsers: Scan으로 사용자를 순회
+// Scan의 Limit은 필터 적용 전 읽는 아이템 수이므로 최대 maxScanRounds번까지만 이어서 읽음
+func (s *UserStorage) ListUsers(ctx context.Context, q UserScan) (*UserPage, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("UserStorage가 초기화되지 않았습니다")
+	}
+	if q.Limit <= 0 {
+		return nil, fmt.Errorf("%w: limit은 0보다 커야 합니다", ErrInvalidArgument)
+	}
+
+	// 이메일 가드 아이템은 사용자가 아니므로 제외
+	filter := expression.Not(expression.Name("user_id").BeginsWith(emailGuardPrefix))
+	if q.NamePrefix != "" {
+		filter = filter.And(expression.Name("name").BeginsWith(q.NamePrefix))
+	}
+	if q.EmailPrefix != "" {
+		filter = filter.And(expression.Name("email_normalized").BeginsWith(normalizeEmail(q.EmailPrefix)))
+	}
+	expr, err := expression.NewBuilder().WithFilter(filter).Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	page := &UserPage{}
+	startKey := q.StartKey
+	for round := 0; round < maxScanRounds; round++ {
+		out, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:                 aws.String(s.tableName),
+			FilterExpression:          expr.Filter(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			ExclusiveStartKey:         startKey,
+			Limit:                     aws.Int32(q.Limit - int32(len(page.Users))),
+		})
+		if err != nil {
+			return nil, dynamoError("Scan", err)
+		}
+
+		var users []UserItem
+		if err := attributevalue.UnmarshalListOfMaps(out.Items, &users); err != nil {
+			return nil, fmt.Errorf("사용자 목록 언마샬 실패: %w", err)
+		}
+		page.Users = append(page.Users, users...)
+		page.NextKey = out.LastEvaluatedKey
+		startKey = out.LastEvaluatedKey
+
+		if startKey == nil || int32(len(page.Users)) >= q.Limit {
+			break
+		}
+	}
+
+	return page, nil
+}
+
+// CreateUser: event가 있으면 사용자, 이메일 가드와 같은 트랜잭션으로 outbox에 기록
+func (s *UserStorage) CreateUser(ctx context.Context, item *UserItem, event *OutboxRecord) error {
 	if s == nil || s.client == nil {
 		return errors.New("UserStorage가 초기화되지 않았습니다")
 	}
 	if item == nil {
-		return errors.New("UserItem이 nil입니다")
+		return fmt.Errorf("%w: UserItem이 nil입니다", ErrInvalidArgument)
 	}
 	if item.UserID == "" {
-		return errors.New("UserItem.UserID가 비어 있습니다")
+		return fmt.Errorf("%w: UserItem.UserID가 비어 있습니다", ErrInvalidArgument)
 	}
 	if item.CreatedAt.IsZero() {
 		item.CreatedAt = time.Now().UTC()
 	}
 
+	if IsEmailGuardKey(item.UserID) {
+		return fmt.Errorf("%w: 사용할 수 없는 userID입니다: %s", ErrInvalidArgument, item.UserID)
+	}
+	item.EmailNormalized = normalizeEmail(item.Email)
+	if err := s.checkLegacyEmail(ctx, item.Email, item.UserID); err != nil {
+		return err
+	}
+
 	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
 		return fmt.Errorf("사용자 marshal 실패: %w", err)
 	}
+	guard, err := attributevalue.MarshalMap(emailGuardItem{
+		Key:         emailGuardKey(item.Email),
+		OwnerUserID: item.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("이메일 가드 marshal 실패: %w", err)
+	}
 
-	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName:           aws.String(s.tableName),
-		Item:                av,
-		ConditionExpression: aws.String("attribute_not_exists(user_id)"),
+	// 사용자 아이템과 이메일 가드 아이템을 하나의 트랜잭션으로 기록
+	transactItems := []types.TransactWriteItem{
+		{
+			Put: &types.Put{
+				TableName:           aws.String(s.tableName),
+				Item:                av,
+				ConditionExpression: aws.String("attribute_not_exists(user_id)"),
+			},
+		},
+		{
+			Put: &types.Put{
+				TableName:           aws.String(s.tableName),
+				Item:                guard,
+				ConditionExpression: aws.String("attribute_not_exists(user_id)"),
+			},
+		},
+	}
+	if event != nil {
+		put, err := outboxPut(s.outboxTableName, event)
+		if err != nil {
+			return err
+		}
+		transactItems = append(transactItems, put)
+	}
+
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
 	})
 	if err != nil {
-		var ccfe *types.ConditionalCheckFailedException
-		if errors.As(err, &ccfe) {
-			return fmt.Errorf("이미 존재하는 사용자: %s", item.UserID)
+		switch failedConditionIndex(err) {
+		case 0:
+			return fmt.Errorf("%w: %s", ErrUserAlreadyExists, item.UserID)
+		case 1:
+			return fmt.Errorf("%w: %s", ErrEmailAlreadyExists, item.Email)
 		}
-		return fmt.Errorf("PutItem 실패: %w", err)
+		return transactError(ctx, err)
 	}
 
 	return nil
@@ -116,15 +317,34 @@ func (s *UserStorage) UpdateUser(ctx context.Context, userID string, email, name
 		return nil, errors.New("UserStorage가 초기화되지 않았습니다")
 	}
 	if userID == "" {
-		return nil, errors.New("userID가 비어 있습니다")
+		return nil, fmt.Errorf("%w: userID가 비어 있습니다", ErrInvalidArgument)
 	}
 	if email == nil && name == nil {
-		return nil, errors.New("업데이트할 필드가 없습니다")
+		return nil, fmt.Errorf("%w: 업데이트할 필드가 없습니다", ErrInvalidArgument)
+	}
+	if IsEmailGuardKey(userID) {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+
+	condition := expression.AttributeExists(expression.Name("user_id"))
+	if email != nil {
+		current, err := s.GetUserByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		// 정규화한 이메일이 바뀌는 경우에만 가드 아이템을 교체
+		if emailGuardKey(current.Email) != emailGuardKey(*email) {
+			return s.updateUserEmail(ctx, current, *email, name)
+		}
+		// 대소문자만 바뀌는 경우에도 조회 이후 다른 요청이 이메일을 바꿨다면 가드와 어긋나지 않도록 실패시킴
+		condition = condition.And(expression.Name("email").Equal(expression.Value(current.Email)))
 	}
 
 	updateBuilder := expression.UpdateBuilder{}
 	if email != nil {
-		updateBuilder = updateBuilder.Set(expression.Name("email"), expression.Value(*email))
+		updateBuilder = updateBuilder.
+			Set(expression.Name("email"), expression.Value(*email)).
+			Set(expression.Name("email_normalized"), expression.Value(normalizeEmail(*email)))
 	}
 	if name != nil {
 		updateBuilder = updateBuilder.Set(expression.Name("name"), expression.Value(*name))
@@ -132,27 +352,32 @@ func (s *UserStorage) UpdateUser(ctx context.Context, userID string, email, name
 
 	expr, err := expression.NewBuilder().
 		WithUpdate(updateBuilder).
-		WithCondition(expression.AttributeExists(expression.Name("user_id"))).
+		WithCondition(condition).
 		Build()
 	if err != nil {
 		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
 	}
 
 	out, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
-		TableName:                 aws.String(s.tableName),
-		Key:                       map[string]types.AttributeValue{"user_id": &types.AttributeValueMemberS{Value: userID}},
-		UpdateExpression:          expr.Update(),
-		ConditionExpression:       expr.Condition(),
-		ExpressionAttributeNames:  expr.Names(),
-		ExpressionAttributeValues: expr.Values(),
-		ReturnValues:              types.ReturnValueAllNew,
+		TableName:                           aws.String(s.tableName),
+		Key:                                 map[string]types.AttributeValue{"user_id": &types.AttributeValueMemberS{Value: userID}},
+		UpdateExpression:                    expr.Update(),
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValues:                        types.ReturnValueAllNew,
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
 	})
 	if err != nil {
 		var ccfe *types.ConditionalCheckFailedException
 		if errors.As(err, &ccfe) {
-			return nil, fmt.Errorf("사용자 %s가 존재하지 않습니다", userID)
+			// 조건 실패 시 기존 아이템이 없으면 사용자 자체가 없는 경우
+			if ccfe.Item == nil {
+				return nil, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+			}
+			return nil, fmt.Errorf("%w: %s", ErrUserConflict, userID)
 		}
-		return nil, fmt.Errorf("UpdateItem 실패: %w", err)
+		return nil, dynamoError("UpdateItem", err)
 	}
 
 	var updated UserItem
@@ -163,28 +388,431 @@ func (s *UserStorage) UpdateUser(ctx context.Context, userID string, email, name
 	return &updated, nil
 }
 
-func (s *UserStorage) DeleteUser(ctx context.Context, id string) error {
+// updateUserEmail: 사용자 수정, 기존 이메일 가드 삭제, 새 이메일 가드 생성을 하나의 트랜잭션으로 처리
+// 조회 이후 다른 요청이 이메일을 바꿨다면 email 조건이 실패하여 트랜잭션 전체가 취소됨
+func (s *UserStorage) updateUserEmail(ctx context.Context, current *UserItem, email string, name *string) (*UserItem, error) {
+	if err := s.checkLegacyEmail(ctx, email, current.UserID); err != nil {
+		return nil, err
+	}
+
+	updateBuilder := expression.
+		Set(expression.Name("email"), expression.Value(email)).
+		Set(expression.Name("email_normalized"), expression.Value(normalizeEmail(email)))
+	if name != nil {
+		updateBuilder = updateBuilder.Set(expression.Name("name"), expression.Value(*name))
+	}
+
+	updateExpr, err := expression.NewBuilder().
+		WithUpdate(updateBuilder).
+		WithCondition(expression.And(
+			expression.AttributeExists(expression.Name("user_id")),
+			expression.Name("email").Equal(expression.Value(current.Email)),
+		)).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	oldGuard, err := s.releaseEmailGuard(current)
+	if err != nil {
+		return nil, err
+	}
+
+	guard, err := attributevalue.MarshalMap(emailGuardItem{
+		Key:         emailGuardKey(email),
+		OwnerUserID: current.UserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("이메일 가드 marshal 실패: %w", err)
+	}
+
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Update: &types.Update{
+					TableName:                           aws.String(s.tableName),
+					Key:                                 map[string]types.AttributeValue{"user_id": &types.AttributeValueMemberS{Value: current.UserID}},
+					UpdateExpression:                    updateExpr.Update(),
+					ConditionExpression:                 updateExpr.Condition(),
+					ExpressionAttributeNames:            updateExpr.Names(),
+					ExpressionAttributeValues:           updateExpr.Values(),
+					ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+				},
+			},
+			{Delete: oldGuard},
+			{
+				Put: &types.Put{
+					TableName:           aws.String(s.tableName),
+					Item:                guard,
+					ConditionExpression: aws.String("attribute_not_exists(user_id)"),
+				},
+			},
+		},
+	})
+	if err != nil {
+		var canceled *types.TransactionCanceledException
+		switch failedConditionIndex(err) {
+		case 0:
+			// 조건 실패 시 기존 아이템이 없으면 조회 이후 삭제된 경우
+			if errors.As(err, &canceled) && canceled.CancellationReasons[0].Item == nil {
+				return nil, fmt.Errorf("%w: %s", ErrUserNotFound, current.UserID)
+			}
+			return nil, fmt.Errorf("%w: %s", ErrUserConflict, current.UserID)
+		case 1:
+			return nil, fmt.Errorf("%w: %s", ErrUserConflict, current.UserID)
+		case 2:
+			return nil, fmt.Errorf("%w: %s", ErrEmailAlreadyExists, email)
+		}
+		return nil, transactError(ctx, err)
+	}
+
+	updated := *current
+	updated.Email = email
+	updated.EmailNormalized = normalizeEmail(email)
+	if name != nil {
+		updated.Name = *name
+	}
+
+	return &updated, nil
+}
+
+// UpdateUserStatus: 현재 상태가 from일 때만 계정 상태를 to로 변경 (from이 비어 있으면 상태가 없는 사용자)
+// reason이 비어 있으면 저장된 사유를 지움. event가 nil이 아니면 같은 트랜잭션으로 outbox에 기록
+func (s *UserStorage) UpdateUserStatus(ctx context.Context, userID, from, to, reason string, event *OutboxRecord) (*UserItem, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("UserStorage가 초기화되지 않았습니다")
+	}
+	if userID == "" {
+		return nil, fmt.Errorf("%w: userID가 비어 있습니다", ErrInvalidArgument)
+	}
+	if to == "" {
+		return nil, fmt.Errorf("%w: 계정 상태가 비어 있습니다", ErrInvalidArgument)
+	}
+	if IsEmailGuardKey(userID) {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+
+	update := expression.Set(expression.Name("status"), expression.Value(to))
+	if reason != "" {
+		update = update.Set(expression.Name("status_reason"), expression.Value(reason))
+	} else {
+		update = update.Remove(expression.Name("status_reason"))
+	}
+	cond := expression.Name("status").Equal(expression.Value(from))
+	if from == "" {
+		cond = expression.And(
+			expression.AttributeExists(expression.Name("user_id")),
+			expression.AttributeNotExists(expression.Name("status")),
+		)
+	}
+
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(cond).Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	transactItems := []types.TransactWriteItem{
+		{
+			Update: &types.Update{
+				TableName: aws.String(s.tableName),
+				Key: map[string]types.AttributeValue{
+					"user_id": &types.AttributeValueMemberS{Value: userID},
+				},
+				UpdateExpression:                    expr.Update(),
+				ConditionExpression:                 expr.Condition(),
+				ExpressionAttributeNames:            expr.Names(),
+				ExpressionAttributeValues:           expr.Values(),
+				ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+			},
+		},
+	}
+	if event != nil {
+		put, err := outboxPut(s.outboxTableName, event)
+		if err != nil {
+			return nil, err
+		}
+		transactItems = append(transactItems, put)
+	}
+
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if err != nil {
+		var canceled *types.TransactionCanceledException
+		if failedConditionIndex(err) == 0 && errors.As(err, &canceled) {
+			// 조건 실패 시 기존 아이템이 없으면 사용자 자체가 없는 경우
+			if canceled.CancellationReasons[0].Item == nil {
+				return nil, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+			}
+			return nil, fmt.Errorf("%w: %s", ErrUserConflict, userID)
+		}
+		return nil, transactError(ctx, err)
+	}
+
+	// TransactWriteItems는 변경된 아이템을 돌려주지 않으므로 다시 읽음
+	return s.GetUserByID(ctx, userID)
+}
+
+// DeleteUser: 사용자와 이메일 가드를 삭제하고, event가 있으면 같은 트랜잭션으로 outbox에 기록
+func (s *UserStorage) DeleteUser(ctx context.Context, id string, event *OutboxRecord) error {
 	if s == nil || s.client == nil {
 		return errors.New("UserStorage가 초기화되지 않았습니다")
 	}
 	if id == "" {
-		return errors.New("id가 비어 있습니다")
+		return fmt.Errorf("%w: id가 비어 있습니다", ErrInvalidArgument)
 	}
 
-	_, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
-		TableName: aws.String(s.tableName),
-		Key: map[string]types.AttributeValue{
-			"user_id": &types.AttributeValueMemberS{Value: id},
+	current, err := s.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// 조회 이후 다른 요청이 이메일을 바꿨다면 아래 가드 삭제가 다른 가드를 가리키므로 실패시킴
+	deleteExpr, err := expression.NewBuilder().
+		WithCondition(expression.And(
+			expression.AttributeExists(expression.Name("user_id")),
+			expression.Name("email").Equal(expression.Value(current.Email)),
+		)).
+		Build()
+	if err != nil {
+		return fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	guard, err := s.releaseEmailGuard(current)
+	if err != nil {
+		return err
+	}
+
+	// 사용자 아이템과 이메일 가드 아이템을 함께 삭제
+	transactItems := []types.TransactWriteItem{
+		{
+			Delete: &types.Delete{
+				TableName: aws.String(s.tableName),
+				Key: map[string]types.AttributeValue{
+					"user_id": &types.AttributeValueMemberS{Value: id},
+				},
+				ConditionExpression:                 deleteExpr.Condition(),
+				ExpressionAttributeNames:            deleteExpr.Names(),
+				ExpressionAttributeValues:           deleteExpr.Values(),
+				ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+			},
 		},
-		ConditionExpression: aws.String("attribute_exists(user_id)"),
+		{Delete: guard},
+	}
+	if event != nil {
+		put, err := outboxPut(s.outboxTableName, event)
+		if err != nil {
+			return err
+		}
+		transactItems = append(transactItems, put)
+	}
+
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
 	})
 	if err != nil {
-		var ccfe *types.ConditionalCheckFailedException
-		if errors.As(err, &ccfe) {
-			return fmt.Errorf("삭제할 사용자 %s가 존재하지 않습니다", id)
+		var canceled *types.TransactionCanceledException
+		switch failedConditionIndex(err) {
+		case 0:
+			// 조건 실패 시 기존 아이템이 없으면 이미 삭제된 경우, 있으면 이메일이 바뀐 경우
+			if errors.As(err, &canceled) && canceled.CancellationReasons[0].Item != nil {
+				return fmt.Errorf("%w: %s", ErrUserConflict, id)
+			}
+			return fmt.Errorf("%w: %s", ErrUserNotFound, id)
+		case 1:
+			return fmt.Errorf("%w: %s", ErrUserConflict, id)
 		}
-		return fmt.Errorf("DeleteItem 실패: %w", err)
+		return transactError(ctx, err)
 	}
 
 	return nil
 }
+
+// BackfillEmailGuards: 가드 아이템과 email_normalized가 생기기 전에 만든 사용자에게 둘을 채움
+// startKey부터 limit개를 Scan하며, 이미 채워진 사용자도 같은 값으로 다시 기록하므로 여러 번 실행해도 됨
+// 사용자마다 이메일이 Scan 이후 바뀌지 않았을 때만 가드와 email_normalized를 하나의 트랜잭션으로 기록
+func (s *UserStorage) BackfillEmailGuards(ctx context.Context, startKey map[string]types.AttributeValue, limit int32) (*EmailBackfillPage, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("UserStorage가 초기화되지 않았습니다")
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: limit은 0보다 커야 합니다", ErrInvalidArgument)
+	}
+
+	expr, err := expression.NewBuilder().
+		WithFilter(expression.Not(expression.Name("user_id").BeginsWith(emailGuardPrefix))).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+	out, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+		TableName:                 aws.String(s.tableName),
+		FilterExpression:          expr.Filter(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ExclusiveStartKey:         startKey,
+		Limit:                     aws.Int32(limit),
+		ConsistentRead:            aws.Bool(true),
+	})
+	if err != nil {
+		return nil, dynamoError("Scan", err)
+	}
+
+	var users []UserItem
+	if err := attributevalue.UnmarshalListOfMaps(out.Items, &users); err != nil {
+		return nil, fmt.Errorf("사용자 목록 언마샬 실패: %w", err)
+	}
+
+	page := &EmailBackfillPage{NextKey: out.LastEvaluatedKey}
+	for i := range users {
+		written, duplicate, err := s.backfillEmailGuard(ctx, &users[i])
+		if err != nil {
+			return nil, fmt.Errorf("사용자 %s 이메일 가드 기록 실패: %w", users[i].UserID, err)
+		}
+		switch {
+		case written:
+			page.Updated++
+		case duplicate:
+			page.Duplicates = append(page.Duplicates, users[i].UserID)
+		default:
+			page.Skipped++
+		}
+	}
+
+	return page, nil
+}
+
+// backfillEmailGuard: 사용자 한 명의 가드와 email_normalized를 기록 (다른 사용자가 가드를 가지고 있으면 duplicate)
+// Scan 이후 이메일이 바뀌었거나 삭제되었다면 그 요청이 가드를 정리했으므로 둘 다 false로 건너뜀
+func (s *UserStorage) backfillEmailGuard(ctx context.Context, user *UserItem) (written, duplicate bool, err error) {
+	updateExpr, err := expression.NewBuilder().
+		WithUpdate(expression.Set(expression.Name("email_normalized"), expression.Value(normalizeEmail(user.Email)))).
+		WithCondition(expression.Name("email").Equal(expression.Value(user.Email))).
+		Build()
+	if err != nil {
+		return false, false, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+	guardExpr, err := expression.NewBuilder().
+		WithCondition(expression.Or(
+			expression.AttributeNotExists(expression.Name("user_id")),
+			expression.Name("owner_user_id").Equal(expression.Value(user.UserID)),
+		)).
+		Build()
+	if err != nil {
+		return false, false, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+	guard, err := attributevalue.MarshalMap(emailGuardItem{
+		Key:         emailGuardKey(user.Email),
+		OwnerUserID: user.UserID,
+	})
+	if err != nil {
+		return false, false, fmt.Errorf("이메일 가드 marshal 실패: %w", err)
+	}
+
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Update: &types.Update{
+					TableName:                           aws.String(s.tableName),
+					Key:                                 map[string]types.AttributeValue{"user_id": &types.AttributeValueMemberS{Value: user.UserID}},
+					UpdateExpression:                    updateExpr.Update(),
+					ConditionExpression:                 updateExpr.Condition(),
+					ExpressionAttributeNames:            updateExpr.Names(),
+					ExpressionAttributeValues:           updateExpr.Values(),
+					ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+				},
+			},
+			{
+				Put: &types.Put{
+					TableName:                 aws.String(s.tableName),
+					Item:                      guard,
+					ConditionExpression:       guardExpr.Condition(),
+					ExpressionAttributeNames:  guardExpr.Names(),
+					ExpressionAttributeValues: guardExpr.Values(),
+				},
+			},
+		},
+	})
+	if err != nil {
+		switch failedConditionIndex(err) {
+		case 0:
+			return false, false, nil
+		case 1:
+			return false, true, nil
+		}
+		return false, false, transactError(ctx, err)
+	}
+	return true, false, nil
+}
+
+// checkLegacyEmail: legacyEmailCheck가 켜져 있으면 이메일 GSI에서 다른 사용자가 같은 이메일을 쓰는지 확인
+func (s *UserStorage) checkLegacyEmail(ctx context.Context, email, userID string) error {
+	if !s.legacyEmailCheck {
+		return nil
+	}
+	existing, err := s.GetUserByEmail(ctx, email)
+	if errors.Is(err, ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.UserID != userID {
+		return fmt.Errorf("%w: %s", ErrEmailAlreadyExists, email)
+	}
+	return nil
+}
+
+// releaseEmailGuard: 사용자가 소유한 이메일 가드 아이템을 삭제하는 트랜잭션 항목을 만듦
+// 가드 도입 이전에 생성된 사용자는 가드가 없을 수 있으므로 가드가 없는 경우도 허용
+func (s *UserStorage) releaseEmailGuard(user *UserItem) (*types.Delete, error) {
+	expr, err := expression.NewBuilder().
+		WithCondition(expression.Or(
+			expression.AttributeNotExists(expression.Name("user_id")),
+			expression.Name("owner_user_id").Equal(expression.Value(user.UserID)),
+		)).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("expression 빌드 실패: %w", err)
+	}
+
+	return &types.Delete{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"user_id": &types.AttributeValueMemberS{Value: emailGuardKey(user.Email)},
+		},
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}, nil
+}
+
+// normalizeEmail: 대소문자와 앞뒤 공백 차이를 무시하도록 이메일을 정규화
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func emailGuardKey(email string) string {
+	return emailGuardPrefix + normalizeEmail(email)
+}
+
+// IsEmailGuardKey: 사용자 테이블의 키가 사용자가 아닌 이메일 가드 아이템의 키인지
+// (테이블 스트림처럼 가드 아이템이 함께 보이는 곳에서 걸러내는 데 사용)
+func IsEmailGuardKey(userID string) bool {
+	return strings.HasPrefix(userID, emailGuardPrefix)
+}
+
+// failedConditionIndex: 트랜잭션이 조건 검사 실패로 취소된 경우 실패한 항목의 인덱스를 반환
+// 조건 검사 실패가 아니면 -1을 반환
+func failedConditionIndex(err error) int {
+	var tce *types.TransactionCanceledException
+	if !errors.As(err, &tce) {
+		return -1
+	}
+	for i, reason := range tce.CancellationReasons {
+		if aws.ToString(reason.Code) == "ConditionalCheckFailed" {
+			return i
+		}
+	}
+	return -1
+}
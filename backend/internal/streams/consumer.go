@@ -0,0 +1,575 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	streamtypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/retry"
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+)
+
+const (
+	// 열린 shard에 새 레코드가 없을 때 다시 읽기까지 기다리는 시간
+	DefaultPollInterval = time.Second
+	// 스트림의 shard 목록을 다시 확인하는 주기 (shard는 몇 시간마다 나뉘거나 새로 생김)
+	DefaultShardSyncInterval = 30 * time.Second
+	// shard를 처리하는 인스턴스가 lease를 가지는 시간 (처리 위치를 저장할 때마다 연장)
+	DefaultLeaseTTL = 30 * time.Second
+	// GetRecords 한 번에 읽는 최대 레코드 수
+	getRecordsLimit = 1000
+)
+
+// 핸들러 실패 후 재시도 간격 (실패할 때마다 두 배, 최대 1분)
+var retryBackoff = retry.Backoff{Base: time.Second, Max: time.Minute}
+
+// Options: Consumer 설정 (0 값이면 기본값 사용)
+type Options struct {
+	// checkpoint를 구분하는 consumer 이름 (같은 이름의 인스턴스끼리 shard를 나눠 처리)
+	Name string
+	// lease를 가진 인스턴스를 구분하기 위한 이름 (보통 파드 이름)
+	Owner string
+	// checkpoint가 없는 shard를 처음(TRIM_HORIZON) 대신 최신 레코드(LATEST)부터 읽음
+	// 부모 shard가 남아 있는 자식 shard는 레코드를 빠뜨리지 않도록 항상 처음부터 읽음
+	StartAtLatest     bool
+	PollInterval      time.Duration
+	ShardSyncInterval time.Duration
+	LeaseTTL          time.Duration
+}
+
+// Consumer: DynamoDB 테이블 스트림을 shard별로 읽어 등록된 핸들러에 순서대로 전달
+// 처리한 위치는 shard마다 checkpoint로 저장하므로 재시작하면 마지막 checkpoint 다음부터 다시 읽고,
+// checkpoint를 저장하기 전에 처리한 레코드는 다시 전달될 수 있음 (at-least-once)
+// 부모 shard를 끝까지 처리한 뒤에 자식 shard를 시작하므로 같은 아이템의 변경은 기록된 순서대로 전달됨
+type Consumer struct {
+	dynamo      tableDescriber
+	streams     streamReader
+	checkpoints storage.StreamCheckpointRepository
+	tableName   string
+	opts        Options
+
+	mu       sync.Mutex
+	handlers []namedHandler
+	// 이 인스턴스가 처리 중인 shard
+	running map[string]struct{}
+	// 끝까지 처리된 것을 확인한 shard
+	finished map[string]struct{}
+	// shard가 끝나면 자식 shard를 바로 시작하도록 shard 목록 확인을 앞당김
+	wake chan struct{}
+	wg   sync.WaitGroup
+}
+
+// tableDescriber: 테이블의 스트림 ARN을 조회하는 DynamoDB API (*dynamodb.Client)
+type tableDescriber interface {
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+}
+
+// streamReader: shard 목록과 레코드를 읽는 DynamoDB Streams API (*dynamodbstreams.Client)
+type streamReader interface {
+	DescribeStream(ctx context.Context, params *dynamodbstreams.DescribeStreamInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.DescribeStreamOutput, error)
+	GetShardIterator(ctx context.Context, params *dynamodbstreams.GetShardIteratorInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetShardIteratorOutput, error)
+	GetRecords(ctx context.Context, params *dynamodbstreams.GetRecordsInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetRecordsOutput, error)
+}
+
+type namedHandler struct {
+	name    string
+	handler Handler
+}
+
+// NewConsumer: tableName 테이블의 스트림 consumer 생성
+// 테이블에 스트림이 켜져 있어야 하며, UserHandler/OrderHandler를 쓰려면 view type이 NEW_AND_OLD_IMAGES여야 함
+func NewConsumer(dynamoClient *dynamodb.Client, streamsClient *dynamodbstreams.Client, checkpoints storage.StreamCheckpointRepository, tableName string, opts Options) (*Consumer, error) {
+	if dynamoClient == nil || streamsClient == nil {
+		return nil, errors.New("dynamodb client가 nil입니다")
+	}
+	if checkpoints == nil {
+		return nil, errors.New("checkpoint repository가 nil입니다")
+	}
+	if tableName == "" {
+		return nil, errors.New("tableName이 비어 있습니다")
+	}
+	if opts.Name == "" || opts.Owner == "" {
+		return nil, errors.New("consumer 이름과 owner는 필수입니다")
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.ShardSyncInterval <= 0 {
+		opts.ShardSyncInterval = DefaultShardSyncInterval
+	}
+	if opts.LeaseTTL <= 0 {
+		opts.LeaseTTL = DefaultLeaseTTL
+	}
+
+	return &Consumer{
+		dynamo:      dynamoClient,
+		streams:     streamsClient,
+		checkpoints: checkpoints,
+		tableName:   tableName,
+		opts:        opts,
+		running:     make(map[string]struct{}),
+		finished:    make(map[string]struct{}),
+		wake:        make(chan struct{}, 1),
+	}, nil
+}
+
+// Handle: 레코드를 전달할 핸들러 등록 (Run 전에 등록해야 함)
+// 레코드마다 등록한 순서대로 호출하며, 실패한 핸들러는 성공할 때까지 다시 호출함
+func (c *Consumer) Handle(name string, handler Handler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.handlers = append(c.handlers, namedHandler{name: name, handler: handler})
+}
+
+// Run: ctx가 끝날 때까지 shard 목록을 주기적으로 확인하며 새 shard 처리를 시작
+// ctx가 끝나면 처리 중인 shard의 lease를 반납하고 모든 shard 처리가 멈춘 뒤 반환
+func (c *Consumer) Run(ctx context.Context) error {
+	c.mu.Lock()
+	handlerCount := len(c.handlers)
+	c.mu.Unlock()
+	if handlerCount == 0 {
+		return errors.New("등록된 스트림 핸들러가 없습니다")
+	}
+
+	ticker := time.NewTicker(c.opts.ShardSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.syncShards(ctx); err != nil && ctx.Err() == nil {
+			c.logger().Error("스트림 shard 확인 실패", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			c.wg.Wait()
+			return nil
+		case <-ticker.C:
+		case <-c.wake:
+		}
+	}
+}
+
+// syncShards: 아직 처리하지 않은 shard 중 부모 shard가 끝난 것의 lease를 가져와 처리를 시작
+func (c *Consumer) syncShards(ctx context.Context) error {
+	streamARN, err := c.streamARN(ctx)
+	if err != nil {
+		return err
+	}
+	shards, err := c.listShards(ctx, streamARN)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]struct{}, len(shards))
+	for _, shard := range shards {
+		known[aws.ToString(shard.ShardId)] = struct{}{}
+	}
+
+	for _, shard := range shards {
+		shardID := aws.ToString(shard.ShardId)
+		if c.isRunning(shardID) || c.isFinished(shardID) {
+			continue
+		}
+
+		// 부모 shard가 스트림에 남아 있으면 부모를 끝까지 처리한 뒤에 시작
+		// (보관 기간이 지나 목록에서 빠진 부모는 더 읽을 레코드가 없음)
+		parentID := aws.ToString(shard.ParentShardId)
+		_, hasParent := known[parentID]
+		if parentID != "" && hasParent {
+			done, err := c.parentFinished(ctx, parentID)
+			if err != nil {
+				return err
+			}
+			if !done {
+				continue
+			}
+		}
+
+		if err := c.startShard(ctx, streamARN, shardID, c.opts.StartAtLatest && !hasParent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Consumer) startShard(ctx context.Context, streamARN, shardID string, latest bool) error {
+	now := time.Now()
+	checkpoint, err := c.checkpoints.AcquireShardLease(ctx, c.opts.Name, streamARN, shardID, c.opts.Owner, now, now.Add(c.opts.LeaseTTL))
+	if errors.Is(err, storage.ErrShardLeaseHeld) {
+		// 다른 인스턴스가 처리 중
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("shard %s lease 획득 실패: %w", shardID, err)
+	}
+
+	worker := &shardWorker{
+		consumer:  c,
+		streamARN: streamARN,
+		shardID:   shardID,
+		latest:    latest,
+		sequence:  checkpoint.SequenceNumber,
+		saved:     checkpoint.SequenceNumber,
+		savedAt:   now,
+	}
+	if checkpoint.Finished {
+		c.markFinished(shardID)
+		worker.release()
+		return nil
+	}
+
+	c.mu.Lock()
+	c.running[shardID] = struct{}{}
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer func() {
+			c.mu.Lock()
+			delete(c.running, shardID)
+			c.mu.Unlock()
+		}()
+		worker.run(ctx)
+	}()
+	return nil
+}
+
+// parentFinished: 부모 shard를 (어느 인스턴스든) 끝까지 처리했는지
+func (c *Consumer) parentFinished(ctx context.Context, parentID string) (bool, error) {
+	if c.isFinished(parentID) {
+		return true, nil
+	}
+	checkpoint, err := c.checkpoints.GetCheckpoint(ctx, c.opts.Name, parentID)
+	if errors.Is(err, storage.ErrCheckpointNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("shard %s checkpoint 조회 실패: %w", parentID, err)
+	}
+	if checkpoint.Finished {
+		c.markFinished(parentID)
+	}
+	return checkpoint.Finished, nil
+}
+
+// streamARN: 테이블의 현재 스트림 ARN (스트림을 껐다 켜면 바뀜)
+func (c *Consumer) streamARN(ctx context.Context) (string, error) {
+	out, err := c.dynamo.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(c.tableName),
+	})
+	if err != nil {
+		return "", fmt.Errorf("DescribeTable 실패: %w", err)
+	}
+
+	spec := out.Table.StreamSpecification
+	if spec == nil || !aws.ToBool(spec.StreamEnabled) || out.Table.LatestStreamArn == nil {
+		return "", fmt.Errorf("%s 테이블에 스트림이 켜져 있지 않습니다", c.tableName)
+	}
+	if spec.StreamViewType != types.StreamViewTypeNewAndOldImages {
+		c.logger().Warn("변경 전후 이미지를 받으려면 스트림 view type이 NEW_AND_OLD_IMAGES여야 합니다", "view_type", spec.StreamViewType)
+	}
+	return aws.ToString(out.Table.LatestStreamArn), nil
+}
+
+func (c *Consumer) listShards(ctx context.Context, streamARN string) ([]streamtypes.Shard, error) {
+	var shards []streamtypes.Shard
+	var startShardID *string
+	for {
+		out, err := c.streams.DescribeStream(ctx, &dynamodbstreams.DescribeStreamInput{
+			StreamArn:             aws.String(streamARN),
+			ExclusiveStartShardId: startShardID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("DescribeStream 실패: %w", err)
+		}
+		shards = append(shards, out.StreamDescription.Shards...)
+
+		startShardID = out.StreamDescription.LastEvaluatedShardId
+		if startShardID == nil {
+			return shards, nil
+		}
+	}
+}
+
+// dispatch: 레코드를 모든 핸들러에 전달 (실패한 핸들러는 성공할 때까지 다시 호출)
+// ctx가 끝나거나 재시도하는 동안 lease를 잃으면 에러를 반환하고 레코드는 처리되지 않은 것으로 남음
+func (c *Consumer) dispatch(ctx context.Context, worker *shardWorker, record Record) error {
+	c.mu.Lock()
+	handlers := c.handlers
+	c.mu.Unlock()
+
+	for _, h := range handlers {
+		for attempt := 0; ; attempt++ {
+			err := h.handler(ctx, record)
+			if err == nil {
+				break
+			}
+			if errors.Is(err, ErrSkipRecord) {
+				worker.logger().Warn("스트림 레코드 건너뜀", "handler", h.name, "event_id", record.EventID, "sequence_number", record.SequenceNumber, "error", err)
+				break
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			delay := retryBackoff.Delay(attempt)
+			worker.logger().Warn("스트림 레코드 처리 실패, 다시 시도합니다", "handler", h.name, "event_id", record.EventID, "retry_after", delay.String(), "error", err)
+			if err := retry.Sleep(ctx, delay); err != nil {
+				return err
+			}
+			if err := worker.renewIfDue(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Consumer) logger() *slog.Logger {
+	return slog.Default().With("table", c.tableName, "consumer", c.opts.Name)
+}
+
+func (c *Consumer) isRunning(shardID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.running[shardID]
+	return ok
+}
+
+func (c *Consumer) isFinished(shardID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.finished[shardID]
+	return ok
+}
+
+func (c *Consumer) markFinished(shardID string) {
+	c.mu.Lock()
+	c.finished[shardID] = struct{}{}
+	c.mu.Unlock()
+
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+// shardWorker: lease를 가진 shard 하나를 끝까지(또는 ctx가 끝날 때까지) 처리
+type shardWorker struct {
+	consumer  *Consumer
+	streamARN string
+	shardID   string
+	latest    bool
+	// 마지막으로 처리한 레코드의 sequence number
+	sequence string
+	// 마지막으로 저장한 sequence number와 저장(lease 연장) 시각
+	saved   string
+	savedAt time.Time
+}
+
+func (w *shardWorker) logger() *slog.Logger {
+	return w.consumer.logger().With("shard", w.shardID)
+}
+
+func (w *shardWorker) run(ctx context.Context) {
+	c := w.consumer
+
+	iterator, err := w.iterator(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger().Error("shard iterator 조회 실패", "error", err)
+		}
+		w.release()
+		return
+	}
+
+	for {
+		out, err := c.streams.GetRecords(ctx, &dynamodbstreams.GetRecordsInput{
+			ShardIterator: iterator,
+			Limit:         aws.Int32(getRecordsLimit),
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				w.release()
+				return
+			}
+			if iterator, err = w.recover(ctx, err); err != nil {
+				w.logger().Error("shard 처리 중단", "error", err)
+				w.release()
+				return
+			}
+			continue
+		}
+
+		for _, raw := range out.Records {
+			record, err := decodeRecord(w.shardID, raw)
+			if err != nil {
+				w.logger().Warn("스트림 레코드 변환 실패, 건너뜀", "error", err)
+			} else if err := c.dispatch(ctx, w, record); err != nil {
+				if !errors.Is(err, storage.ErrShardLeaseLost) {
+					w.release()
+				}
+				return
+			}
+			if raw.Dynamodb != nil {
+				w.sequence = aws.ToString(raw.Dynamodb.SequenceNumber)
+			}
+		}
+
+		// 다음 iterator가 없으면 닫힌 shard를 끝까지 읽은 것
+		finished := out.NextShardIterator == nil
+		if len(out.Records) > 0 || finished {
+			if err := w.save(ctx, finished); err != nil {
+				if errors.Is(err, storage.ErrShardLeaseLost) || ctx.Err() != nil {
+					return
+				}
+				// 저장하지 못한 위치는 다음 저장 때 함께 기록되며, 그 전에 재시작하면 다시 전달됨
+				w.logger().Warn("checkpoint 저장 실패", "error", err)
+				if finished {
+					w.release()
+					return
+				}
+			}
+		} else if err := w.renewIfDue(ctx); err != nil {
+			return
+		}
+
+		if finished {
+			c.markFinished(w.shardID)
+			return
+		}
+		iterator = out.NextShardIterator
+
+		if len(out.Records) == 0 {
+			if err := retry.Sleep(ctx, c.opts.PollInterval); err != nil {
+				w.release()
+				return
+			}
+		}
+	}
+}
+
+// iterator: 마지막으로 처리한 레코드 다음부터 읽는 iterator
+// 처리한 레코드가 없으면 shard 처음(또는 latest면 최신)부터 읽음
+func (w *shardWorker) iterator(ctx context.Context) (*string, error) {
+	input := &dynamodbstreams.GetShardIteratorInput{
+		StreamArn: aws.String(w.streamARN),
+		ShardId:   aws.String(w.shardID),
+	}
+	switch {
+	case w.sequence != "":
+		input.ShardIteratorType = streamtypes.ShardIteratorTypeAfterSequenceNumber
+		input.SequenceNumber = aws.String(w.sequence)
+	case w.latest:
+		input.ShardIteratorType = streamtypes.ShardIteratorTypeLatest
+	default:
+		input.ShardIteratorType = streamtypes.ShardIteratorTypeTrimHorizon
+	}
+
+	out, err := w.consumer.streams.GetShardIterator(ctx, input)
+	if err != nil {
+		var trimmed *streamtypes.TrimmedDataAccessException
+		if w.sequence != "" && errors.As(err, &trimmed) {
+			// checkpoint 다음 레코드가 이미 보관 기간을 지나 삭제된 경우 남아 있는 처음부터 읽음
+			w.logger().Warn("checkpoint 이후 레코드 일부가 보관 기간이 지나 삭제되었습니다", "sequence_number", w.sequence)
+			w.sequence = ""
+			w.latest = false
+			return w.iterator(ctx)
+		}
+		return nil, fmt.Errorf("GetShardIterator 실패: %w", err)
+	}
+	return out.ShardIterator, nil
+}
+
+// recover: GetRecords 실패 후 다시 읽을 iterator
+// shard가 사라졌으면 에러를 반환하고, 일시적인 실패는 잠시 기다린 뒤 새 iterator로 다시 읽음
+func (w *shardWorker) recover(ctx context.Context, err error) (*string, error) {
+	var notFound *streamtypes.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return nil, fmt.Errorf("shard를 찾을 수 없습니다: %w", err)
+	}
+
+	var expired *streamtypes.ExpiredIteratorException
+	if !errors.As(err, &expired) {
+		w.logger().Error("GetRecords 실패", "error", err)
+		if err := retry.Sleep(ctx, w.consumer.opts.PollInterval); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.renewIfDue(ctx); err != nil {
+		return nil, err
+	}
+	return w.iterator(ctx)
+}
+
+// save: 처리 위치를 저장하고 lease를 연장
+func (w *shardWorker) save(ctx context.Context, finished bool) error {
+	c := w.consumer
+	now := time.Now()
+	err := c.checkpoints.SaveCheckpoint(ctx, storage.StreamCheckpoint{
+		Consumer:       c.opts.Name,
+		ShardID:        w.shardID,
+		Owner:          c.opts.Owner,
+		SequenceNumber: w.sequence,
+		Finished:       finished,
+		LeaseUntil:     now.Add(c.opts.LeaseTTL),
+	})
+	if errors.Is(err, storage.ErrShardLeaseLost) {
+		w.logger().Warn("shard lease를 잃어 처리를 멈춥니다")
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	w.saved = w.sequence
+	w.savedAt = now
+	return nil
+}
+
+// renewIfDue: lease의 1/3이 지났으면 처리 위치를 저장하며 lease를 연장
+// lease를 잃은 경우에만 에러를 반환 (일시적인 저장 실패는 다음 연장 때 다시 시도)
+func (w *shardWorker) renewIfDue(ctx context.Context) error {
+	if time.Since(w.savedAt) < w.consumer.opts.LeaseTTL/3 {
+		return nil
+	}
+	err := w.save(ctx, false)
+	if errors.Is(err, storage.ErrShardLeaseLost) {
+		return err
+	}
+	if err != nil && ctx.Err() == nil {
+		w.logger().Warn("shard lease 연장 실패", "error", err)
+	}
+	return nil
+}
+
+// release: 저장하지 않은 처리 위치를 저장하고 lease를 반납해 다른 인스턴스가 바로 이어서 처리할 수 있게 함
+func (w *shardWorker) release() {
+	c := w.consumer
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if w.sequence != w.saved {
+		if err := w.save(ctx, false); err != nil {
+			if !errors.Is(err, storage.ErrShardLeaseLost) {
+				w.logger().Warn("checkpoint 저장 실패", "error", err)
+			}
+			return
+		}
+	}
+	if err := c.checkpoints.ReleaseShardLease(ctx, c.opts.Name, w.shardID, c.opts.Owner); err != nil && !errors.Is(err, storage.ErrShardLeaseLost) {
+		w.logger().Warn("shard lease 반납 실패", "error", err)
+	}
+}
@@ -0,0 +1,361 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	streamtypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/retry"
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+)
+
+const (
+	testTable     = "users"
+	testStreamARN = "arn:aws:dynamodb:ap-northeast-2:000000000000:table/users/stream/test"
+	testConsumer  = "test-consumer"
+)
+
+// fakeShard: 테스트 스트림의 shard 하나
+type fakeShard struct {
+	id     string
+	parent string
+	// 레코드의 sequence number는 "<shard ID>-<순번>"
+	records int
+	// 보관 기간이 지나 삭제된 앞쪽 레코드 수
+	trimmed int
+	// 닫힌 shard는 끝까지 읽으면 다음 iterator가 없음
+	closed bool
+}
+
+// fakeStream: 메모리에서 shard를 읽는 DynamoDB Streams (iterator는 "<shard ID>#<다음 위치>")
+type fakeStream struct {
+	shards []fakeShard
+}
+
+func sequenceNumber(shardID string, i int) string {
+	return fmt.Sprintf("%s-%03d", shardID, i+1)
+}
+
+func (f *fakeStream) shard(shardID string) (*fakeShard, error) {
+	for i := range f.shards {
+		if f.shards[i].id == shardID {
+			return &f.shards[i], nil
+		}
+	}
+	return nil, &streamtypes.ResourceNotFoundException{Message: aws.String(shardID)}
+}
+
+func (f *fakeStream) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	return &dynamodb.DescribeTableOutput{Table: &types.TableDescription{
+		TableName: params.TableName,
+		StreamSpecification: &types.StreamSpecification{
+			StreamEnabled:  aws.Bool(true),
+			StreamViewType: types.StreamViewTypeNewAndOldImages,
+		},
+		LatestStreamArn: aws.String(testStreamARN),
+	}}, nil
+}
+
+func (f *fakeStream) DescribeStream(ctx context.Context, params *dynamodbstreams.DescribeStreamInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.DescribeStreamOutput, error) {
+	var shards []streamtypes.Shard
+	for _, shard := range f.shards {
+		s := streamtypes.Shard{ShardId: aws.String(shard.id)}
+		if shard.parent != "" {
+			s.ParentShardId = aws.String(shard.parent)
+		}
+		shards = append(shards, s)
+	}
+	return &dynamodbstreams.DescribeStreamOutput{StreamDescription: &streamtypes.StreamDescription{Shards: shards}}, nil
+}
+
+func (f *fakeStream) GetShardIterator(ctx context.Context, params *dynamodbstreams.GetShardIteratorInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetShardIteratorOutput, error) {
+	shard, err := f.shard(aws.ToString(params.ShardId))
+	if err != nil {
+		return nil, err
+	}
+
+	var next int
+	switch params.ShardIteratorType {
+	case streamtypes.ShardIteratorTypeTrimHorizon:
+		next = shard.trimmed
+	case streamtypes.ShardIteratorTypeLatest:
+		next = shard.records
+	case streamtypes.ShardIteratorTypeAfterSequenceNumber:
+		sequence := aws.ToString(params.SequenceNumber)
+		n, err := strconv.Atoi(sequence[strings.LastIndex(sequence, "-")+1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < shard.trimmed {
+			return nil, &streamtypes.TrimmedDataAccessException{Message: aws.String(sequence)}
+		}
+		next = n
+	default:
+		return nil, fmt.Errorf("지원하지 않는 iterator 종류: %s", params.ShardIteratorType)
+	}
+	return &dynamodbstreams.GetShardIteratorOutput{ShardIterator: aws.String(fmt.Sprintf("%s#%d", shard.id, next))}, nil
+}
+
+func (f *fakeStream) GetRecords(ctx context.Context, params *dynamodbstreams.GetRecordsInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetRecordsOutput, error) {
+	shardID, pos, _ := strings.Cut(aws.ToString(params.ShardIterator), "#")
+	shard, err := f.shard(shardID)
+	if err != nil {
+		return nil, err
+	}
+	next, err := strconv.Atoi(pos)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &dynamodbstreams.GetRecordsOutput{}
+	for i := next; i < shard.records; i++ {
+		sequence := sequenceNumber(shard.id, i)
+		out.Records = append(out.Records, streamtypes.Record{
+			EventID:   aws.String(sequence),
+			EventName: streamtypes.OperationTypeModify,
+			Dynamodb: &streamtypes.StreamRecord{
+				SequenceNumber: aws.String(sequence),
+				Keys:           map[string]streamtypes.AttributeValue{"user_id": &streamtypes.AttributeValueMemberS{Value: "user_1"}},
+			},
+		})
+	}
+	if !shard.closed || next < shard.records {
+		out.NextShardIterator = aws.String(fmt.Sprintf("%s#%d", shard.id, shard.records))
+	}
+	return out, nil
+}
+
+// consumerHarness: fakeStream과 메모리 checkpoint 저장소로 실행하는 consumer
+type consumerHarness struct {
+	consumer    *Consumer
+	checkpoints *storage.MemoryStreamCheckpointStorage
+
+	mu sync.Mutex
+	// 핸들러가 처리한 레코드의 sequence number 순서
+	handled []string
+}
+
+func newConsumerHarness(t *testing.T, stream *fakeStream, opts Options) *consumerHarness {
+	t.Helper()
+	// 재시도 대기 시간을 줄여 테스트가 오래 걸리지 않게 함
+	backoff := retryBackoff
+	retryBackoff = retry.Backoff{Base: 20 * time.Millisecond, Max: 20 * time.Millisecond}
+	t.Cleanup(func() { retryBackoff = backoff })
+
+	opts.Name = testConsumer
+	opts.Owner = "pod-a"
+	opts.PollInterval = 5 * time.Millisecond
+	opts.ShardSyncInterval = 10 * time.Millisecond
+	checkpoints := storage.NewMemoryStreamCheckpointStorage()
+	consumer, err := NewConsumer(dynamodb.New(dynamodb.Options{}), dynamodbstreams.New(dynamodbstreams.Options{}), checkpoints, testTable, opts)
+	if err != nil {
+		t.Fatalf("NewConsumer: %v", err)
+	}
+	consumer.dynamo = stream
+	consumer.streams = stream
+	return &consumerHarness{consumer: consumer, checkpoints: checkpoints}
+}
+
+// handle: 레코드를 기록하는 핸들러 (before가 에러를 반환하면 기록하지 않고 실패)
+func (h *consumerHarness) handle(before func(ctx context.Context, record Record) error) {
+	h.consumer.Handle("test", func(ctx context.Context, record Record) error {
+		if before != nil {
+			if err := before(ctx, record); err != nil {
+				return err
+			}
+		}
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.handled = append(h.handled, record.SequenceNumber)
+		return nil
+	})
+}
+
+func (h *consumerHarness) handledRecords() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Clone(h.handled)
+}
+
+// run: done이 true가 될 때까지 consumer를 실행하고 멈춤
+func (h *consumerHarness) run(t *testing.T, done func() bool) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() { result <- h.consumer.Run(ctx) }()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !done() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	if err := <-result; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+}
+
+func (h *consumerHarness) checkpoint(t *testing.T, shardID string) *storage.StreamCheckpointRecord {
+	t.Helper()
+	record, err := h.checkpoints.GetCheckpoint(context.Background(), testConsumer, shardID)
+	if err != nil {
+		t.Fatalf("GetCheckpoint(%s): %v", shardID, err)
+	}
+	return record
+}
+
+// finished: shard를 끝까지 처리했다고 저장했는지
+func (h *consumerHarness) finished(shardID string) bool {
+	record, err := h.checkpoints.GetCheckpoint(context.Background(), testConsumer, shardID)
+	return err == nil && record.Finished
+}
+
+func TestConsumerParentBeforeChild(t *testing.T) {
+	stream := &fakeStream{shards: []fakeShard{
+		// 목록에서 자식 shard가 먼저 나와도 부모를 끝낸 뒤 시작해야 함
+		{id: "child", parent: "parent", records: 1, closed: true},
+		{id: "parent", records: 2, closed: true},
+		// 보관 기간이 지나 목록에서 빠진 부모를 기다리지 않음
+		{id: "orphan", parent: "trimmed-parent", records: 1, closed: true},
+	}}
+	h := newConsumerHarness(t, stream, Options{})
+	h.handle(func(ctx context.Context, record Record) error {
+		// 부모 처리가 늦어져도 자식이 먼저 시작되지 않는지 확인
+		if record.ShardID == "parent" {
+			time.Sleep(20 * time.Millisecond)
+		}
+		return nil
+	})
+
+	h.run(t, func() bool { return len(h.handledRecords()) == 4 })
+
+	handled := h.handledRecords()
+	var ordered []string
+	for _, sequence := range handled {
+		if !strings.HasPrefix(sequence, "orphan") {
+			ordered = append(ordered, sequence)
+		}
+	}
+	if want := []string{"parent-001", "parent-002", "child-001"}; !slices.Equal(ordered, want) {
+		t.Errorf("처리 순서 = %v, want %v", ordered, want)
+	}
+	if !slices.Contains(handled, "orphan-001") {
+		t.Errorf("부모가 목록에 없는 shard를 처리하지 않음: %v", handled)
+	}
+	for _, shardID := range []string{"parent", "child", "orphan"} {
+		if !h.finished(shardID) {
+			t.Errorf("%s shard를 끝까지 처리한 것으로 기록하지 않음", shardID)
+		}
+	}
+}
+
+func TestConsumerResumeFromCheckpoint(t *testing.T) {
+	tests := []struct {
+		name string
+		// 이전 인스턴스가 저장한 처리 위치 (빈 값이면 checkpoint 없음)
+		sequence string
+		// 보관 기간이 지나 삭제된 앞쪽 레코드 수
+		trimmed     int
+		startLatest bool
+		want        []string
+	}{
+		{
+			name: "checkpoint 없으면 처음부터",
+			want: []string{"shard-001", "shard-002", "shard-003", "shard-004"},
+		},
+		{
+			name:     "checkpoint 다음부터",
+			sequence: "shard-002",
+			want:     []string{"shard-003", "shard-004"},
+		},
+		{
+			// checkpoint 다음 레코드가 삭제되었으면 남아 있는 처음부터 읽음
+			name:     "삭제된 레코드 이후의 남은 레코드부터",
+			sequence: "shard-001",
+			trimmed:  2,
+			want:     []string{"shard-003", "shard-004"},
+		},
+		{
+			name:        "최신부터 읽도록 설정해도 checkpoint가 있으면 다음부터",
+			sequence:    "shard-003",
+			startLatest: true,
+			want:        []string{"shard-004"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			stream := &fakeStream{shards: []fakeShard{{id: "shard", records: 4, trimmed: tt.trimmed, closed: true}}}
+			h := newConsumerHarness(t, stream, Options{StartAtLatest: tt.startLatest})
+			h.handle(nil)
+
+			if tt.sequence != "" {
+				// 다른 인스턴스가 처리하다 lease를 반납한 상태
+				now := time.Now()
+				if _, err := h.checkpoints.AcquireShardLease(ctx, testConsumer, testStreamARN, "shard", "pod-b", now, now.Add(time.Minute)); err != nil {
+					t.Fatalf("AcquireShardLease: %v", err)
+				}
+				if err := h.checkpoints.SaveCheckpoint(ctx, storage.StreamCheckpoint{Consumer: testConsumer, ShardID: "shard", Owner: "pod-b", SequenceNumber: tt.sequence, LeaseUntil: now}); err != nil {
+					t.Fatalf("SaveCheckpoint: %v", err)
+				}
+				if err := h.checkpoints.ReleaseShardLease(ctx, testConsumer, "shard", "pod-b"); err != nil {
+					t.Fatalf("ReleaseShardLease: %v", err)
+				}
+			}
+
+			h.run(t, func() bool { return h.finished("shard") })
+
+			if got := h.handledRecords(); !slices.Equal(got, tt.want) {
+				t.Errorf("처리한 레코드 = %v, want %v", got, tt.want)
+			}
+			if checkpoint := h.checkpoint(t, "shard"); checkpoint.SequenceNumber != "shard-004" {
+				t.Errorf("저장된 처리 위치 = %s, want shard-004", checkpoint.SequenceNumber)
+			}
+		})
+	}
+}
+
+func TestConsumerStopsAfterLeaseLost(t *testing.T) {
+	stream := &fakeStream{shards: []fakeShard{{id: "shard", records: 3}}}
+	// 핸들러를 다시 시도하는 동안 lease를 연장하도록 lease를 짧게 잡음
+	h := newConsumerHarness(t, stream, Options{LeaseTTL: 30 * time.Millisecond})
+
+	var stolen bool
+	h.handle(func(ctx context.Context, record Record) error {
+		if stolen {
+			return nil
+		}
+		// 첫 레코드를 처리하는 동안 lease가 지난 것으로 보고 다른 인스턴스가 가져감
+		stolen = true
+		later := time.Now().Add(time.Hour)
+		if _, err := h.checkpoints.AcquireShardLease(ctx, testConsumer, testStreamARN, "shard", "pod-b", later, later.Add(time.Hour)); err != nil {
+			return err
+		}
+		return errors.New("일시적인 실패")
+	})
+
+	h.run(t, func() bool {
+		time.Sleep(100 * time.Millisecond)
+		return true
+	})
+
+	// lease를 잃으면 처리 중인 레코드를 포기하고 다음 레코드로 넘어가지 않음
+	if got := h.handledRecords(); len(got) != 0 {
+		t.Errorf("lease를 잃은 뒤 처리한 레코드 = %v, want 없음", got)
+	}
+	checkpoint := h.checkpoint(t, "shard")
+	if checkpoint.LeaseOwner != "pod-b" || checkpoint.SequenceNumber != "" {
+		t.Errorf("checkpoint = owner %s 위치 %q, want pod-b가 가진 처음 위치", checkpoint.LeaseOwner, checkpoint.SequenceNumber)
+	}
+}
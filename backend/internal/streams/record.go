@@ -0,0 +1,138 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	streamtypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+)
+
+// Operation: 스트림 레코드를 만든 변경 종류
+type Operation string
+
+const (
+	OperationInsert Operation = "INSERT"
+	OperationModify Operation = "MODIFY"
+	OperationRemove Operation = "REMOVE"
+)
+
+// ErrSkipRecord: 핸들러가 이 에러를 감싸 반환하면 재시도하지 않고 다음 레코드로 넘어감
+// (디코딩할 수 없는 레코드처럼 다시 시도해도 성공할 수 없는 경우에만 사용)
+var ErrSkipRecord = errors.New("처리할 수 없는 스트림 레코드입니다")
+
+// Record: 테이블 아이템 하나의 변경
+// 이미지는 스트림 view type이 NEW_AND_OLD_IMAGES일 때 채워짐 (INSERT는 OldImage, REMOVE는 NewImage가 없음)
+type Record struct {
+	EventID        string
+	Operation      Operation
+	ShardID        string
+	SequenceNumber string
+	// 변경이 기록된 대략적인 시각
+	ApproximateCreatedAt time.Time
+	Keys                 map[string]types.AttributeValue
+	OldImage             map[string]types.AttributeValue
+	NewImage             map[string]types.AttributeValue
+}
+
+// Handler: 스트림 레코드 처리 함수
+// 레코드는 한 번 이상 전달될 수 있으므로(at-least-once) 같은 레코드를 여러 번 처리해도 결과가 같아야 함
+// 에러를 반환하면 같은 레코드로 다시 호출되며, 그동안 같은 shard의 다음 레코드는 처리하지 않음
+type Handler func(ctx context.Context, record Record) error
+
+// UserChange: 사용자 테이블의 사용자 아이템 변경 (없는 쪽 이미지는 nil)
+type UserChange struct {
+	Record
+	Old *storage.UserItem
+	New *storage.UserItem
+}
+
+// OrderChange: 주문 테이블의 주문 변경 (없는 쪽 이미지는 nil)
+type OrderChange struct {
+	Record
+	Old *storage.OrderRecord
+	New *storage.OrderRecord
+}
+
+// UserHandler: 사용자 테이블 스트림 레코드를 UserChange로 디코딩해 fn에 넘김
+// 같은 테이블에 저장되는 이메일 가드 아이템의 변경은 넘기지 않음
+func UserHandler(fn func(ctx context.Context, change UserChange) error) Handler {
+	return func(ctx context.Context, record Record) error {
+		var key struct {
+			UserID string `dynamodbav:"user_id"`
+		}
+		if err := attributevalue.UnmarshalMap(record.Keys, &key); err != nil {
+			return fmt.Errorf("%w: 사용자 키 언마샬 실패: %w", ErrSkipRecord, err)
+		}
+		if storage.IsEmailGuardKey(key.UserID) {
+			return nil
+		}
+
+		change := UserChange{Record: record}
+		if len(record.OldImage) > 0 {
+			change.Old = &storage.UserItem{}
+			if err := attributevalue.UnmarshalMap(record.OldImage, change.Old); err != nil {
+				return fmt.Errorf("%w: 사용자 이미지 언마샬 실패: %w", ErrSkipRecord, err)
+			}
+		}
+		if len(record.NewImage) > 0 {
+			change.New = &storage.UserItem{}
+			if err := attributevalue.UnmarshalMap(record.NewImage, change.New); err != nil {
+				return fmt.Errorf("%w: 사용자 이미지 언마샬 실패: %w", ErrSkipRecord, err)
+			}
+		}
+		return fn(ctx, change)
+	}
+}
+
+// OrderHandler: 주문 테이블 스트림 레코드를 OrderChange로 디코딩해 fn에 넘김
+func OrderHandler(fn func(ctx context.Context, change OrderChange) error) Handler {
+	return func(ctx context.Context, record Record) error {
+		change := OrderChange{Record: record}
+		if len(record.OldImage) > 0 {
+			change.Old = &storage.OrderRecord{}
+			if err := attributevalue.UnmarshalMap(record.OldImage, change.Old); err != nil {
+				return fmt.Errorf("%w: 주문 이미지 언마샬 실패: %w", ErrSkipRecord, err)
+			}
+		}
+		if len(record.NewImage) > 0 {
+			change.New = &storage.OrderRecord{}
+			if err := attributevalue.UnmarshalMap(record.NewImage, change.New); err != nil {
+				return fmt.Errorf("%w: 주문 이미지 언마샬 실패: %w", ErrSkipRecord, err)
+			}
+		}
+		return fn(ctx, change)
+	}
+}
+
+// decodeRecord: DynamoDB Streams 레코드를 Record로 변환 (속성 값은 dynamodb 패키지 타입으로 바꿈)
+func decodeRecord(shardID string, raw streamtypes.Record) (Record, error) {
+	record := Record{
+		EventID:   aws.ToString(raw.EventID),
+		Operation: Operation(raw.EventName),
+		ShardID:   shardID,
+	}
+	if raw.Dynamodb == nil {
+		return record, errors.New("스트림 레코드에 변경 내용이 없습니다")
+	}
+	record.SequenceNumber = aws.ToString(raw.Dynamodb.SequenceNumber)
+	record.ApproximateCreatedAt = aws.ToTime(raw.Dynamodb.ApproximateCreationDateTime)
+
+	var err error
+	if record.Keys, err = attributevalue.FromDynamoDBStreamsMap(raw.Dynamodb.Keys); err != nil {
+		return record, fmt.Errorf("키 변환 실패: %w", err)
+	}
+	if record.OldImage, err = attributevalue.FromDynamoDBStreamsMap(raw.Dynamodb.OldImage); err != nil {
+		return record, fmt.Errorf("이전 이미지 변환 실패: %w", err)
+	}
+	if record.NewImage, err = attributevalue.FromDynamoDBStreamsMap(raw.Dynamodb.NewImage); err != nil {
+		return record, fmt.Errorf("새 이미지 변환 실패: %w", err)
+	}
+	return record, nil
+}
@@ -0,0 +1,114 @@
+package tracing
+
+import (
+	"context"
+	"slices"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "Acho-mj/2025_Golang_MSA/backend/internal/tracing"
+
+// DynamoDBMiddleware: DynamoDB 클라이언트의 APIOptions에 추가하는 span middleware
+// operation마다 client span 하나를 만듦 (SDK 재시도를 포함한 요청 하나가 span 하나)
+// outbox relay 폴링, readiness 확인처럼 RPC 밖에서 하는 호출까지 trace로 남기지 않도록
+// ctx에 이미 span이 있을 때(요청 처리 중)만 기록함
+func DynamoDBMiddleware(stack *middleware.Stack) error {
+	// 서비스 메타데이터(operation 이름)가 ctx에 기록된 뒤 실행되도록 Initialize 단계 마지막에 둠
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("TracingStartSpan", startDynamoSpan), middleware.After)
+}
+
+func startDynamoSpan(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return next.HandleInitialize(ctx, in)
+	}
+
+	operation := awsmiddleware.GetOperationName(ctx)
+	attrs := []attribute.KeyValue{
+		attribute.String("rpc.system", "aws-api"),
+		attribute.String("rpc.service", "DynamoDB"),
+		attribute.String("rpc.method", operation),
+		attribute.String("db.system.name", "aws.dynamodb"),
+	}
+	if tables := tableNames(in.Parameters); len(tables) > 0 {
+		attrs = append(attrs, attribute.StringSlice("aws.dynamodb.table_names", tables))
+	}
+
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, "DynamoDB."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	out, metadata, err := next.HandleInitialize(ctx, in)
+	if requestID, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok {
+		span.SetAttributes(attribute.String("aws.request_id", requestID))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return out, metadata, err
+}
+
+// tableNames: 요청이 접근하는 테이블 이름 (트랜잭션, 배치 요청은 항목마다 모아 중복 제거)
+func tableNames(params any) []string {
+	var tables []string
+	add := func(name *string) {
+		if name != nil && *name != "" && !slices.Contains(tables, *name) {
+			tables = append(tables, *name)
+		}
+	}
+
+	switch in := params.(type) {
+	case *dynamodb.GetItemInput:
+		add(in.TableName)
+	case *dynamodb.PutItemInput:
+		add(in.TableName)
+	case *dynamodb.UpdateItemInput:
+		add(in.TableName)
+	case *dynamodb.DeleteItemInput:
+		add(in.TableName)
+	case *dynamodb.QueryInput:
+		add(in.TableName)
+	case *dynamodb.ScanInput:
+		add(in.TableName)
+	case *dynamodb.DescribeTableInput:
+		add(in.TableName)
+	case *dynamodb.TransactWriteItemsInput:
+		for _, item := range in.TransactItems {
+			switch {
+			case item.Put != nil:
+				add(item.Put.TableName)
+			case item.Update != nil:
+				add(item.Update.TableName)
+			case item.Delete != nil:
+				add(item.Delete.TableName)
+			case item.ConditionCheck != nil:
+				add(item.ConditionCheck.TableName)
+			}
+		}
+	case *dynamodb.TransactGetItemsInput:
+		for _, item := range in.TransactItems {
+			if item.Get != nil {
+				add(item.Get.TableName)
+			}
+		}
+	case *dynamodb.BatchGetItemInput:
+		for name := range in.RequestItems {
+			add(&name)
+		}
+	case *dynamodb.BatchWriteItemInput:
+		for name := range in.RequestItems {
+			add(&name)
+		}
+	}
+	slices.Sort(tables)
+	return tables
+}
@@ -0,0 +1,113 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	connect "connectrpc.com/connect"
+	"connectrpc.com/otelconnect"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/config"
+)
+
+// Config: trace 내보내기 설정
+type Config struct {
+	// trace에 기록할 서비스 이름 (OTEL_SERVICE_NAME이 있으면 그쪽을 따름)
+	ServiceName string
+	// config.TracingExporterNone, TracingExporterStdout, TracingExporterOTLP 중 하나
+	Exporter string
+	// OTLP/HTTP collector 주소 (예: http://otel-collector:4318)
+	OTLPEndpoint string
+	// 새로 시작하는 trace를 기록할 비율 (0~1)
+	SampleRatio float64
+}
+
+func ConfigFrom(cfg *config.Config, serviceName string) Config {
+	return Config{
+		ServiceName:  serviceName,
+		Exporter:     cfg.TracingExporter,
+		OTLPEndpoint: cfg.TracingOTLPEndpoint,
+		SampleRatio:  cfg.TracingSampleRatio,
+	}
+}
+
+// Setup: 전역 TracerProvider와 W3C trace context(traceparent, baggage) propagator 설정
+// 반환한 shutdown은 종료할 때 호출해 남은 span을 내보냄
+// Exporter가 none이면 span을 기록하지 않고 받은 trace context를 다음 호출로 전파만 함
+func Setup(ctx context.Context, cfg Config) (shutdown func(context.Context) error, err error) {
+	if cfg.ServiceName == "" {
+		return nil, errors.New("tracing 서비스 이름이 필요합니다")
+	}
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return nil, fmt.Errorf("tracing 샘플링 비율은 0~1 사이여야 합니다: %v", cfg.SampleRatio)
+	}
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var spanProcessor sdktrace.SpanProcessor
+	switch cfg.Exporter {
+	case config.TracingExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case config.TracingExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, fmt.Errorf("stdout trace exporter 생성 실패: %w", err)
+		}
+		// 로컬 확인용이므로 span이 끝나는 즉시 출력
+		spanProcessor = sdktrace.NewSimpleSpanProcessor(exporter)
+	case config.TracingExporterOTLP:
+		if cfg.OTLPEndpoint == "" {
+			return nil, errors.New("OTLP endpoint가 필요합니다")
+		}
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+		if err != nil {
+			return nil, fmt.Errorf("OTLP trace exporter 생성 실패: %w", err)
+		}
+		spanProcessor = sdktrace.NewBatchSpanProcessor(exporter)
+	default:
+		return nil, fmt.Errorf("지원하지 않는 trace exporter: %s", cfg.Exporter)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(cfg.ServiceName)),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+		// OTEL_SERVICE_NAME, OTEL_RESOURCE_ATTRIBUTES (파드 이름, 네임스페이스 등)
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("trace resource 생성 실패: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithSpanProcessor(spanProcessor),
+		// 상위 서비스에서 받은 trace는 그쪽의 샘플링 결정을 따르고, 새 trace만 비율로 샘플링
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// NewInterceptor: Connect 핸들러와 클라이언트에 span을 만들고 trace context를 헤더로 주고받는 interceptor
+// 서비스끼리 호출하는 내부 RPC이므로 받은 traceparent를 새 trace의 link가 아닌 부모 span으로 이어 받음
+// 지표는 Prometheus(metrics 패키지)로 따로 기록하므로 OpenTelemetry 지표는 끔
+func NewInterceptor() (connect.Interceptor, error) {
+	interceptor, err := otelconnect.NewInterceptor(otelconnect.WithTrustRemote(), otelconnect.WithoutMetrics())
+	if err != nil {
+		return nil, fmt.Errorf("tracing interceptor 생성 실패: %w", err)
+	}
+	return interceptor, nil
+}
@@ -0,0 +1,123 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"buf.build/go/protovalidate"
+	connect "connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/rpcerr"
+)
+
+// NewInterceptor: 받은 요청 메시지를 proto에 선언한 buf.validate 규칙으로 검사하는 interceptor
+// 규칙을 어기면 핸들러를 호출하지 않고 InvalidArgument와 필드별 위반 내용(google.rpc.BadRequest)을 반환
+// (형식, 길이, 개수처럼 요청만 보고 판단할 수 있는 검사만 proto에 두고 상태에 따른 검사는 store에서 함)
+func NewInterceptor() (connect.Interceptor, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, fmt.Errorf("validation interceptor 생성 실패: %w", err)
+	}
+	return &interceptor{validator: validator}, nil
+}
+
+type interceptor struct {
+	validator protovalidate.Validator
+}
+
+func (i *interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := i.validate(req.Any()); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		return next(ctx, &validatingConn{StreamingHandlerConn: conn, interceptor: i})
+	}
+}
+
+// validatingConn: 스트림으로 받는 메시지도 하나씩 검사
+type validatingConn struct {
+	connect.StreamingHandlerConn
+	interceptor *interceptor
+}
+
+func (c *validatingConn) Receive(msg any) error {
+	if err := c.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+	return c.interceptor.validate(msg)
+}
+
+func (i *interceptor) validate(msg any) error {
+	message, ok := msg.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	err := i.validator.Validate(message)
+	if err == nil {
+		return nil
+	}
+	var validationErr *protovalidate.ValidationError
+	if !errors.As(err, &validationErr) {
+		// 규칙을 해석하지 못한 경우 (proto에 잘못 선언한 규칙) 요청 잘못이 아님
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("요청 검증 실패: %w", err))
+	}
+
+	violations := make([]rpcerr.FieldViolation, 0, len(validationErr.Violations))
+	for _, v := range validationErr.Violations {
+		violations = append(violations, rpcerr.FieldViolation{
+			Field:       protovalidate.FieldPathString(v.Proto.GetField()),
+			Description: describe(v),
+		})
+	}
+	return rpcerr.InvalidFields(violations)
+}
+
+// 규칙별 안내 문구 (%v 자리에는 proto에 선언한 규칙 값이 들어감)
+var ruleMessages = map[string]string{
+	"required":           "필수입니다",
+	"string.email":       "올바른 이메일 주소가 아닙니다",
+	"string.email_empty": "올바른 이메일 주소가 아닙니다",
+	"string.min_len":     "%v자 이상이어야 합니다",
+	"string.max_len":     "%v자 이하여야 합니다",
+	"string.max_bytes":   "%vbyte 이하여야 합니다",
+	"int32.gte":          "%v 이상이어야 합니다",
+	"repeated.min_items": "%v개 이상이어야 합니다",
+	"repeated.max_items": "%v개 이하여야 합니다",
+	"enum.defined_only":  "정의되지 않은 값입니다",
+	"field_mask.in":      "변경할 수 없는 필드가 포함되어 있습니다",
+}
+
+// describe: 위반한 규칙의 안내 문구 (모르는 규칙이면 protovalidate의 영문 메시지를 그대로 씀)
+func describe(v *protovalidate.Violation) string {
+	ruleID := v.Proto.GetRuleId()
+
+	// 범위 규칙은 하한만 RuleValue로 넘어오므로 선언한 규칙에서 상한을 함께 읽음
+	if ruleID == "int32.gte_lte" && v.FieldDescriptor != nil {
+		rules, _ := proto.GetExtension(v.FieldDescriptor.Options(), validate.E_Field).(*validate.FieldRules)
+		return fmt.Sprintf("%d 이상 %d 이하여야 합니다", rules.GetInt32().GetGte(), rules.GetInt32().GetLte())
+	}
+
+	format, ok := ruleMessages[ruleID]
+	if !ok {
+		return v.Proto.GetMessage()
+	}
+	if strings.Contains(format, "%v") && v.RuleValue.IsValid() {
+		return fmt.Sprintf(format, v.RuleValue.Interface())
+	}
+	return format
+}
@@ -0,0 +1,165 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	connect "connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	orderpb "Acho-mj/2025_Golang_MSA/backend/gen/order"
+	userpb "Acho-mj/2025_Golang_MSA/backend/gen/user"
+)
+
+// violation: BadRequest의 필드 위반 하나 (메시지 단위 규칙은 Field가 비어 있음)
+type violation struct {
+	Field       string
+	Description string
+}
+
+// callUnary: interceptor를 거쳐 req로 핸들러를 호출하고, 핸들러가 호출되었는지와 에러를 반환
+func callUnary(t *testing.T, req connect.AnyRequest) (bool, error) {
+	t.Helper()
+	interceptor, err := NewInterceptor()
+	if err != nil {
+		t.Fatalf("NewInterceptor: %v", err)
+	}
+
+	called := false
+	next := interceptor.WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		called = true
+		return nil, nil
+	})
+	_, err = next(context.Background(), req)
+	return called, err
+}
+
+// badRequest: InvalidArgument 에러의 BadRequest 필드 위반 목록
+func badRequest(t *testing.T, err error) []violation {
+	t.Helper()
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeInvalidArgument {
+		t.Fatalf("error = %v, want InvalidArgument", err)
+	}
+
+	var violations []violation
+	for _, detail := range connectErr.Details() {
+		value, err := detail.Value()
+		if err != nil {
+			t.Fatalf("detail.Value: %v", err)
+		}
+		if br, ok := value.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				violations = append(violations, violation{Field: v.GetField(), Description: v.GetDescription()})
+			}
+		}
+	}
+	return violations
+}
+
+func TestInterceptorRejectsInvalidRequest(t *testing.T) {
+	validItem := &orderpb.OrderItem{ProductId: "prod_1", Quantity: 1}
+
+	tests := []struct {
+		name string
+		req  connect.AnyRequest
+		want []violation
+	}{
+		{
+			name: "필수 필드 없음",
+			req:  connect.NewRequest(&orderpb.CreateOrderRequest{Items: []*orderpb.OrderItem{validItem}}),
+			want: []violation{{Field: "user_id", Description: "필수입니다"}},
+		},
+		{
+			// 범위 규칙은 선언한 상한까지 함께 안내
+			name: "수량 범위 밖",
+			req: connect.NewRequest(&orderpb.CreateOrderRequest{UserId: "user_1", Items: []*orderpb.OrderItem{
+				validItem,
+				{ProductId: "prod_2", Quantity: 1001},
+			}}),
+			want: []violation{{Field: "items[1].quantity", Description: "1 이상 1000 이하여야 합니다"}},
+		},
+		{
+			name: "상품 없음",
+			req:  connect.NewRequest(&orderpb.CreateOrderRequest{UserId: "user_1"}),
+			want: []violation{{Field: "items", Description: "1개 이상이어야 합니다"}},
+		},
+		{
+			name: "정의되지 않은 enum",
+			req:  connect.NewRequest(&orderpb.TransitionOrderRequest{OrderId: "order_1", Status: orderpb.OrderStatus(99)}),
+			want: []violation{{Field: "status", Description: "정의되지 않은 값입니다"}},
+		},
+		{
+			name: "여러 필드 위반",
+			req:  connect.NewRequest(&userpb.CreateUserRequest{Email: "not-an-email"}),
+			want: []violation{
+				{Field: "email", Description: "올바른 이메일 주소가 아닙니다"},
+				{Field: "name", Description: "필수입니다"},
+			},
+		},
+		{
+			name: "변경할 수 없는 필드 지정",
+			req:  connect.NewRequest(&userpb.UpdateUserRequest{UserId: "user_1", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"status"}}}),
+			want: []violation{{Field: "update_mask", Description: "변경할 수 없는 필드가 포함되어 있습니다"}},
+		},
+		{
+			// 메시지 단위 규칙은 필드 경로 없이 proto에 선언한 문구를 씀
+			name: "update_mask에 지정한 필드를 비움",
+			req:  connect.NewRequest(&userpb.UpdateUserRequest{UserId: "user_1", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}}}),
+			want: []violation{{Description: "update_mask에 name을 지정하면 name은 비어 있을 수 없습니다"}},
+		},
+		{
+			name: "음수 page_size",
+			req:  connect.NewRequest(&orderpb.ListOrdersRequest{UserId: "user_1", PageSize: -1}),
+			want: []violation{{Field: "page_size", Description: "0 이상이어야 합니다"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called, err := callUnary(t, tt.req)
+			if called {
+				t.Error("규칙을 어긴 요청으로 핸들러가 호출됨")
+			}
+			got := badRequest(t, err)
+			if len(got) != len(tt.want) {
+				t.Fatalf("위반 = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("위반[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestInterceptorPassesValidRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  connect.AnyRequest
+	}{
+		{
+			name: "주문 생성",
+			req:  connect.NewRequest(&orderpb.CreateOrderRequest{UserId: "user_1", Items: []*orderpb.OrderItem{{ProductId: "prod_1", Quantity: 1000}}}),
+		},
+		{
+			// update_mask에 지정하지 않은 필드는 비어 있어도 됨
+			name: "이름만 수정",
+			req:  connect.NewRequest(&userpb.UpdateUserRequest{UserId: "user_1", Name: "홍길동", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}}}),
+		},
+		{
+			name: "규칙 없는 요청",
+			req:  connect.NewRequest(&userpb.ListUsersRequest{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called, err := callUnary(t, tt.req)
+			if err != nil || !called {
+				t.Errorf("검증 = %v, 핸들러 호출 %t, want 통과", err, called)
+			}
+		})
+	}
+}
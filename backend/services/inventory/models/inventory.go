@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	inventorypb "Acho-mj/2025_Golang_MSA/backend/gen/inventory"
+)
+
+// ReservationStatus: DB에 저장되는 예약 상태 값
+type ReservationStatus string
+
+const (
+	ReservationStatusReserved  ReservationStatus = "reserved"
+	ReservationStatusCommitted ReservationStatus = "committed"
+	ReservationStatusReleased  ReservationStatus = "released"
+)
+
+var reservationStatusToProto = map[ReservationStatus]inventorypb.ReservationStatus{
+	ReservationStatusReserved:  inventorypb.ReservationStatus_RESERVATION_STATUS_RESERVED,
+	ReservationStatusCommitted: inventorypb.ReservationStatus_RESERVATION_STATUS_COMMITTED,
+	ReservationStatusReleased:  inventorypb.ReservationStatus_RESERVATION_STATUS_RELEASED,
+}
+
+// ToProto: 알 수 없는 값은 UNSPECIFIED
+func (s ReservationStatus) ToProto() inventorypb.ReservationStatus {
+	return reservationStatusToProto[s]
+}
+
+type Stock struct {
+	ProductID string
+	Quantity  int64
+	Reserved  int64
+	UpdatedAt time.Time
+}
+
+func (s *Stock) ToProto() *inventorypb.Stock {
+	if s == nil {
+		return nil
+	}
+	stock := &inventorypb.Stock{
+		ProductId: s.ProductID,
+		Quantity:  s.Quantity,
+		Reserved:  s.Reserved,
+	}
+	// 재고가 한 번도 등록되지 않은 상품은 updated_at이 비어 있음
+	if !s.UpdatedAt.IsZero() {
+		stock.UpdatedAt = s.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	return stock
+}
+
+type ReservationLine struct {
+	ProductID string
+	Quantity  int32
+}
+
+type Reservation struct {
+	ReservationID string
+	Lines         []ReservationLine
+	Status        ReservationStatus
+	CreatedAt     time.Time
+}
+
+func (r *Reservation) ToProto() *inventorypb.Reservation {
+	if r == nil {
+		return nil
+	}
+
+	lines := make([]*inventorypb.ReservationLine, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		lines = append(lines, &inventorypb.ReservationLine{
+			ProductId: line.ProductID,
+			Quantity:  line.Quantity,
+		})
+	}
+
+	return &inventorypb.Reservation{
+		ReservationId: r.ReservationID,
+		Lines:         lines,
+		Status:        r.Status.ToProto(),
+		CreatedAt:     r.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,120 @@
+package rpchandler
+
+import (
+	"context"
+	"fmt"
+
+	connect "connectrpc.com/connect"
+
+	inventorypb "Acho-mj/2025_Golang_MSA/backend/gen/inventory"
+	inventoryconnect "Acho-mj/2025_Golang_MSA/backend/gen/inventory/inventoryconnect"
+	"Acho-mj/2025_Golang_MSA/backend/internal/rpcerr"
+	"Acho-mj/2025_Golang_MSA/backend/services/inventory/models"
+	"Acho-mj/2025_Golang_MSA/backend/services/inventory/store"
+)
+
+type InventoryHandler struct {
+	service *store.InventoryService
+}
+
+func NewInventoryHandler(service *store.InventoryService) *InventoryHandler {
+	return &InventoryHandler{service: service}
+}
+
+func (h *InventoryHandler) GetStock(ctx context.Context, req *connect.Request[inventorypb.GetStockRequest]) (*connect.Response[inventorypb.GetStockResponse], error) {
+	productID := req.Msg.GetProductId()
+	if productID == "" {
+		return nil, rpcerr.InvalidField("product_id", "product_id는 필수입니다")
+	}
+
+	stock, err := h.service.GetStock(ctx, productID)
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	resp := connect.NewResponse(&inventorypb.GetStockResponse{
+		Stock: stock.ToProto(),
+	})
+	return resp, nil
+}
+
+func (h *InventoryHandler) AdjustStock(ctx context.Context, req *connect.Request[inventorypb.AdjustStockRequest]) (*connect.Response[inventorypb.AdjustStockResponse], error) {
+	productID := req.Msg.GetProductId()
+	if productID == "" {
+		return nil, rpcerr.InvalidField("product_id", "product_id는 필수입니다")
+	}
+
+	stock, err := h.service.AdjustStock(ctx, productID, req.Msg.GetDelta())
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	resp := connect.NewResponse(&inventorypb.AdjustStockResponse{
+		Stock: stock.ToProto(),
+	})
+	return resp, nil
+}
+
+func (h *InventoryHandler) Reserve(ctx context.Context, req *connect.Request[inventorypb.ReserveRequest]) (*connect.Response[inventorypb.ReserveResponse], error) {
+	reservationID := req.Msg.GetReservationId()
+	if reservationID == "" {
+		return nil, rpcerr.InvalidField("reservation_id", "reservation_id는 필수입니다")
+	}
+
+	lines := make([]models.ReservationLine, 0, len(req.Msg.GetLines()))
+	for i, line := range req.Msg.GetLines() {
+		if line == nil {
+			return nil, rpcerr.InvalidField(fmt.Sprintf("lines[%d]", i), "상품 정보가 올바르지 않습니다")
+		}
+		lines = append(lines, models.ReservationLine{
+			ProductID: line.GetProductId(),
+			Quantity:  line.GetQuantity(),
+		})
+	}
+
+	reservation, err := h.service.Reserve(ctx, reservationID, lines)
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	resp := connect.NewResponse(&inventorypb.ReserveResponse{
+		Reservation: reservation.ToProto(),
+	})
+	return resp, nil
+}
+
+func (h *InventoryHandler) Commit(ctx context.Context, req *connect.Request[inventorypb.CommitRequest]) (*connect.Response[inventorypb.CommitResponse], error) {
+	reservationID := req.Msg.GetReservationId()
+	if reservationID == "" {
+		return nil, rpcerr.InvalidField("reservation_id", "reservation_id는 필수입니다")
+	}
+
+	reservation, err := h.service.Commit(ctx, reservationID)
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	resp := connect.NewResponse(&inventorypb.CommitResponse{
+		Reservation: reservation.ToProto(),
+	})
+	return resp, nil
+}
+
+func (h *InventoryHandler) Release(ctx context.Context, req *connect.Request[inventorypb.ReleaseRequest]) (*connect.Response[inventorypb.ReleaseResponse], error) {
+	reservationID := req.Msg.GetReservationId()
+	if reservationID == "" {
+		return nil, rpcerr.InvalidField("reservation_id", "reservation_id는 필수입니다")
+	}
+
+	reservation, err := h.service.Release(ctx, reservationID)
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	resp := connect.NewResponse(&inventorypb.ReleaseResponse{
+		Reservation: reservation.ToProto(),
+	})
+	return resp, nil
+}
+
+var _ inventoryconnect.InventoryServiceHandler = (*InventoryHandler)(nil)
@@ -0,0 +1,198 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/apperr"
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+	"Acho-mj/2025_Golang_MSA/backend/services/inventory/models"
+)
+
+var (
+	ErrInvalidInput             = apperr.New(apperr.ErrInvalidInput, "잘못된 입력입니다")
+	ErrInsufficientStock        = apperr.New(apperr.ErrFailedPrecondition, "재고가 부족합니다")
+	ErrReservationNotFound      = apperr.New(apperr.ErrNotFound, "재고 예약을 찾을 수 없습니다")
+	ErrReservationAlreadyExists = apperr.New(apperr.ErrAlreadyExists, "이미 존재하는 재고 예약입니다")
+	// 취소된 예약을 확정하려는 경우
+	ErrReservationReleased = apperr.New(apperr.ErrFailedPrecondition, "이미 취소된 재고 예약입니다")
+	ErrReservationConflict = apperr.New(apperr.ErrConflict, "재고 예약이 동시에 변경되었습니다")
+)
+
+type InventoryService struct {
+	storage storage.InventoryRepository
+}
+
+func NewInventoryService(storage storage.InventoryRepository) *InventoryService {
+	return &InventoryService{storage: storage}
+}
+
+func (s *InventoryService) GetStock(ctx context.Context, productID string) (*models.Stock, error) {
+	if productID == "" {
+		return nil, invalidInput("product_id", "productID는 필수입니다")
+	}
+
+	record, err := s.storage.GetStock(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return stockFromRecord(record), nil
+}
+
+func (s *InventoryService) AdjustStock(ctx context.Context, productID string, delta int64) (*models.Stock, error) {
+	if productID == "" {
+		return nil, invalidInput("product_id", "productID는 필수입니다")
+	}
+	if delta == 0 {
+		return nil, invalidInput("delta", "delta는 0이 아니어야 합니다")
+	}
+
+	record, err := s.storage.AdjustStock(ctx, productID, delta)
+	if err != nil {
+		if errors.Is(err, storage.ErrInsufficientStock) {
+			return nil, apperr.WithField("delta", fmt.Errorf("%w: %s", ErrInsufficientStock, productID))
+		}
+		return nil, err
+	}
+
+	return stockFromRecord(record), nil
+}
+
+// Reserve: 모든 상품의 재고를 한 번에 차감해 예약 (하나라도 부족하면 아무것도 차감하지 않음)
+func (s *InventoryService) Reserve(ctx context.Context, reservationID string, lines []models.ReservationLine) (*models.Reservation, error) {
+	if reservationID == "" {
+		return nil, invalidInput("reservation_id", "reservationID는 필수입니다")
+	}
+	if len(lines) == 0 {
+		return nil, invalidInput("lines", "최소 한 개의 상품이 필요합니다")
+	}
+	if len(lines) > storage.MaxReservationLines {
+		return nil, invalidInput("lines", fmt.Sprintf("한 번에 최대 %d개 상품까지 예약할 수 있습니다", storage.MaxReservationLines))
+	}
+
+	recordLines := make([]storage.ReservationLine, 0, len(lines))
+	seen := make(map[string]struct{}, len(lines))
+	for i, line := range lines {
+		if line.ProductID == "" || line.Quantity <= 0 {
+			return nil, invalidInput(fmt.Sprintf("lines[%d]", i), "상품 ID와 수량은 필수입니다")
+		}
+		if _, ok := seen[line.ProductID]; ok {
+			return nil, invalidInput(fmt.Sprintf("lines[%d].product_id", i), fmt.Sprintf("중복된 상품입니다: %s", line.ProductID))
+		}
+		seen[line.ProductID] = struct{}{}
+		recordLines = append(recordLines, storage.ReservationLine{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+		})
+	}
+
+	record := &storage.ReservationRecord{
+		ReservationID: reservationID,
+		Lines:         recordLines,
+	}
+	if err := s.storage.Reserve(ctx, record); err != nil {
+		var shortage *storage.StockShortageError
+		switch {
+		case errors.As(err, &shortage):
+			return nil, apperr.WithField(fmt.Sprintf("lines[%d].quantity", shortage.LineIndex),
+				fmt.Errorf("%w: %s", ErrInsufficientStock, shortage.ProductID))
+		case errors.Is(err, storage.ErrReservationAlreadyExists):
+			return nil, apperr.WithField("reservation_id", ErrReservationAlreadyExists)
+		}
+		return nil, err
+	}
+
+	return reservationFromRecord(record), nil
+}
+
+// Commit: 예약을 확정 (이미 확정된 예약이면 그대로 반환)
+func (s *InventoryService) Commit(ctx context.Context, reservationID string) (*models.Reservation, error) {
+	current, err := s.getReservation(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch current.Status {
+	case storage.ReservationStatusCommitted:
+		return reservationFromRecord(current), nil
+	case storage.ReservationStatusReleased:
+		return nil, apperr.WithField("reservation_id", ErrReservationReleased)
+	}
+
+	return s.transition(ctx, current, storage.ReservationStatusCommitted)
+}
+
+// Release: 예약(또는 확정된 예약)을 취소하고 재고를 되돌림 (이미 취소된 예약이면 그대로 반환)
+func (s *InventoryService) Release(ctx context.Context, reservationID string) (*models.Reservation, error) {
+	current, err := s.getReservation(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.Status == storage.ReservationStatusReleased {
+		return reservationFromRecord(current), nil
+	}
+
+	return s.transition(ctx, current, storage.ReservationStatusReleased)
+}
+
+func (s *InventoryService) getReservation(ctx context.Context, reservationID string) (*storage.ReservationRecord, error) {
+	if reservationID == "" {
+		return nil, invalidInput("reservation_id", "reservationID는 필수입니다")
+	}
+
+	record, err := s.storage.GetReservation(ctx, reservationID)
+	if err != nil {
+		if errors.Is(err, storage.ErrReservationNotFound) {
+			return nil, apperr.WithField("reservation_id", ErrReservationNotFound)
+		}
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (s *InventoryService) transition(ctx context.Context, current *storage.ReservationRecord, to string) (*models.Reservation, error) {
+	record, err := s.storage.TransitionReservation(ctx, current, to)
+	if err != nil {
+		if errors.Is(err, storage.ErrReservationConflict) {
+			return nil, ErrReservationConflict
+		}
+		return nil, err
+	}
+
+	return reservationFromRecord(record), nil
+}
+
+func stockFromRecord(record *storage.StockRecord) *models.Stock {
+	return &models.Stock{
+		ProductID: record.ProductID,
+		Quantity:  record.Quantity,
+		Reserved:  record.Reserved,
+		UpdatedAt: record.UpdatedAt,
+	}
+}
+
+func reservationFromRecord(record *storage.ReservationRecord) *models.Reservation {
+	lines := make([]models.ReservationLine, 0, len(record.Lines))
+	for _, line := range record.Lines {
+		lines = append(lines, models.ReservationLine{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+		})
+	}
+
+	return &models.Reservation{
+		ReservationID: record.ReservationID,
+		Lines:         lines,
+		Status:        models.ReservationStatus(record.Status),
+		CreatedAt:     record.CreatedAt,
+	}
+}
+
+// invalidInput: 원인이 된 요청 필드를 함께 기록한 입력 에러
+func invalidInput(field, msg string) error {
+	return apperr.WithField(field, fmt.Errorf("%w: %s", ErrInvalidInput, msg))
+}
@@ -0,0 +1,182 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/apperr"
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+	"Acho-mj/2025_Golang_MSA/backend/services/inventory/models"
+)
+
+// newTestInventory: 메모리 저장소에 상품별 가용 재고를 등록한 InventoryService
+func newTestInventory(t *testing.T, stocks map[string]int64) *InventoryService {
+	t.Helper()
+	service := NewInventoryService(storage.NewMemoryInventoryStorage())
+	for productID, quantity := range stocks {
+		if _, err := service.AdjustStock(context.Background(), productID, quantity); err != nil {
+			t.Fatalf("AdjustStock(%s): %v", productID, err)
+		}
+	}
+	return service
+}
+
+// assertStock: 상품의 가용 재고와 예약된 재고를 확인
+func assertStock(t *testing.T, service *InventoryService, productID string, wantQuantity, wantReserved int64) {
+	t.Helper()
+	stock, err := service.GetStock(context.Background(), productID)
+	if err != nil {
+		t.Fatalf("GetStock(%s): %v", productID, err)
+	}
+	if stock.Quantity != wantQuantity || stock.Reserved != wantReserved {
+		t.Errorf("%s 재고 = 가용 %d 예약 %d, want 가용 %d 예약 %d", productID, stock.Quantity, stock.Reserved, wantQuantity, wantReserved)
+	}
+}
+
+func TestInventoryReserve(t *testing.T) {
+	ctx := context.Background()
+	service := newTestInventory(t, map[string]int64{"prod_a": 5, "prod_b": 1})
+
+	reservation, err := service.Reserve(ctx, "res_1", []models.ReservationLine{
+		{ProductID: "prod_a", Quantity: 3},
+		{ProductID: "prod_b", Quantity: 1},
+	})
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	if reservation.Status != models.ReservationStatusReserved {
+		t.Errorf("예약 상태 = %s, want reserved", reservation.Status)
+	}
+	assertStock(t, service, "prod_a", 2, 3)
+	assertStock(t, service, "prod_b", 0, 1)
+
+	// 하나라도 부족하면 아무것도 차감하지 않고 부족한 줄을 알려 줌
+	_, err = service.Reserve(ctx, "res_2", []models.ReservationLine{
+		{ProductID: "prod_a", Quantity: 1},
+		{ProductID: "prod_b", Quantity: 1},
+	})
+	if !errors.Is(err, ErrInsufficientStock) {
+		t.Fatalf("재고 부족 Reserve error = %v, want ErrInsufficientStock", err)
+	}
+	if field, _ := apperr.Field(err); field != "lines[1].quantity" {
+		t.Errorf("재고 부족 필드 = %q, want lines[1].quantity", field)
+	}
+	assertStock(t, service, "prod_a", 2, 3)
+	assertStock(t, service, "prod_b", 0, 1)
+
+	// 같은 예약 ID로 다시 예약하면 재고를 두 번 차감하지 않음
+	_, err = service.Reserve(ctx, "res_1", []models.ReservationLine{{ProductID: "prod_a", Quantity: 1}})
+	if !errors.Is(err, ErrReservationAlreadyExists) {
+		t.Fatalf("중복 Reserve error = %v, want ErrReservationAlreadyExists", err)
+	}
+	assertStock(t, service, "prod_a", 2, 3)
+}
+
+func TestInventoryReservationTransitions(t *testing.T) {
+	tests := []struct {
+		name string
+		// 예약 후 차례로 실행하는 전환 ("commit", "release")
+		steps []string
+		// 마지막 전환의 에러
+		wantErr      error
+		wantStatus   models.ReservationStatus
+		wantQuantity int64
+		wantReserved int64
+	}{
+		{
+			// 확정하면 예약된 재고만 줄어듦
+			name:         "예약 확정",
+			steps:        []string{"commit"},
+			wantStatus:   models.ReservationStatusCommitted,
+			wantQuantity: 7,
+		},
+		{
+			name:         "예약 취소는 가용 재고로 되돌림",
+			steps:        []string{"release"},
+			wantStatus:   models.ReservationStatusReleased,
+			wantQuantity: 10,
+		},
+		{
+			name:         "확정된 예약 취소는 가용 재고로 되돌림",
+			steps:        []string{"commit", "release"},
+			wantStatus:   models.ReservationStatusReleased,
+			wantQuantity: 10,
+		},
+		{
+			name:         "확정을 다시 요청해도 재고를 바꾸지 않음",
+			steps:        []string{"commit", "commit"},
+			wantStatus:   models.ReservationStatusCommitted,
+			wantQuantity: 7,
+		},
+		{
+			name:         "취소를 다시 요청해도 재고를 두 번 되돌리지 않음",
+			steps:        []string{"release", "release"},
+			wantStatus:   models.ReservationStatusReleased,
+			wantQuantity: 10,
+		},
+		{
+			name:         "취소된 예약은 확정할 수 없음",
+			steps:        []string{"release", "commit"},
+			wantErr:      ErrReservationReleased,
+			wantStatus:   models.ReservationStatusReleased,
+			wantQuantity: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			service := newTestInventory(t, map[string]int64{"prod_a": 10})
+			if _, err := service.Reserve(ctx, "res_1", []models.ReservationLine{{ProductID: "prod_a", Quantity: 3}}); err != nil {
+				t.Fatalf("Reserve: %v", err)
+			}
+
+			var err error
+			for _, step := range tt.steps {
+				switch step {
+				case "commit":
+					_, err = service.Commit(ctx, "res_1")
+				case "release":
+					_, err = service.Release(ctx, "res_1")
+				}
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("%s error = %v, want %v", tt.steps[len(tt.steps)-1], err, tt.wantErr)
+			}
+
+			record, err := service.storage.GetReservation(ctx, "res_1")
+			if err != nil {
+				t.Fatalf("GetReservation: %v", err)
+			}
+			if models.ReservationStatus(record.Status) != tt.wantStatus {
+				t.Errorf("예약 상태 = %s, want %s", record.Status, tt.wantStatus)
+			}
+			assertStock(t, service, "prod_a", tt.wantQuantity, tt.wantReserved)
+		})
+	}
+}
+
+func TestInventoryTransitionConflict(t *testing.T) {
+	ctx := context.Background()
+	service := newTestInventory(t, map[string]int64{"prod_a": 10})
+	if _, err := service.Reserve(ctx, "res_1", []models.ReservationLine{{ProductID: "prod_a", Quantity: 3}}); err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+
+	// 조회한 뒤 다른 요청이 먼저 확정한 경우
+	stale, err := service.storage.GetReservation(ctx, "res_1")
+	if err != nil {
+		t.Fatalf("GetReservation: %v", err)
+	}
+	if _, err := service.Commit(ctx, "res_1"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if _, err := service.transition(ctx, stale, storage.ReservationStatusReleased); !errors.Is(err, ErrReservationConflict) {
+		t.Fatalf("이전 상태로 전환 error = %v, want ErrReservationConflict", err)
+	}
+	assertStock(t, service, "prod_a", 7, 0)
+
+	if _, err := service.Commit(ctx, "res_unknown"); !errors.Is(err, ErrReservationNotFound) {
+		t.Errorf("없는 예약 Commit error = %v, want ErrReservationNotFound", err)
+	}
+}
@@ -0,0 +1,65 @@
+// backfill-created-at: created_at을 고정 길이 형식으로 저장하기 전에 만든 주문의 created_at을 다시 기록하는 일회성 작업
+// 끝날 때까지 이전 주문은 사용자별 주문 목록(GSI)에서 새 주문과 시간 순서대로 정렬되지 않음
+// order 서비스 이미지에 함께 들어 있으므로 같은 환경 변수로 실행함
+//
+//	kubectl exec deploy/order-service -- /app/backfill-created-at
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/config"
+	"Acho-mj/2025_Golang_MSA/backend/internal/logging"
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+)
+
+// 한 번에 Scan하는 주문 수
+const pageSize = 100
+
+func main() {
+	ctx := context.Background()
+
+	cfg, err := config.LoadConfig(config.TableOrder, config.TableOutbox)
+	if err != nil {
+		logging.Fatal("config load 실패", "error", err)
+	}
+	logging.Setup("order-backfill-created-at", cfg.LogLevel)
+	if cfg.StorageBackend != config.StorageBackendDynamoDB {
+		logging.Fatal("STORAGE_BACKEND=dynamodb에서만 실행할 수 있습니다", "storage_backend", cfg.StorageBackend)
+	}
+
+	dynamoClient, err := storage.NewDynamoClient(ctx, cfg)
+	if err != nil {
+		logging.Fatal("dynamodb 초기화 실패", "error", err)
+	}
+	orderStorage, err := storage.NewOrderStorage(dynamoClient, cfg.DynamoOrderTable, cfg.DynamoOutboxTable)
+	if err != nil {
+		logging.Fatal("order storage 초기화 실패", "error", err)
+	}
+
+	var (
+		startKey map[string]types.AttributeValue
+		updated  int
+		skipped  int
+	)
+	for {
+		page, err := orderStorage.BackfillOrderCreatedAt(ctx, startKey, pageSize)
+		if err != nil {
+			// 이미 바꾼 주문은 건너뛰므로 처음부터 다시 실행하면 됨
+			logging.Fatal("주문 created_at backfill 실패", "updated", updated, "error", err)
+		}
+		updated += page.Updated
+		skipped += page.Skipped
+		slog.Info("주문 created_at backfill 진행", "updated", updated, "skipped", skipped)
+
+		if page.NextKey == nil {
+			break
+		}
+		startKey = page.NextKey
+	}
+
+	slog.Info("주문 created_at backfill 완료", "updated", updated, "skipped", skipped)
+}
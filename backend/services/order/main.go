@@ -2,56 +2,222 @@ package main
 
 import (
 	"context"
-	"log"
-	"net/http"
+	"log/slog"
+	"os"
 
+	inventoryconnect "Acho-mj/2025_Golang_MSA/backend/gen/inventory/inventoryconnect"
 	orderconnect "Acho-mj/2025_Golang_MSA/backend/gen/order/orderconnect"
+	productconnect "Acho-mj/2025_Golang_MSA/backend/gen/product/productconnect"
 	userconnect "Acho-mj/2025_Golang_MSA/backend/gen/user/userconnect"
 
 	"Acho-mj/2025_Golang_MSA/backend/internal/config"
+	"Acho-mj/2025_Golang_MSA/backend/internal/health"
+	"Acho-mj/2025_Golang_MSA/backend/internal/idempotency"
+	"Acho-mj/2025_Golang_MSA/backend/internal/logging"
+	"Acho-mj/2025_Golang_MSA/backend/internal/metrics"
+	"Acho-mj/2025_Golang_MSA/backend/internal/outbox"
+	"Acho-mj/2025_Golang_MSA/backend/internal/pagination"
+	"Acho-mj/2025_Golang_MSA/backend/internal/rpcclient"
+	"Acho-mj/2025_Golang_MSA/backend/internal/saga"
+	"Acho-mj/2025_Golang_MSA/backend/internal/server"
 	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+	"Acho-mj/2025_Golang_MSA/backend/internal/tracing"
 	"Acho-mj/2025_Golang_MSA/backend/services/order/rpchandler"
 	"Acho-mj/2025_Golang_MSA/backend/services/order/store"
 )
 
+// user 서비스가 사용자 이벤트를 보내는 경로 (user 서비스의 EVENT_FANOUT_URLS)
+const userEventsPath = "/events/user"
+
 func main() {
 	ctx := context.Background()
 
-	cfg, err := config.LoadConfig()
+	cfg, err := config.LoadConfig(config.TableOrder, config.TableIdempotency, config.TableSaga, config.TableOutbox)
+	if err != nil {
+		logging.Fatal("config load 실패", "error", err)
+	}
+	// JSON 로그 (LOG_LEVEL 이상만 남김)
+	logging.Setup("order-service", cfg.LogLevel)
+
+	// HTTP 서버 (종료 신호를 받으면 진행 중인 요청과 백그라운드 작업을 마치고 종료)
+	srv, err := server.New("order service", server.OptionsFrom(cfg))
+	if err != nil {
+		logging.Fatal("server 초기화 실패", "error", err)
+	}
+
+	// OpenTelemetry trace (TRACING_EXPORTER), 종료할 때 남은 span을 내보냄
+	shutdownTracing, err := tracing.Setup(ctx, tracing.ConfigFrom(cfg, "order-service"))
 	if err != nil {
-		log.Fatalf("config load 실패: %v", err)
+		logging.Fatal("tracing 초기화 실패", "error", err)
+	}
+	srv.OnShutdown(shutdownTracing)
+
+	// lease 소유자 이름으로 파드 이름(hostname)을 사용
+	podName, err := os.Hostname()
+	if err != nil || podName == "" {
+		podName = "order-service"
 	}
 
-	dynamoClient, err := storage.NewDynamoClient(ctx, cfg)
+	// 주문마다 user 서비스를 호출하지 않도록 사용자 상태를 캐시
+	userCache, err := store.NewUserCache(cfg.UserCacheSize, cfg.UserCacheTTL, cfg.UserCacheNegativeTTL)
 	if err != nil {
-		log.Fatalf("dynamodb 초기화 실패: %v", err)
+		logging.Fatal("user cache 초기화 실패", "error", err)
+	}
+	if err := metrics.RegisterCache("user", userCache.Stats); err != nil {
+		logging.Fatal("user cache 지표 등록 실패", "error", err)
+	}
+
+	var orderStorage storage.OrderRepository
+	var idempotencyStorage storage.IdempotencyRepository
+	var outboxStorage storage.OutboxRepository
+	var sagaStorage storage.SagaRepository
+	switch cfg.StorageBackend {
+	case config.StorageBackendMemory:
+		memoryOutbox := storage.NewMemoryOutboxStorage()
+		orderStorage = storage.NewMemoryOrderStorage(memoryOutbox)
+		outboxStorage = memoryOutbox
+		idempotencyStorage = storage.NewMemoryIdempotencyStorage()
+		sagaStorage = storage.NewMemorySagaStorage()
+	default:
+		dynamoClient, err := storage.NewDynamoClient(ctx, cfg)
+		if err != nil {
+			logging.Fatal("dynamodb 초기화 실패", "error", err)
+		}
+
+		orderStorage, err = storage.NewOrderStorage(dynamoClient, cfg.DynamoOrderTable, cfg.DynamoOutboxTable)
+		if err != nil {
+			logging.Fatal("order storage 초기화 실패", "error", err)
+		}
+
+		idempotencyStorage, err = storage.NewIdempotencyStorage(dynamoClient, cfg.DynamoIdempotencyTable)
+		if err != nil {
+			logging.Fatal("idempotency storage 초기화 실패", "error", err)
+		}
+
+		outboxStorage, err = storage.NewOutboxStorage(dynamoClient, cfg.DynamoOutboxTable)
+		if err != nil {
+			logging.Fatal("outbox storage 초기화 실패", "error", err)
+		}
+
+		sagaStorage, err = storage.NewSagaStorage(dynamoClient, cfg.DynamoSagaTable)
+		if err != nil {
+			logging.Fatal("saga storage 초기화 실패", "error", err)
+		}
+
+		// readiness: 사용하는 테이블을 DescribeTable로 확인
+		for _, table := range []string{cfg.DynamoOrderTable, cfg.DynamoIdempotencyTable, cfg.DynamoOutboxTable, cfg.DynamoSagaTable} {
+			srv.Check("dynamodb:"+table, func(ctx context.Context) error {
+				return storage.PingTable(ctx, dynamoClient, table)
+			})
+		}
 	}
+	slog.Info("저장소 설정", "storage_backend", cfg.StorageBackend)
 
-	orderStorage, err := storage.NewOrderStorage(dynamoClient, cfg.DynamoOrderTable)
+	// 다른 서비스 호출: 서비스마다 circuit breaker를 따로 둠
+	rpcConfig := rpcclient.ConfigFrom(cfg)
+	userRPC, err := rpcclient.New("user-service", rpcConfig)
+	if err != nil {
+		logging.Fatal("user-service client 초기화 실패", "error", err)
+	}
+	productRPC, err := rpcclient.New("product-service", rpcConfig)
+	if err != nil {
+		logging.Fatal("product-service client 초기화 실패", "error", err)
+	}
+	inventoryRPC, err := rpcclient.New("inventory-service", rpcConfig)
 	if err != nil {
-		log.Fatalf("order storage 초기화 실패: %v", err)
+		logging.Fatal("inventory-service client 초기화 실패", "error", err)
 	}
 
 	userClient := userconnect.NewUserServiceClient(
-		http.DefaultClient,
+		userRPC.HTTPClient(),
 		cfg.UserServiceURL,
+		userRPC.Options()...,
 	)
+	productClient := productconnect.NewProductServiceClient(
+		productRPC.HTTPClient(),
+		cfg.ProductServiceURL,
+		productRPC.Options()...,
+	)
+	inventoryClient := inventoryconnect.NewInventoryServiceClient(
+		inventoryRPC.HTTPClient(),
+		cfg.InventoryServiceURL,
+		inventoryRPC.Options()...,
+	)
+
+	// readiness: 호출하는 서비스가 살아 있는지 /livez로 확인
+	srv.Check("user-service", health.HTTPCheck(userRPC.HTTPClient(), cfg.UserServiceURL+"/livez"))
+	srv.Check("product-service", health.HTTPCheck(productRPC.HTTPClient(), cfg.ProductServiceURL+"/livez"))
+	srv.Check("inventory-service", health.HTTPCheck(inventoryRPC.HTTPClient(), cfg.InventoryServiceURL+"/livez"))
+
+	// 주문 목록 page token 서명용 secret
+	pageTokenSecret := []byte(cfg.PageTokenSecret)
+	if len(pageTokenSecret) == 0 {
+		slog.Warn("PAGE_TOKEN_SECRET이 없어 임시 secret을 사용합니다 (파드 간 page token 호환 불가)")
+		pageTokenSecret, err = pagination.RandomSecret()
+		if err != nil {
+			logging.Fatal("page token secret 생성 실패", "error", err)
+		}
+	}
+	pageTokens, err := pagination.NewTokenCodec(pageTokenSecret)
+	if err != nil {
+		logging.Fatal("page token codec 초기화 실패", "error", err)
+	}
+
+	idempotencyGuard, err := idempotency.NewGuard(idempotencyStorage, idempotency.DefaultTTL, idempotency.DefaultLeaseTTL)
+	if err != nil {
+		logging.Fatal("idempotency guard 초기화 실패", "error", err)
+	}
+
+	sagas, err := saga.NewOrchestrator(sagaStorage, podName, saga.DefaultLeaseTTL)
+	if err != nil {
+		logging.Fatal("saga orchestrator 초기화 실패", "error", err)
+	}
+
+	// 결제 서비스가 아직 없어 주문 생성 saga의 결제 단계는 아무것도 하지 않음 (연동하면 PaymentGateway를 넘김)
+	orderService, err := store.NewOrderService(orderStorage, userClient, userCache, productClient, inventoryClient, nil, sagas, pageTokens, idempotencyGuard, cfg.OrderTaxRateBPS)
+	if err != nil {
+		logging.Fatal("order service 초기화 실패", "error", err)
+	}
+
+	// 이전 파드가 실행하다 중단된 saga를 이어서 실행
+	srv.Go(func(ctx context.Context) {
+		sagas.RunRecovery(ctx, saga.DefaultRecoveryInterval)
+	})
 
-	orderService := store.NewOrderService(orderStorage, userClient)
 	orderHandler := rpchandler.NewOrderHandler(orderService)
 
-	mux := http.NewServeMux()
-	path, handler := orderconnect.NewOrderServiceHandler(orderHandler)
-	mux.Handle(path, handler)
-	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
+	// outbox에 기록된 order 이벤트를 발행
+	publisher, err := outbox.NewPublisher(cfg)
+	if err != nil {
+		logging.Fatal("event publisher 초기화 실패", "error", err)
+	}
+	relay, err := outbox.NewRelay(outboxStorage, outbox.SourceOrder, publisher)
+	if err != nil {
+		logging.Fatal("outbox relay 초기화 실패", "error", err)
+	}
+	srv.Go(func(ctx context.Context) {
+		relay.Run(ctx, outbox.DefaultPollInterval)
+	})
+	slog.Info("이벤트 발행 설정", "event_publisher", cfg.EventPublisher)
+
+	// 주문 확정/취소와 같은 트랜잭션으로 기록한 재고 처리 작업으로 재고 예약을 확정/취소
+	// 이벤트 발행과 따로 실행하므로 재고 서비스 장애가 이벤트 발행을 막지 않고, 작업은 성공할 때까지 다시 시도함
+	stockWorker, err := outbox.NewWorker(outboxStorage, outbox.SourceOrderStock, orderService.SettleReservedStock)
+	if err != nil {
+		logging.Fatal("재고 처리 worker 초기화 실패", "error", err)
+	}
+	srv.Go(func(ctx context.Context) {
+		stockWorker.Run(ctx, outbox.DefaultPollInterval)
 	})
 
-	addr := ":" + cfg.Port
-	log.Printf("order service listening on %s", addr)
+	path, handler := orderconnect.NewOrderServiceHandler(orderHandler, srv.HandlerOptions()...)
+	srv.Handle(path, handler)
+
+	// user 서비스가 발행한 이벤트(EVENT_FANOUT_URLS)로 사용자가 변경/삭제되면 캐시 항목을 바로 지움
+	// 이벤트를 받지 못해도 캐시 항목은 USER_CACHE_TTL이 지나면 만료됨
+	srv.Handle(userEventsPath, outbox.NewWebhookHandler(store.InvalidateUserCache(userCache)))
 
-	if err := http.ListenAndServe(addr, mux); err != nil {
-		log.Fatalf("서버 종료: %v", err)
+	if err := srv.Run(); err != nil {
+		logging.Fatal("서버 종료", "error", err)
 	}
 }
@@ -9,14 +9,21 @@ import (
 type OrderItem struct {
 	ProductID string `dynamodbav:"product_id"`
 	Quantity  int32  `dynamodbav:"quantity"`
+	// 주문 생성 시점의 상품 가격 (최소 화폐 단위)
+	UnitPrice int64 `dynamodbav:"unit_price"`
+	LineTotal int64 `dynamodbav:"line_total"`
 }
 
 type Order struct {
 	OrderID   string      `dynamodbav:"order_id"`
 	UserID    string      `dynamodbav:"user_id"`
 	Items     []OrderItem `dynamodbav:"items"`
-	Status    string      `dynamodbav:"status"`
+	Status    OrderStatus `dynamodbav:"status"`
 	CreatedAt time.Time   `dynamodbav:"created_at"`
+	Currency  string      `dynamodbav:"currency"`
+	Subtotal  int64       `dynamodbav:"subtotal"`
+	Tax       int64       `dynamodbav:"tax"`
+	Total     int64       `dynamodbav:"total"`
 }
 
 func (o *Order) ToProto() *orderpb.Order {
@@ -29,6 +36,8 @@ func (o *Order) ToProto() *orderpb.Order {
 		items = append(items, &orderpb.OrderItem{
 			ProductId: item.ProductID,
 			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			LineTotal: item.LineTotal,
 		})
 	}
 
@@ -36,8 +45,12 @@ func (o *Order) ToProto() *orderpb.Order {
 		OrderId:   o.OrderID,
 		UserId:    o.UserID,
 		Items:     items,
-		Status:    o.Status,
 		CreatedAt: o.CreatedAt.UTC().Format(time.RFC3339),
+		Status:    o.Status.ToProto(),
+		Currency:  o.Currency,
+		Subtotal:  o.Subtotal,
+		Tax:       o.Tax,
+		Total:     o.Total,
 	}
 }
 
@@ -54,6 +67,8 @@ func OrderFromProto(p *orderpb.Order) *Order {
 		items = append(items, OrderItem{
 			ProductID: item.ProductId,
 			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			LineTotal: item.LineTotal,
 		})
 	}
 
@@ -62,11 +77,17 @@ func OrderFromProto(p *orderpb.Order) *Order {
 		createdAt = time.Time{}
 	}
 
+	status, _ := OrderStatusFromProto(p.Status)
+
 	return &Order{
 		OrderID:   p.OrderId,
 		UserID:    p.UserId,
 		Items:     items,
-		Status:    p.Status,
+		Status:    status,
 		CreatedAt: createdAt,
+		Currency:  p.Currency,
+		Subtotal:  p.Subtotal,
+		Tax:       p.Tax,
+		Total:     p.Total,
 	}
 }
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"math"
+)
+
+// 세율은 basis point(1/10000) 단위 (예: 1000 = 10%)
+const MaxTaxRateBPS = 10000
+
+var ErrInvalidAmount = errors.New("주문 금액이 음수이거나 표현 가능한 범위를 넘었습니다")
+
+// OrderPrice: 주문 금액 합계
+type OrderPrice struct {
+	Subtotal int64
+	Tax      int64
+	Total    int64
+}
+
+// PriceItems: 각 상품의 UnitPrice로 LineTotal을 채우고 주문 합계를 계산
+// 세금은 subtotal에 세율을 곱해 최소 화폐 단위에서 반올림(0.5 이상 올림)함
+func PriceItems(items []OrderItem, taxRateBPS int64) (OrderPrice, error) {
+	var subtotal int64
+	for i := range items {
+		lineTotal, ok := mul(items[i].UnitPrice, int64(items[i].Quantity))
+		if !ok {
+			return OrderPrice{}, ErrInvalidAmount
+		}
+		items[i].LineTotal = lineTotal
+
+		if subtotal, ok = add(subtotal, lineTotal); !ok {
+			return OrderPrice{}, ErrInvalidAmount
+		}
+	}
+
+	taxed, ok := mul(subtotal, taxRateBPS)
+	if !ok {
+		return OrderPrice{}, ErrInvalidAmount
+	}
+	tax := taxed / MaxTaxRateBPS
+	if taxed%MaxTaxRateBPS*2 >= MaxTaxRateBPS {
+		tax++
+	}
+
+	total, ok := add(subtotal, tax)
+	if !ok {
+		return OrderPrice{}, ErrInvalidAmount
+	}
+
+	return OrderPrice{
+		Subtotal: subtotal,
+		Tax:      tax,
+		Total:    total,
+	}, nil
+}
+
+// mul, add: 음수가 아닌 금액의 overflow를 확인하는 연산
+func mul(a, b int64) (int64, bool) {
+	if a < 0 || b < 0 {
+		return 0, false
+	}
+	if a != 0 && b > math.MaxInt64/a {
+		return 0, false
+	}
+	return a * b, true
+}
+
+func add(a, b int64) (int64, bool) {
+	if a < 0 || b < 0 || a > math.MaxInt64-b {
+		return 0, false
+	}
+	return a + b, true
+}
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPriceItems(t *testing.T) {
+	tests := []struct {
+		name       string
+		items      []OrderItem
+		taxRateBPS int64
+		wantLines  []int64
+		want       OrderPrice
+	}{
+		{
+			name:       "세금 없음",
+			items:      []OrderItem{{UnitPrice: 1000, Quantity: 2}, {UnitPrice: 350, Quantity: 1}},
+			taxRateBPS: 0,
+			wantLines:  []int64{2000, 350},
+			want:       OrderPrice{Subtotal: 2350, Tax: 0, Total: 2350},
+		},
+		{
+			name:       "나누어떨어지는 세금",
+			items:      []OrderItem{{UnitPrice: 1000, Quantity: 3}},
+			taxRateBPS: 1000,
+			wantLines:  []int64{3000},
+			want:       OrderPrice{Subtotal: 3000, Tax: 300, Total: 3300},
+		},
+		{
+			// 15 * 10% = 1.5 -> 2
+			name:       "0.5는 올림",
+			items:      []OrderItem{{UnitPrice: 15, Quantity: 1}},
+			taxRateBPS: 1000,
+			wantLines:  []int64{15},
+			want:       OrderPrice{Subtotal: 15, Tax: 2, Total: 17},
+		},
+		{
+			// 14 * 10% = 1.4 -> 1
+			name:       "0.5 미만은 버림",
+			items:      []OrderItem{{UnitPrice: 14, Quantity: 1}},
+			taxRateBPS: 1000,
+			wantLines:  []int64{14},
+			want:       OrderPrice{Subtotal: 14, Tax: 1, Total: 15},
+		},
+		{
+			// 1 * 0.01% = 0.0001 -> 0
+			name:       "아주 작은 세금",
+			items:      []OrderItem{{UnitPrice: 1, Quantity: 1}},
+			taxRateBPS: 1,
+			wantLines:  []int64{1},
+			want:       OrderPrice{Subtotal: 1, Tax: 0, Total: 1},
+		},
+		{
+			name:       "세율 100%",
+			items:      []OrderItem{{UnitPrice: 999, Quantity: 1}},
+			taxRateBPS: MaxTaxRateBPS,
+			wantLines:  []int64{999},
+			want:       OrderPrice{Subtotal: 999, Tax: 999, Total: 1998},
+		},
+		{
+			name:       "상품 없음",
+			items:      nil,
+			taxRateBPS: 1000,
+			want:       OrderPrice{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PriceItems(tt.items, tt.taxRateBPS)
+			if err != nil {
+				t.Fatalf("PriceItems: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("PriceItems = %+v, want %+v", got, tt.want)
+			}
+			for i, want := range tt.wantLines {
+				if tt.items[i].LineTotal != want {
+					t.Errorf("items[%d].LineTotal = %d, want %d", i, tt.items[i].LineTotal, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPriceItemsInvalidAmount(t *testing.T) {
+	tests := []struct {
+		name       string
+		items      []OrderItem
+		taxRateBPS int64
+	}{
+		{
+			name:  "음수 가격",
+			items: []OrderItem{{UnitPrice: -1, Quantity: 1}},
+		},
+		{
+			name:  "음수 수량",
+			items: []OrderItem{{UnitPrice: 100, Quantity: -1}},
+		},
+		{
+			name:  "항목 금액 overflow",
+			items: []OrderItem{{UnitPrice: math.MaxInt64 / 2, Quantity: 3}},
+		},
+		{
+			name:  "합계 overflow",
+			items: []OrderItem{{UnitPrice: math.MaxInt64, Quantity: 1}, {UnitPrice: 1, Quantity: 1}},
+		},
+		{
+			name:       "세금 계산 overflow",
+			items:      []OrderItem{{UnitPrice: math.MaxInt64 / 2, Quantity: 1}},
+			taxRateBPS: 1000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := PriceItems(tt.items, tt.taxRateBPS); !errors.Is(err, ErrInvalidAmount) {
+				t.Errorf("PriceItems error = %v, want ErrInvalidAmount", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	orderpb "Acho-mj/2025_Golang_MSA/backend/gen/order"
+)
+
+// OrderStatus: DB에 저장되는 주문 상태 값
+type OrderStatus string
+
+const (
+	OrderStatusPending   OrderStatus = "pending"
+	OrderStatusConfirmed OrderStatus = "confirmed"
+	OrderStatusShipped   OrderStatus = "shipped"
+	OrderStatusDelivered OrderStatus = "delivered"
+	OrderStatusCancelled OrderStatus = "cancelled"
+	OrderStatusRefunded  OrderStatus = "refunded"
+)
+
+// 상태별로 다음에 올 수 있는 상태
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:   {OrderStatusConfirmed, OrderStatusCancelled},
+	OrderStatusConfirmed: {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusShipped:   {OrderStatusDelivered},
+	OrderStatusDelivered: {OrderStatusRefunded},
+	OrderStatusCancelled: {},
+	OrderStatusRefunded:  {},
+}
+
+var orderStatusToProto = map[OrderStatus]orderpb.OrderStatus{
+	OrderStatusPending:   orderpb.OrderStatus_ORDER_STATUS_PENDING,
+	OrderStatusConfirmed: orderpb.OrderStatus_ORDER_STATUS_CONFIRMED,
+	OrderStatusShipped:   orderpb.OrderStatus_ORDER_STATUS_SHIPPED,
+	OrderStatusDelivered: orderpb.OrderStatus_ORDER_STATUS_DELIVERED,
+	OrderStatusCancelled: orderpb.OrderStatus_ORDER_STATUS_CANCELLED,
+	OrderStatusRefunded:  orderpb.OrderStatus_ORDER_STATUS_REFUNDED,
+}
+
+func (s OrderStatus) IsValid() bool {
+	_, ok := orderTransitions[s]
+	return ok
+}
+
+// CanTransitionTo: 현재 상태에서 next로 변경할 수 있는지 여부
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (s OrderStatus) ToProto() orderpb.OrderStatus {
+	return orderStatusToProto[s]
+}
+
+// OrderStatusFromProto: UNSPECIFIED나 알 수 없는 값이면 false를 반환
+func OrderStatusFromProto(p orderpb.OrderStatus) (OrderStatus, bool) {
+	for status, pb := range orderStatusToProto {
+		if pb == p {
+			return status, true
+		}
+	}
+	return "", false
+}
@@ -3,11 +3,15 @@ package rpchandler
 import (
 	"context"
 	"fmt"
+	"time"
 
 	connect "connectrpc.com/connect"
 
 	orderpb "Acho-mj/2025_Golang_MSA/backend/gen/order"
 	orderconnect "Acho-mj/2025_Golang_MSA/backend/gen/order/orderconnect"
+	"Acho-mj/2025_Golang_MSA/backend/internal/apperr"
+	"Acho-mj/2025_Golang_MSA/backend/internal/idempotency"
+	"Acho-mj/2025_Golang_MSA/backend/internal/rpcerr"
 	"Acho-mj/2025_Golang_MSA/backend/services/order/models"
 	"Acho-mj/2025_Golang_MSA/backend/services/order/store"
 )
@@ -21,30 +25,23 @@ func NewOrderHandler(service *store.OrderService) *OrderHandler {
 }
 
 func (h *OrderHandler) CreateOrder(ctx context.Context, req *connect.Request[orderpb.CreateOrderRequest]) (*connect.Response[orderpb.CreateOrderResponse], error) {
-	userID := req.Msg.GetUserId()
 	items := req.Msg.GetItems()
-
-	if userID == "" {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("user_id는 필수입니다"))
-	}
-	if len(items) == 0 {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("items는 최소 한 개 이상이어야 합니다"))
-	}
-
 	modelItems := make([]models.OrderItem, 0, len(items))
 	for _, item := range items {
-		if item == nil || item.ProductId == "" || item.Quantity <= 0 {
-			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("상품 정보가 올바르지 않습니다"))
-		}
 		modelItems = append(modelItems, models.OrderItem{
-			ProductID: item.ProductId,
-			Quantity:  item.Quantity,
+			ProductID: item.GetProductId(),
+			Quantity:  item.GetQuantity(),
 		})
 	}
 
-	order, err := h.service.CreateOrder(ctx, userID, modelItems)
+	idempotencyKey, err := idempotency.KeyFrom(req.Header(), req.Msg.GetIdempotencyKey())
+	if err != nil {
+		return nil, rpcerr.ToConnect(apperr.WithField("idempotency_key", err))
+	}
+
+	order, err := h.service.CreateOrder(ctx, req.Msg.GetUserId(), modelItems, idempotencyKey)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, rpcerr.ToConnect(err)
 	}
 
 	resp := connect.NewResponse(&orderpb.CreateOrderResponse{
@@ -54,20 +51,96 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, req *connect.Request[ord
 }
 
 func (h *OrderHandler) GetOrder(ctx context.Context, req *connect.Request[orderpb.GetOrderRequest]) (*connect.Response[orderpb.GetOrderResponse], error) {
-	orderID := req.Msg.GetOrderId()
-	if orderID == "" {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("order_id는 필수입니다"))
+	order, err := h.service.GetOrder(ctx, req.Msg.GetOrderId())
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
 	}
 
-	order, err := h.service.GetOrder(ctx, orderID)
+	resp := connect.NewResponse(&orderpb.GetOrderResponse{
+		Order: order.ToProto(),
+	})
+	return resp, nil
+}
+
+func (h *OrderHandler) ListOrders(ctx context.Context, req *connect.Request[orderpb.ListOrdersRequest]) (*connect.Response[orderpb.ListOrdersResponse], error) {
+	createdFrom, err := parseTimeFilter(req.Msg.GetCreatedFrom())
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, rpcerr.InvalidField("created_from", fmt.Sprintf("created_from 형식이 올바르지 않습니다: %v", err))
+	}
+	createdTo, err := parseTimeFilter(req.Msg.GetCreatedTo())
+	if err != nil {
+		return nil, rpcerr.InvalidField("created_to", fmt.Sprintf("created_to 형식이 올바르지 않습니다: %v", err))
 	}
 
-	resp := connect.NewResponse(&orderpb.GetOrderResponse{
+	// UNSPECIFIED는 상태 필터 없음
+	var status models.OrderStatus
+	if req.Msg.GetStatus() != orderpb.OrderStatus_ORDER_STATUS_UNSPECIFIED {
+		var ok bool
+		status, ok = models.OrderStatusFromProto(req.Msg.GetStatus())
+		if !ok {
+			return nil, rpcerr.InvalidField("status", fmt.Sprintf("알 수 없는 주문 상태입니다: %v", req.Msg.GetStatus()))
+		}
+	}
+
+	orders, nextPageToken, err := h.service.ListOrders(ctx, store.ListOrdersParams{
+		UserID:      req.Msg.GetUserId(),
+		Status:      status,
+		CreatedFrom: createdFrom,
+		CreatedTo:   createdTo,
+		PageSize:    req.Msg.GetPageSize(),
+		PageToken:   req.Msg.GetPageToken(),
+	})
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	pbOrders := make([]*orderpb.Order, 0, len(orders))
+	for _, order := range orders {
+		pbOrders = append(pbOrders, order.ToProto())
+	}
+
+	resp := connect.NewResponse(&orderpb.ListOrdersResponse{
+		Orders:        pbOrders,
+		NextPageToken: nextPageToken,
+	})
+	return resp, nil
+}
+
+func (h *OrderHandler) TransitionOrder(ctx context.Context, req *connect.Request[orderpb.TransitionOrderRequest]) (*connect.Response[orderpb.TransitionOrderResponse], error) {
+	status, ok := models.OrderStatusFromProto(req.Msg.GetStatus())
+	if !ok {
+		return nil, rpcerr.InvalidField("status", "변경할 주문 상태가 올바르지 않습니다")
+	}
+
+	order, err := h.service.TransitionOrder(ctx, req.Msg.GetOrderId(), status)
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	resp := connect.NewResponse(&orderpb.TransitionOrderResponse{
 		Order: order.ToProto(),
 	})
 	return resp, nil
 }
 
+func (h *OrderHandler) CancelOrder(ctx context.Context, req *connect.Request[orderpb.CancelOrderRequest]) (*connect.Response[orderpb.CancelOrderResponse], error) {
+	order, err := h.service.CancelOrder(ctx, req.Msg.GetOrderId())
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	resp := connect.NewResponse(&orderpb.CancelOrderResponse{
+		Order: order.ToProto(),
+	})
+	return resp, nil
+}
+
+// parseTimeFilter: 비어 있으면 zero 값(제한 없음)을 반환
+func parseTimeFilter(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
 var _ orderconnect.OrderServiceHandler = (*OrderHandler)(nil)
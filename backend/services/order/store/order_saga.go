@@ -0,0 +1,174 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/logging"
+	"Acho-mj/2025_Golang_MSA/backend/internal/outbox"
+	"Acho-mj/2025_Golang_MSA/backend/internal/saga"
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+	"Acho-mj/2025_Golang_MSA/backend/services/order/models"
+)
+
+// 주문 생성 saga 종류와 단계 이름 (saga 기록에 저장되므로 바꾸면 진행 중인 saga를 재개할 수 없음)
+const (
+	createOrderSagaType = "create_order"
+	stepReserveStock    = "reserve_stock"
+	stepAuthorizePay    = "authorize_payment"
+	stepSaveOrder       = "save_order"
+	createOrderScope    = "CreateOrder"
+)
+
+// PaymentGateway: 주문 금액의 결제 승인/취소
+// saga가 재개되면 같은 주문 ID로 다시 호출될 수 있으므로 주문 ID 기준으로 멱등해야 함
+type PaymentGateway interface {
+	Authorize(ctx context.Context, orderID string, amount int64, currency string) error
+	// Void: 승인하지 않은 주문이면 아무것도 하지 않음
+	Void(ctx context.Context, orderID string) error
+}
+
+// createOrderPayload: 주문 생성 saga의 단계들이 공유하는 입력
+type createOrderPayload struct {
+	Order          storage.OrderRecord `json:"order"`
+	IdempotencyKey string              `json:"idempotency_key,omitempty"`
+	// idempotency 기록을 정리할 때 같은 요청의 기록인지 확인하는 값
+	RequestHash string `json:"request_hash,omitempty"`
+}
+
+func (s *OrderService) runCreateOrderSaga(ctx context.Context, record *storage.OrderRecord, idempotencyKey, requestHash string) error {
+	payload, err := json.Marshal(createOrderPayload{
+		Order:          *record,
+		IdempotencyKey: idempotencyKey,
+		RequestHash:    requestHash,
+	})
+	if err != nil {
+		return fmt.Errorf("saga 입력 marshal 실패: %w", err)
+	}
+
+	return s.sagas.Execute(ctx, createOrderSagaType, record.OrderID, payload)
+}
+
+// createOrderSaga: 재고 예약 -> 결제 승인 -> 주문 저장
+// 결제 연동 여부와 관계없이 단계 구성은 항상 같음 (결제 연동이 없으면 결제 단계는 아무것도 하지 않음)
+// 설정이 바뀌어도 이전 설정으로 시작한 saga를 재개하고 보상할 수 있어야 하므로 단계를 설정에 따라 빼지 않음
+func (s *OrderService) createOrderSaga() saga.Definition {
+	return saga.Definition{
+		Type: createOrderSagaType,
+		Steps: []saga.Step{{
+			Name:       stepReserveStock,
+			Action:     s.withOrder(s.reserveOrderStock),
+			Compensate: s.withOrder(s.releaseOrderStock),
+		}, {
+			Name:       stepAuthorizePay,
+			Action:     s.withOrder(s.authorizePayment),
+			Compensate: s.withOrder(s.voidPayment),
+		}, {
+			Name:       stepSaveOrder,
+			Action:     s.withOrder(s.saveOrder),
+			Compensate: s.withOrder(s.cancelSavedOrder),
+		}},
+		Recovered: s.settleRecoveredOrder,
+	}
+}
+
+func (s *OrderService) reserveOrderStock(ctx context.Context, record *storage.OrderRecord) error {
+	items := make([]models.OrderItem, 0, len(record.Items))
+	for _, item := range record.Items {
+		items = append(items, models.OrderItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+	// 예약 ID는 주문 ID를 그대로 사용
+	return s.reserveStock(ctx, record.OrderID, items)
+}
+
+func (s *OrderService) releaseOrderStock(ctx context.Context, record *storage.OrderRecord) error {
+	return s.releaseStock(ctx, record.OrderID)
+}
+
+// authorizePayment, voidPayment: 결제 연동이 없으면 아무것도 하지 않음
+func (s *OrderService) authorizePayment(ctx context.Context, record *storage.OrderRecord) error {
+	if s.payments == nil {
+		return nil
+	}
+	return s.payments.Authorize(ctx, record.OrderID, record.Total, record.Currency)
+}
+
+func (s *OrderService) voidPayment(ctx context.Context, record *storage.OrderRecord) error {
+	if s.payments == nil {
+		return nil
+	}
+	return s.payments.Void(ctx, record.OrderID)
+}
+
+func (s *OrderService) saveOrder(ctx context.Context, record *storage.OrderRecord) error {
+	event, err := orderCreatedEvent(record)
+	if err != nil {
+		return err
+	}
+	err = s.storage.CreateOrder(ctx, record, event)
+	if errors.Is(err, storage.ErrOrderAlreadyExists) {
+		// 재개된 saga가 이미 저장한 주문을 다시 저장하는 경우
+		return nil
+	}
+	return err
+}
+
+// cancelSavedOrder: 저장 결과를 알 수 없이 실패한 경우(타임아웃 등) 저장되었을 수 있는 주문을 취소
+// 예약한 재고는 재고 예약 단계의 보상이 되돌리므로 재고 처리 작업은 기록하지 않음
+func (s *OrderService) cancelSavedOrder(ctx context.Context, record *storage.OrderRecord) error {
+	event, err := statusChangedEvent(outbox.SourceOrder, record, models.OrderStatusCancelled)
+	if err != nil {
+		return err
+	}
+	_, err = s.storage.UpdateOrderStatus(ctx, record.OrderID, string(models.OrderStatusPending), string(models.OrderStatusCancelled), event)
+	if err != nil && !errors.Is(err, storage.ErrOrderNotFound) {
+		return err
+	}
+	return nil
+}
+
+// settleRecoveredOrder: 재개된 saga가 끝나면 요청 경로에서 끝내지 못한 idempotency 기록을 정리
+// 이 주문 ID에 묶인 같은 요청의 기록만 바꾸므로 이미 다른 요청이 가져간 key는 건드리지 않음
+func (s *OrderService) settleRecoveredOrder(ctx context.Context, orderID string, payload []byte, sagaErr error) {
+	var input createOrderPayload
+	if err := json.Unmarshal(payload, &input); err != nil {
+		logging.FromContext(ctx).Error("saga 입력 언마샬 실패", "order_id", orderID, "error", err)
+		return
+	}
+
+	var err error
+	if sagaErr == nil {
+		err = s.completeRecoveredOrder(ctx, orderID, &input)
+	} else {
+		logging.FromContext(ctx).Warn("주문 생성 saga가 보상 후 종료되었습니다", "order_id", orderID, "error", sagaErr)
+		err = s.idempotency.Release(ctx, createOrderScope, input.IdempotencyKey, input.RequestHash, orderID)
+	}
+	if err != nil {
+		logging.FromContext(ctx).Error("idempotency 기록 정리 실패", "order_id", orderID, "error", err)
+	}
+}
+
+// completeRecoveredOrder: 요청 경로에서 끝났다면 돌려줬을 응답과 함께 idempotency 기록을 완료로 표시
+func (s *OrderService) completeRecoveredOrder(ctx context.Context, orderID string, input *createOrderPayload) error {
+	response, err := orderResponse(&input.Order)
+	if err != nil {
+		return err
+	}
+	return s.idempotency.Complete(ctx, createOrderScope, input.IdempotencyKey, input.RequestHash, orderID, response)
+}
+
+// withOrder: saga 입력에서 주문을 꺼내 단계 함수에 넘김
+func (s *OrderService) withOrder(fn func(ctx context.Context, record *storage.OrderRecord) error) func(ctx context.Context, sagaID string, payload []byte) error {
+	return func(ctx context.Context, sagaID string, payload []byte) error {
+		var input createOrderPayload
+		if err := json.Unmarshal(payload, &input); err != nil {
+			return fmt.Errorf("saga 입력 언마샬 실패: %w", err)
+		}
+		return fn(ctx, &input.Order)
+	}
+}
@@ -6,95 +6,354 @@ import (
 	"fmt"
 	"time"
 
+	eventspb "Acho-mj/2025_Golang_MSA/backend/gen/events"
+	inventorypb "Acho-mj/2025_Golang_MSA/backend/gen/inventory"
+	inventoryconnect "Acho-mj/2025_Golang_MSA/backend/gen/inventory/inventoryconnect"
+	orderpb "Acho-mj/2025_Golang_MSA/backend/gen/order"
+	productpb "Acho-mj/2025_Golang_MSA/backend/gen/product"
+	productconnect "Acho-mj/2025_Golang_MSA/backend/gen/product/productconnect"
 	userpb "Acho-mj/2025_Golang_MSA/backend/gen/user"
 	userconnect "Acho-mj/2025_Golang_MSA/backend/gen/user/userconnect"
+	"Acho-mj/2025_Golang_MSA/backend/internal/apperr"
+	"Acho-mj/2025_Golang_MSA/backend/internal/idempotency"
+	"Acho-mj/2025_Golang_MSA/backend/internal/ids"
+	"Acho-mj/2025_Golang_MSA/backend/internal/outbox"
+	"Acho-mj/2025_Golang_MSA/backend/internal/pagination"
+	"Acho-mj/2025_Golang_MSA/backend/internal/saga"
 	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
 	"Acho-mj/2025_Golang_MSA/backend/services/order/models"
 
 	connect "connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
 )
 
 var (
-	ErrInvalidInput   = errors.New("잘못된 입력입니다")
-	ErrOrderNotFound  = errors.New("주문을 찾을 수 없습니다")
-	defaultOrderState = "pending"
+	ErrInvalidInput      = apperr.New(apperr.ErrInvalidInput, "잘못된 입력입니다")
+	ErrOrderNotFound     = apperr.New(apperr.ErrNotFound, "주문을 찾을 수 없습니다")
+	ErrInvalidTransition = apperr.New(apperr.ErrFailedPrecondition, "허용되지 않는 주문 상태 변경입니다")
+	ErrOrderConflict     = apperr.New(apperr.ErrConflict, "주문이 동시에 변경되었습니다")
+	// 판매 중지된 상품을 주문한 경우
+	ErrProductUnavailable = apperr.New(apperr.ErrFailedPrecondition, "주문할 수 없는 상품입니다")
+	// 주문 수량만큼 재고를 예약할 수 없는 경우
+	ErrOutOfStock = apperr.New(apperr.ErrFailedPrecondition, "주문 수량만큼 재고를 예약할 수 없습니다")
+	// 통화가 다른 상품을 한 주문에 담은 경우
+	ErrCurrencyMismatch = apperr.New(apperr.ErrFailedPrecondition, "통화가 다른 상품을 함께 주문할 수 없습니다")
+	// 정지/해지된 사용자가 주문하려는 경우
+	ErrUserNotActive = apperr.New(apperr.ErrPermissionDenied, "주문할 수 없는 계정 상태입니다")
+	// user 서비스를 호출할 수 없는 경우
+	ErrUserServiceUnavailable = apperr.New(apperr.ErrUnavailable, "user 서비스를 사용할 수 없습니다")
+	// product 서비스를 호출할 수 없는 경우
+	ErrProductServiceUnavailable = apperr.New(apperr.ErrUnavailable, "product 서비스를 사용할 수 없습니다")
+	// inventory 서비스를 호출할 수 없는 경우
+	ErrInventoryServiceUnavailable = apperr.New(apperr.ErrUnavailable, "inventory 서비스를 사용할 수 없습니다")
+	// 같은 idempotency key가 내용이 다른 요청에 사용된 경우
+	ErrIdempotencyKeyReused = apperr.New(apperr.ErrInvalidInput, "idempotency key가 다른 요청에 이미 사용되었습니다")
+	// 같은 idempotency key의 이전 요청이 아직 처리 중인 경우
+	ErrRequestInProgress = apperr.New(apperr.ErrConflict, "같은 idempotency key의 요청이 처리 중입니다. 잠시 후 같은 key로 다시 시도하세요")
+	// 주문 생성 saga를 요청 안에 끝내지 못한 경우 (복구 루프가 이어서 처리하므로 같은 key로 나중에 다시 조회)
+	ErrOrderPending   = apperr.New(apperr.ErrUnavailable, "주문 생성이 아직 끝나지 않았습니다. 같은 idempotency key로 다시 시도하세요")
+	defaultOrderState = models.OrderStatusPending
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
 )
 
 type OrderService struct {
-	storage    *storage.OrderStorage
-	userClient userconnect.UserServiceClient
+	storage         storage.OrderRepository
+	userClient      userconnect.UserServiceClient
+	productClient   productconnect.ProductServiceClient
+	inventoryClient inventoryconnect.InventoryServiceClient
+	// nil이면 주문마다 user 서비스로 사용자를 확인함
+	users *UserCache
+	// nil이면 주문 생성 saga의 결제 단계가 아무것도 하지 않음
+	payments    PaymentGateway
+	sagas       *saga.Orchestrator
+	pageTokens  *pagination.TokenCodec
+	idempotency *idempotency.Guard
+	// 주문 금액에 적용할 세율 (basis point, 1000 = 10%)
+	taxRateBPS int64
 }
 
-func NewOrderService(storage *storage.OrderStorage, userClient userconnect.UserServiceClient) *OrderService {
-	return &OrderService{
-		storage:    storage,
-		userClient: userClient,
-	}
+// ListOrdersParams: 주문 목록 조회 조건
+type ListOrdersParams struct {
+	UserID string
+	// 비어 있으면 모든 상태
+	Status      models.OrderStatus
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	PageSize    int32
+	PageToken   string
 }
 
-func (s *OrderService) CreateOrder(ctx context.Context, userID string, items []models.OrderItem) (*models.Order, error) {
-	if userID == "" {
-		return nil, fmt.Errorf("%w: userID는 필수입니다", ErrInvalidInput)
+// NewOrderService: 주문 생성 saga를 sagas에 등록하므로 sagas의 복구 루프보다 먼저 호출해야 함
+func NewOrderService(storage storage.OrderRepository, userClient userconnect.UserServiceClient, users *UserCache, productClient productconnect.ProductServiceClient, inventoryClient inventoryconnect.InventoryServiceClient, payments PaymentGateway, sagas *saga.Orchestrator, pageTokens *pagination.TokenCodec, idempotency *idempotency.Guard, taxRateBPS int64) (*OrderService, error) {
+	if sagas == nil {
+		return nil, errors.New("saga orchestrator가 nil입니다")
 	}
-	if len(items) == 0 {
-		return nil, fmt.Errorf("%w: 최소 한 개의 상품이 필요합니다", ErrInvalidInput)
+
+	s := &OrderService{
+		storage:         storage,
+		userClient:      userClient,
+		users:           users,
+		productClient:   productClient,
+		inventoryClient: inventoryClient,
+		payments:        payments,
+		sagas:           sagas,
+		pageTokens:      pageTokens,
+		idempotency:     idempotency,
+		taxRateBPS:      taxRateBPS,
+	}
+	if err := sagas.Register(s.createOrderSaga()); err != nil {
+		return nil, fmt.Errorf("주문 생성 saga 등록 실패: %w", err)
 	}
 
+	return s, nil
+}
+
+// CreateOrder: idempotencyKey가 있으면 같은 key의 재시도에 처음 생성한 주문을 돌려줌
+func (s *OrderService) CreateOrder(ctx context.Context, userID string, items []models.OrderItem, idempotencyKey string) (*models.Order, error) {
 	if s.userClient == nil {
 		return nil, fmt.Errorf("user 서비스 클라이언트가 초기화되지 않았습니다")
 	}
-
-	if err := s.ensureUserExists(ctx, userID); err != nil {
-		return nil, err
+	if s.productClient == nil {
+		return nil, fmt.Errorf("product 서비스 클라이언트가 초기화되지 않았습니다")
+	}
+	if s.inventoryClient == nil {
+		return nil, fmt.Errorf("inventory 서비스 클라이언트가 초기화되지 않았습니다")
 	}
 
-	recordItems := make([]storage.OrderLine, 0, len(items))
+	hashParts := make([]string, 0, len(items)+1)
+	hashParts = append(hashParts, userID)
 	for _, item := range items {
-		if item.ProductID == "" || item.Quantity <= 0 {
-			return nil, fmt.Errorf("%w: 상품 ID와 수량은 필수입니다", ErrInvalidInput)
-		}
-		recordItems = append(recordItems, storage.OrderLine{
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-		})
+		hashParts = append(hashParts, fmt.Sprintf("%s:%d", item.ProductID, item.Quantity))
 	}
 
-	record := &storage.OrderRecord{
-		OrderID:   generateOrderID(),
-		UserID:    userID,
-		Items:     recordItems,
-		Status:    defaultOrderState,
-		CreatedAt: time.Now().UTC(),
+	var created *models.Order
+	requestHash := idempotency.HashRequest(hashParts...)
+	result, err := s.idempotency.Do(ctx, createOrderScope, idempotencyKey, requestHash, func(ctx context.Context, hold idempotency.Hold) (idempotency.Result, error) {
+		if err := s.ensureUserExists(ctx, userID); err != nil {
+			return idempotency.Result{}, err
+		}
+		products, err := s.availableProducts(ctx, items)
+		if err != nil {
+			return idempotency.Result{}, err
+		}
+		priced, currency, price, err := s.priceItems(items, products)
+		if err != nil {
+			return idempotency.Result{}, err
+		}
+
+		orderID, err := ids.NewOrderID()
+		if err != nil {
+			return idempotency.Result{}, fmt.Errorf("주문 ID 생성 실패: %w", err)
+		}
+
+		recordItems := make([]storage.OrderLine, 0, len(priced))
+		for _, item := range priced {
+			recordItems = append(recordItems, storage.OrderLine{
+				ProductID: item.ProductID,
+				Quantity:  item.Quantity,
+				UnitPrice: item.UnitPrice,
+				LineTotal: item.LineTotal,
+			})
+		}
+
+		record := &storage.OrderRecord{
+			OrderID:   orderID,
+			UserID:    userID,
+			Items:     recordItems,
+			Status:    string(defaultOrderState),
+			CreatedAt: time.Now().UTC(),
+			Currency:  currency,
+			Subtotal:  price.Subtotal,
+			Tax:       price.Tax,
+			Total:     price.Total,
+		}
+
+		// 같은 key의 재시도에 돌려줄 응답 (saga가 재개되어 끝나도 같은 값을 기록함)
+		response, err := orderResponse(record)
+		if err != nil {
+			return idempotency.Result{}, err
+		}
+
+		// saga를 시작하기 전에 idempotency 기록을 주문 ID에 묶음
+		// saga가 요청 안에 끝나지 않으면 기록은 saga가 끝날 때까지 남아 같은 key로 주문이 두 번 생성되지 않음
+		if err := hold(ctx, orderID); err != nil {
+			return idempotency.Result{}, err
+		}
+
+		// 재고 예약 -> 결제 승인 -> 주문 저장을 saga로 실행 (saga ID는 주문 ID)
+		// 실패하면 앞 단계를 역순으로 되돌리고, 실행 중 파드가 죽으면 다른 인스턴스가 이어서 실행
+		if err := s.runCreateOrderSaga(ctx, record, idempotencyKey, requestHash); err != nil {
+			if errors.Is(err, saga.ErrInFlight) {
+				// 기록 정리는 saga를 끝내는 쪽(settleRecoveredOrder)이 맡음
+				return idempotency.Result{}, fmt.Errorf("%w: %w", idempotency.ErrPending, err)
+			}
+			return idempotency.Result{}, err
+		}
+
+		created = orderFromRecord(record)
+		return idempotency.Result{ResourceID: record.OrderID, Response: response}, nil
+	})
+	if err != nil {
+		return nil, idempotencyError(err)
+	}
+	if result.Replayed {
+		return s.replayedOrder(ctx, result)
 	}
 
-	if err := s.storage.CreateOrder(ctx, record); err != nil {
-		return nil, err
+	return created, nil
+}
+
+// replayedOrder: 같은 key의 재시도에 처음 응답한 주문을 돌려줌
+// 응답을 기록하기 전에 완료된 기록이면 현재 주문을 조회함
+func (s *OrderService) replayedOrder(ctx context.Context, result idempotency.Result) (*models.Order, error) {
+	if len(result.Response) == 0 {
+		return s.GetOrder(ctx, result.ResourceID)
 	}
+	var order orderpb.Order
+	if err := proto.Unmarshal(result.Response, &order); err != nil {
+		return nil, fmt.Errorf("idempotency 응답 언마샬 실패: %w", err)
+	}
+	return models.OrderFromProto(&order), nil
+}
 
-	return &models.Order{
-		OrderID:   record.OrderID,
-		UserID:    record.UserID,
-		Items:     items,
-		Status:    record.Status,
-		CreatedAt: record.CreatedAt,
-	}, nil
+// orderResponse: 주문 생성 요청의 응답으로 idempotency 기록에 남길 값
+func orderResponse(record *storage.OrderRecord) ([]byte, error) {
+	response, err := proto.Marshal(orderFromRecord(record).ToProto())
+	if err != nil {
+		return nil, fmt.Errorf("주문 응답 marshal 실패: %w", err)
+	}
+	return response, nil
 }
 
 func (s *OrderService) GetOrder(ctx context.Context, orderID string) (*models.Order, error) {
-	if orderID == "" {
-		return nil, fmt.Errorf("%w: orderID는 필수입니다", ErrInvalidInput)
+	record, err := s.storage.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, orderLookupError(err)
 	}
 
-	record, err := s.storage.GetOrderByID(ctx, orderID)
+	return orderFromRecord(record), nil
+}
+
+// ListOrders: 사용자의 주문을 최신순으로 조회하고 다음 페이지 token을 함께 반환
+func (s *OrderService) ListOrders(ctx context.Context, params ListOrdersParams) ([]*models.Order, string, error) {
+	if !params.CreatedFrom.IsZero() && !params.CreatedTo.IsZero() && params.CreatedFrom.After(params.CreatedTo) {
+		return nil, "", invalidInput("created_from", "created_from이 created_to보다 늦습니다")
+	}
+
+	pageSize := params.PageSize
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	// token은 발급 당시의 조회 조건에서만 유효
+	scope := fmt.Sprintf("orders|%s|%s|%s|%s", params.UserID, params.Status,
+		params.CreatedFrom.UTC().Format(time.RFC3339Nano), params.CreatedTo.UTC().Format(time.RFC3339Nano))
+	startKey, err := s.pageTokens.Decode(scope, params.PageToken)
+	if err != nil {
+		return nil, "", apperr.WithField("page_token", fmt.Errorf("%w: %v", ErrInvalidInput, err))
+	}
+
+	page, err := s.storage.ListOrdersByUser(ctx, storage.OrderQuery{
+		UserID:      params.UserID,
+		Status:      string(params.Status),
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+		Limit:       pageSize,
+		StartKey:    startKey,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextToken, err := s.pageTokens.Encode(scope, page.NextKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	orders := make([]*models.Order, 0, len(page.Orders))
+	for i := range page.Orders {
+		orders = append(orders, orderFromRecord(&page.Orders[i]))
+	}
+
+	return orders, nextToken, nil
+}
+
+// TransitionOrder: 현재 상태에서 허용된 경우에만 주문 상태를 변경
+func (s *OrderService) TransitionOrder(ctx context.Context, orderID string, next models.OrderStatus) (*models.Order, error) {
+	current, err := s.storage.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, orderLookupError(err)
+	}
+
+	status := models.OrderStatus(current.Status)
+	if !status.CanTransitionTo(next) {
+		return nil, apperr.WithField("status", fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, status, next))
+	}
+
+	event, err := statusChangedEvent(outbox.SourceOrder, current, next)
+	if err != nil {
+		return nil, err
+	}
+	settlement, err := stockSettlementTask(current, next)
 	if err != nil {
 		return nil, err
 	}
 
+	// 조회 이후 다른 요청이 상태를 바꿨다면 조건부 업데이트가 실패함
+	record, err := s.storage.UpdateOrderStatus(ctx, orderID, current.Status, string(next), event, settlement)
+	if err != nil {
+		if errors.Is(err, storage.ErrOrderStatusConflict) {
+			return nil, ErrOrderConflict
+		}
+		return nil, orderLookupError(err)
+	}
+
+	// 재고 예약 확정/취소는 같은 트랜잭션으로 기록한 작업을 재고 처리 worker가 실행함 (SettleReservedStock)
+	return orderFromRecord(record), nil
+}
+
+// SettleReservedStock: 주문이 확정되면 재고 예약도 확정하고, 취소되면 예약한 재고를 되돌리는 재고 처리 worker 핸들러
+// 실패하면 worker가 성공할 때까지 다시 시도함 (inventory의 확정/취소는 같은 예약에 다시 호출해도 결과가 같음)
+func (s *OrderService) SettleReservedStock(ctx context.Context, event *eventspb.Event) error {
+	changed := event.GetOrderStatusChanged()
+	if changed == nil {
+		return nil
+	}
+
+	// 예약 ID는 주문 ID
+	switch changed.GetToStatus() {
+	case models.OrderStatusConfirmed.ToProto():
+		if err := s.commitStock(ctx, changed.GetOrderId()); err != nil {
+			return fmt.Errorf("재고 예약 확정 실패: %w", err)
+		}
+	case models.OrderStatusCancelled.ToProto():
+		if err := s.releaseStock(ctx, changed.GetOrderId()); err != nil {
+			return fmt.Errorf("재고 예약 취소 실패: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *OrderService) CancelOrder(ctx context.Context, orderID string) (*models.Order, error) {
+	return s.TransitionOrder(ctx, orderID, models.OrderStatusCancelled)
+}
+
+// orderFromRecord: DB 레코드(OrderRecord) -> 도메인 모델(Order)로 변환
+func orderFromRecord(record *storage.OrderRecord) *models.Order {
 	items := make([]models.OrderItem, 0, len(record.Items))
 	for _, item := range record.Items {
 		items = append(items, models.OrderItem{
 			ProductID: item.ProductID,
 			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			LineTotal: item.LineTotal,
 		})
 	}
 
@@ -102,33 +361,254 @@ func (s *OrderService) GetOrder(ctx context.Context, orderID string) (*models.Or
 		OrderID:   record.OrderID,
 		UserID:    record.UserID,
 		Items:     items,
-		Status:    record.Status,
+		Status:    models.OrderStatus(record.Status),
 		CreatedAt: record.CreatedAt,
-	}, nil
+		Currency:  record.Currency,
+		Subtotal:  record.Subtotal,
+		Tax:       record.Tax,
+		Total:     record.Total,
+	}
+}
+
+// orderCreatedEvent: 주문과 같은 트랜잭션으로 기록할 OrderCreated 이벤트
+func orderCreatedEvent(record *storage.OrderRecord) (*storage.OutboxRecord, error) {
+	return outbox.NewRecord(outbox.SourceOrder, record.OrderID, &eventspb.Event{
+		Payload: &eventspb.Event_OrderCreated{
+			OrderCreated: &eventspb.OrderCreated{Order: orderFromRecord(record).ToProto()},
+		},
+	})
+}
+
+// statusChangedEvent: 상태 변경과 같은 트랜잭션으로 기록할 OrderStatusChanged 이벤트
+func statusChangedEvent(source string, current *storage.OrderRecord, next models.OrderStatus) (*storage.OutboxRecord, error) {
+	return outbox.NewRecord(source, current.OrderID, &eventspb.Event{
+		Payload: &eventspb.Event_OrderStatusChanged{
+			OrderStatusChanged: &eventspb.OrderStatusChanged{
+				OrderId:    current.OrderID,
+				UserId:     current.UserID,
+				FromStatus: models.OrderStatus(current.Status).ToProto(),
+				ToStatus:   next.ToProto(),
+			},
+		},
+	})
 }
 
-func generateOrderID() string {
-	return fmt.Sprintf("order-%d", time.Now().UnixNano())
+// stockSettlementTask: 확정/취소로 바뀔 때 상태 변경과 같은 트랜잭션으로 기록할 재고 처리 작업 (그 밖의 상태는 nil)
+// 발행할 이벤트와 source가 달라 재고 서비스 장애가 이벤트 발행을 막지 않음
+func stockSettlementTask(current *storage.OrderRecord, next models.OrderStatus) (*storage.OutboxRecord, error) {
+	if next != models.OrderStatusConfirmed && next != models.OrderStatusCancelled {
+		return nil, nil
+	}
+	return statusChangedEvent(outbox.SourceOrderStock, current, next)
 }
 
+// idempotencyError: idempotency 패키지 에러를 서비스 에러로 변환
+func idempotencyError(err error) error {
+	switch {
+	case errors.Is(err, idempotency.ErrInvalidKey):
+		return apperr.WithField("idempotency_key", fmt.Errorf("%w: %v", ErrInvalidInput, err))
+	case errors.Is(err, idempotency.ErrKeyReused):
+		return apperr.WithField("idempotency_key", ErrIdempotencyKeyReused)
+	case errors.Is(err, idempotency.ErrInProgress):
+		return apperr.WithRetryDelay(idempotency.InProgressRetryDelay, ErrRequestInProgress)
+	case errors.Is(err, idempotency.ErrPending):
+		return fmt.Errorf("%w: %v", ErrOrderPending, err)
+	}
+	return err
+}
+
+// orderLookupError: storage의 not found를 서비스 에러로 변환
+func orderLookupError(err error) error {
+	if errors.Is(err, storage.ErrOrderNotFound) {
+		return apperr.WithField("order_id", ErrOrderNotFound)
+	}
+	return err
+}
+
+// invalidInput: 원인이 된 요청 필드를 함께 기록한 입력 에러
+func invalidInput(field, msg string) error {
+	return apperr.WithField(field, fmt.Errorf("%w: %s", ErrInvalidInput, msg))
+}
+
+// ensureUserExists: 사용자가 존재하고 주문할 수 있는 상태(active)인지 user 서비스로 확인
 func (s *OrderService) ensureUserExists(ctx context.Context, userID string) error {
-	req := connect.NewRequest(&userpb.GetUserRequest{
-		UserId: userID,
+	status, found, err := s.userStatus(ctx, userID)
+	if err != nil {
+		var connectErr *connect.Error
+		if errors.As(err, &connectErr) {
+			switch connectErr.Code() {
+			case connect.CodeInvalidArgument:
+				return invalidInput("user_id", fmt.Sprintf("userID %s가 올바르지 않습니다", userID))
+			case connect.CodeUnavailable, connect.CodeDeadlineExceeded, connect.CodeResourceExhausted:
+				return fmt.Errorf("%w: %v", ErrUserServiceUnavailable, err)
+			}
+		}
+		return fmt.Errorf("user 서비스 호출 실패: %w", err)
+	}
+	if !found {
+		return invalidInput("user_id", fmt.Sprintf("사용자 %s를 찾을 수 없습니다", userID))
+	}
+
+	// active로 확인된 사용자만 허용 (상태를 알 수 없는 UNSPECIFIED도 거부)
+	// 상태가 없는 이전 사용자는 user 서비스가 active로 응답함
+	if status != userpb.UserStatus_USER_STATUS_ACTIVE {
+		return fmt.Errorf("%w: 사용자 %s (%s)", ErrUserNotActive, userID, status)
+	}
+	return nil
+}
+
+// userStatus: 사용자 상태 조회 (캐시가 있으면 캐시를 먼저 확인하고, 같은 사용자의 동시 조회는 한 번만 호출)
+// 사용자가 없으면 found가 false
+func (s *OrderService) userStatus(ctx context.Context, userID string) (userpb.UserStatus, bool, error) {
+	if s.users == nil {
+		return s.fetchUserStatus(ctx, userID)
+	}
+	return s.users.Get(ctx, userID, func(ctx context.Context) (userpb.UserStatus, bool, error) {
+		return s.fetchUserStatus(ctx, userID)
 	})
-	_, err := s.userClient.GetUser(ctx, req)
-	if err == nil {
-		return nil
+}
+
+func (s *OrderService) fetchUserStatus(ctx context.Context, userID string) (userpb.UserStatus, bool, error) {
+	resp, err := s.userClient.GetUser(ctx, connect.NewRequest(&userpb.GetUserRequest{
+		UserId: userID,
+	}))
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeNotFound {
+			return userpb.UserStatus_USER_STATUS_UNSPECIFIED, false, nil
+		}
+		return userpb.UserStatus_USER_STATUS_UNSPECIFIED, false, err
+	}
+	return resp.Msg.GetUser().GetStatus(), true, nil
+}
+
+// availableProducts: 주문한 상품이 모두 존재하고 판매 중인지 product 서비스로 확인하고 상품 정보를 돌려줌
+func (s *OrderService) availableProducts(ctx context.Context, items []models.OrderItem) (map[string]*productpb.Product, error) {
+	products := make(map[string]*productpb.Product, len(items))
+	for i, item := range items {
+		if _, ok := products[item.ProductID]; ok {
+			continue
+		}
+
+		field := fmt.Sprintf("items[%d].product_id", i)
+		req := connect.NewRequest(&productpb.GetProductRequest{
+			ProductId: item.ProductID,
+		})
+		resp, err := s.productClient.GetProduct(ctx, req)
+		if err != nil {
+			var connectErr *connect.Error
+			if errors.As(err, &connectErr) {
+				switch connectErr.Code() {
+				case connect.CodeNotFound:
+					return nil, invalidInput(field, fmt.Sprintf("상품 %s를 찾을 수 없습니다", item.ProductID))
+				case connect.CodeInvalidArgument:
+					return nil, invalidInput(field, fmt.Sprintf("productID %s가 올바르지 않습니다", item.ProductID))
+				case connect.CodeUnavailable, connect.CodeDeadlineExceeded, connect.CodeResourceExhausted:
+					return nil, fmt.Errorf("%w: %v", ErrProductServiceUnavailable, err)
+				}
+			}
+			return nil, fmt.Errorf("product 서비스 호출 실패: %w", err)
+		}
+
+		product := resp.Msg.GetProduct()
+		if !product.GetAvailable() {
+			return nil, apperr.WithField(field, fmt.Errorf("%w: %s", ErrProductUnavailable, item.ProductID))
+		}
+		products[item.ProductID] = product
+	}
+
+	return products, nil
+}
+
+// priceItems: 현재 상품 가격을 주문 상품에 기록하고 주문 금액을 계산
+// 주문의 통화는 상품의 통화를 따르며 모든 상품의 통화가 같아야 함
+func (s *OrderService) priceItems(items []models.OrderItem, products map[string]*productpb.Product) ([]models.OrderItem, string, models.OrderPrice, error) {
+	priced := make([]models.OrderItem, 0, len(items))
+	var currency string
+	for i, item := range items {
+		product := products[item.ProductID]
+		if i == 0 {
+			currency = product.GetCurrency()
+		} else if product.GetCurrency() != currency {
+			return nil, "", models.OrderPrice{}, apperr.WithField(fmt.Sprintf("items[%d].product_id", i),
+				fmt.Errorf("%w: %s, %s", ErrCurrencyMismatch, currency, product.GetCurrency()))
+		}
+
+		priced = append(priced, models.OrderItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			UnitPrice: product.GetPrice(),
+		})
 	}
 
-	var connectErr *connect.Error
-	if errors.As(err, &connectErr) {
-		switch connectErr.Code() {
-		case connect.CodeNotFound:
-			return fmt.Errorf("%w: 사용자 %s를 찾을 수 없습니다", ErrInvalidInput, userID)
-		case connect.CodeInvalidArgument:
-			return fmt.Errorf("%w: userID %s가 올바르지 않습니다", ErrInvalidInput, userID)
+	price, err := models.PriceItems(priced, s.taxRateBPS)
+	if err != nil {
+		return nil, "", models.OrderPrice{}, apperr.WithField("items", fmt.Errorf("%w: %v", ErrInvalidInput, err))
+	}
+
+	return priced, currency, price, nil
+}
+
+// reserveStock: 주문 상품의 재고를 예약 (같은 상품이 여러 번 있으면 수량을 합침)
+func (s *OrderService) reserveStock(ctx context.Context, reservationID string, items []models.OrderItem) error {
+	lines := make([]*inventorypb.ReservationLine, 0, len(items))
+	index := make(map[string]int, len(items))
+	for _, item := range items {
+		if i, ok := index[item.ProductID]; ok {
+			lines[i].Quantity += item.Quantity
+			continue
+		}
+		index[item.ProductID] = len(lines)
+		lines = append(lines, &inventorypb.ReservationLine{
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	_, err := s.inventoryClient.Reserve(ctx, connect.NewRequest(&inventorypb.ReserveRequest{
+		ReservationId: reservationID,
+		Lines:         lines,
+	}))
+	if err != nil {
+		var connectErr *connect.Error
+		if errors.As(err, &connectErr) {
+			switch connectErr.Code() {
+			case connect.CodeFailedPrecondition:
+				return apperr.WithField("items", fmt.Errorf("%w: %s", ErrOutOfStock, connectErr.Message()))
+			case connect.CodeAlreadyExists:
+				// 재개된 saga가 이미 예약한 주문을 다시 예약하는 경우 (예약 ID = 주문 ID)
+				return nil
+			}
 		}
+		return inventoryError(err)
+	}
+	return nil
+}
+
+// commitStock, releaseStock: 재고 예약이 없는 주문(재고 예약 도입 전 주문)은 무시
+func (s *OrderService) commitStock(ctx context.Context, reservationID string) error {
+	_, err := s.inventoryClient.Commit(ctx, connect.NewRequest(&inventorypb.CommitRequest{
+		ReservationId: reservationID,
+	}))
+	if err != nil && connect.CodeOf(err) != connect.CodeNotFound {
+		return inventoryError(err)
 	}
+	return nil
+}
 
-	return fmt.Errorf("user 서비스 호출 실패: %w", err)
+func (s *OrderService) releaseStock(ctx context.Context, reservationID string) error {
+	_, err := s.inventoryClient.Release(ctx, connect.NewRequest(&inventorypb.ReleaseRequest{
+		ReservationId: reservationID,
+	}))
+	if err != nil && connect.CodeOf(err) != connect.CodeNotFound {
+		return inventoryError(err)
+	}
+	return nil
+}
+
+func inventoryError(err error) error {
+	switch connect.CodeOf(err) {
+	case connect.CodeUnavailable, connect.CodeDeadlineExceeded, connect.CodeResourceExhausted:
+		return fmt.Errorf("%w: %v", ErrInventoryServiceUnavailable, err)
+	}
+	return fmt.Errorf("inventory 서비스 호출 실패: %w", err)
 }
@@ -0,0 +1,37 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	eventspb "Acho-mj/2025_Golang_MSA/backend/gen/events"
+	userpb "Acho-mj/2025_Golang_MSA/backend/gen/user"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/cache"
+	"Acho-mj/2025_Golang_MSA/backend/internal/outbox"
+)
+
+// UserCache: user 서비스로 조회한 사용자 상태를 user_id별로 담아 두는 캐시
+// 없는 사용자(NotFound)도 negative TTL 동안 캐시함
+type UserCache = cache.Cache[string, userpb.UserStatus]
+
+func NewUserCache(capacity int, ttl, negativeTTL time.Duration) (*UserCache, error) {
+	return cache.New[string, userpb.UserStatus](capacity, ttl, negativeTTL)
+}
+
+// InvalidateUserCache: user 서비스가 발행한 이벤트 핸들러
+// 사용자가 생성/상태 변경(정지, 해지 포함)/삭제되면 캐시 항목을 지워 다음 주문에서 user 서비스로 다시 확인하게 함
+// (생성은 없는 사용자로 캐시된 항목을 지우기 위함)
+func InvalidateUserCache(users *UserCache) outbox.Handler {
+	return func(ctx context.Context, event *eventspb.Event) error {
+		switch payload := event.GetPayload().(type) {
+		case *eventspb.Event_UserCreated:
+			users.Invalidate(payload.UserCreated.GetUser().GetUserId())
+		case *eventspb.Event_UserStatusChanged:
+			users.Invalidate(payload.UserStatusChanged.GetUserId())
+		case *eventspb.Event_UserDeleted:
+			users.Invalidate(payload.UserDeleted.GetUserId())
+		}
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	productconnect "Acho-mj/2025_Golang_MSA/backend/gen/product/productconnect"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/config"
+	"Acho-mj/2025_Golang_MSA/backend/internal/logging"
+	"Acho-mj/2025_Golang_MSA/backend/internal/server"
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+	"Acho-mj/2025_Golang_MSA/backend/internal/tracing"
+	"Acho-mj/2025_Golang_MSA/backend/services/product/rpchandler"
+	"Acho-mj/2025_Golang_MSA/backend/services/product/store"
+)
+
+func main() {
+	ctx := context.Background()
+
+	cfg, err := config.LoadConfig(config.TableProduct)
+	if err != nil {
+		logging.Fatal("config load 실패", "error", err)
+	}
+	// JSON 로그 (LOG_LEVEL 이상만 남김)
+	logging.Setup("product-service", cfg.LogLevel)
+
+	// HTTP 서버 (종료 신호를 받으면 진행 중인 요청과 백그라운드 작업을 마치고 종료)
+	srv, err := server.New("product service", server.OptionsFrom(cfg))
+	if err != nil {
+		logging.Fatal("server 초기화 실패", "error", err)
+	}
+
+	// OpenTelemetry trace (TRACING_EXPORTER), 종료할 때 남은 span을 내보냄
+	shutdownTracing, err := tracing.Setup(ctx, tracing.ConfigFrom(cfg, "product-service"))
+	if err != nil {
+		logging.Fatal("tracing 초기화 실패", "error", err)
+	}
+	srv.OnShutdown(shutdownTracing)
+
+	var productStorage storage.ProductRepository
+	switch cfg.StorageBackend {
+	case config.StorageBackendMemory:
+		productStorage = storage.NewMemoryProductStorage()
+	default:
+		dynamoClient, err := storage.NewDynamoClient(ctx, cfg)
+		if err != nil {
+			logging.Fatal("dynamodb 초기화 실패", "error", err)
+		}
+
+		productStorage, err = storage.NewProductStorage(dynamoClient, cfg.DynamoProductTable)
+		if err != nil {
+			logging.Fatal("product storage 초기화 실패", "error", err)
+		}
+
+		// readiness: 사용하는 테이블을 DescribeTable로 확인
+		srv.Check("dynamodb:"+cfg.DynamoProductTable, func(ctx context.Context) error {
+			return storage.PingTable(ctx, dynamoClient, cfg.DynamoProductTable)
+		})
+	}
+	slog.Info("저장소 설정", "storage_backend", cfg.StorageBackend)
+
+	productService := store.NewProductService(productStorage)
+	productHandler := rpchandler.NewProductHandler(productService)
+
+	path, handler := productconnect.NewProductServiceHandler(productHandler, srv.HandlerOptions()...)
+	srv.Handle(path, handler)
+
+	if err := srv.Run(); err != nil {
+		logging.Fatal("서버 종료", "error", err)
+	}
+}
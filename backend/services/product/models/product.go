@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	productpb "Acho-mj/2025_Golang_MSA/backend/gen/product"
+)
+
+type Product struct {
+	ProductID string
+	Name      string
+	// 최소 화폐 단위 정수 (예: KRW는 원, USD는 센트)
+	Price     int64
+	Currency  string
+	Available bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ToProto: 도메인 모델(Product) -> Proto 모델(*productpb.Product)로 변환
+func (p *Product) ToProto() *productpb.Product {
+	if p == nil {
+		return nil
+	}
+	return &productpb.Product{
+		ProductId: p.ProductID,
+		Name:      p.Name,
+		Price:     p.Price,
+		Currency:  p.Currency,
+		Available: p.Available,
+		CreatedAt: p.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: p.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
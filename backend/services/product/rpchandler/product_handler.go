@@ -0,0 +1,100 @@
+package rpchandler
+
+import (
+	"context"
+	"fmt"
+
+	connect "connectrpc.com/connect"
+
+	productpb "Acho-mj/2025_Golang_MSA/backend/gen/product"
+	productconnect "Acho-mj/2025_Golang_MSA/backend/gen/product/productconnect"
+	"Acho-mj/2025_Golang_MSA/backend/internal/rpcerr"
+	"Acho-mj/2025_Golang_MSA/backend/services/product/store"
+)
+
+type ProductHandler struct {
+	service *store.ProductService
+}
+
+func NewProductHandler(service *store.ProductService) *ProductHandler {
+	return &ProductHandler{service: service}
+}
+
+func (h *ProductHandler) CreateProduct(ctx context.Context, req *connect.Request[productpb.CreateProductRequest]) (*connect.Response[productpb.CreateProductResponse], error) {
+	name := req.Msg.GetName()
+	if name == "" {
+		return nil, rpcerr.InvalidField("name", "name은 필수입니다")
+	}
+
+	product, err := h.service.CreateProduct(ctx, name, req.Msg.GetPrice(), req.Msg.GetCurrency(), req.Msg.GetAvailable())
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	resp := connect.NewResponse(&productpb.CreateProductResponse{
+		Product: product.ToProto(),
+	})
+
+	return resp, nil
+}
+
+func (h *ProductHandler) GetProduct(ctx context.Context, req *connect.Request[productpb.GetProductRequest]) (*connect.Response[productpb.GetProductResponse], error) {
+	productID := req.Msg.GetProductId()
+	if productID == "" {
+		return nil, rpcerr.InvalidField("product_id", "product_id는 필수입니다")
+	}
+
+	product, err := h.service.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	resp := connect.NewResponse(&productpb.GetProductResponse{
+		Product: product.ToProto(),
+	})
+
+	return resp, nil
+}
+
+func (h *ProductHandler) UpdateProduct(ctx context.Context, req *connect.Request[productpb.UpdateProductRequest]) (*connect.Response[productpb.UpdateProductResponse], error) {
+	productID := req.Msg.GetProductId()
+	if productID == "" {
+		return nil, rpcerr.InvalidField("product_id", "product_id는 필수입니다")
+	}
+
+	paths := req.Msg.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return nil, rpcerr.InvalidField("update_mask", "update_mask는 필수입니다")
+	}
+
+	// update_mask에 지정된 필드만 수정 대상으로 넘김
+	var update store.ProductUpdate
+	for _, path := range paths {
+		switch path {
+		case "name":
+			v := req.Msg.GetName()
+			update.Name = &v
+		case "price":
+			v := req.Msg.GetPrice()
+			update.Price = &v
+		case "available":
+			v := req.Msg.GetAvailable()
+			update.Available = &v
+		default:
+			return nil, rpcerr.InvalidField("update_mask", fmt.Sprintf("수정할 수 없는 필드입니다: %s", path))
+		}
+	}
+
+	product, err := h.service.UpdateProduct(ctx, productID, update)
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	resp := connect.NewResponse(&productpb.UpdateProductResponse{
+		Product: product.ToProto(),
+	})
+
+	return resp, nil
+}
+
+var _ productconnect.ProductServiceHandler = (*ProductHandler)(nil)
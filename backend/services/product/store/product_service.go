@@ -0,0 +1,144 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/apperr"
+	"Acho-mj/2025_Golang_MSA/backend/internal/ids"
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+	"Acho-mj/2025_Golang_MSA/backend/services/product/models"
+)
+
+var (
+	ErrInvalidInput    = apperr.New(apperr.ErrInvalidInput, "잘못된 입력입니다")
+	ErrProductNotFound = apperr.New(apperr.ErrNotFound, "상품을 찾을 수 없습니다")
+)
+
+type ProductService struct {
+	storage storage.ProductRepository
+}
+
+// ProductUpdate: nil이 아닌 필드만 수정
+type ProductUpdate struct {
+	Name      *string
+	Price     *int64
+	Available *bool
+}
+
+func NewProductService(storage storage.ProductRepository) *ProductService {
+	return &ProductService{storage: storage}
+}
+
+func (s *ProductService) CreateProduct(ctx context.Context, name string, price int64, currency string, available bool) (*models.Product, error) {
+	if name == "" {
+		return nil, invalidInput("name", "name은 필수입니다")
+	}
+	if price < 0 {
+		return nil, invalidInput("price", "price는 0 이상이어야 합니다")
+	}
+	if !isCurrencyCode(currency) {
+		return nil, invalidInput("currency", fmt.Sprintf("ISO-4217 통화 코드가 아닙니다: %q", currency))
+	}
+
+	productID, err := ids.NewProductID()
+	if err != nil {
+		return nil, fmt.Errorf("상품 ID 생성 실패: %w", err)
+	}
+
+	now := time.Now().UTC()
+	record := &storage.ProductRecord{
+		ProductID: productID,
+		Name:      name,
+		Price:     price,
+		Currency:  currency,
+		Available: available,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.storage.CreateProduct(ctx, record); err != nil {
+		return nil, err
+	}
+
+	return productFromRecord(record), nil
+}
+
+func (s *ProductService) GetProduct(ctx context.Context, productID string) (*models.Product, error) {
+	if productID == "" {
+		return nil, invalidInput("product_id", "productID는 필수입니다")
+	}
+
+	record, err := s.storage.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, productLookupError(err)
+	}
+
+	return productFromRecord(record), nil
+}
+
+func (s *ProductService) UpdateProduct(ctx context.Context, productID string, update ProductUpdate) (*models.Product, error) {
+	if productID == "" {
+		return nil, invalidInput("product_id", "productID는 필수입니다")
+	}
+	if update.Name == nil && update.Price == nil && update.Available == nil {
+		return nil, invalidInput("update_mask", "수정할 필드가 없습니다")
+	}
+	if update.Name != nil && *update.Name == "" {
+		return nil, invalidInput("name", "name은 비어 있을 수 없습니다")
+	}
+	if update.Price != nil && *update.Price < 0 {
+		return nil, invalidInput("price", "price는 0 이상이어야 합니다")
+	}
+
+	record, err := s.storage.UpdateProduct(ctx, productID, storage.ProductUpdate{
+		Name:      update.Name,
+		Price:     update.Price,
+		Available: update.Available,
+	})
+	if err != nil {
+		return nil, productLookupError(err)
+	}
+
+	return productFromRecord(record), nil
+}
+
+// productFromRecord: DB 레코드(ProductRecord) -> 도메인 모델(Product)로 변환
+func productFromRecord(record *storage.ProductRecord) *models.Product {
+	return &models.Product{
+		ProductID: record.ProductID,
+		Name:      record.Name,
+		Price:     record.Price,
+		Currency:  record.Currency,
+		Available: record.Available,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+	}
+}
+
+// productLookupError: storage의 not found를 서비스 에러로 변환
+func productLookupError(err error) error {
+	if errors.Is(err, storage.ErrProductNotFound) {
+		return apperr.WithField("product_id", ErrProductNotFound)
+	}
+	return err
+}
+
+// isCurrencyCode: ISO-4217 형식(대문자 3자리)인지 확인
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		if code[i] < 'A' || code[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// invalidInput: 원인이 된 요청 필드를 함께 기록한 입력 에러
+func invalidInput(field, msg string) error {
+	return apperr.WithField(field, fmt.Errorf("%w: %s", ErrInvalidInput, msg))
+}
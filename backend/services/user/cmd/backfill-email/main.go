@@ -0,0 +1,76 @@
+// backfill-email: 이메일 가드(EMAIL#)와 email_normalized가 생기기 전에 만든 사용자에게 둘을 채우는 일회성 작업
+// user 서비스 이미지에 함께 들어 있으므로 같은 환경 변수로 실행함
+//
+//	kubectl exec deploy/user-service -- /app/backfill-email
+//
+// 끝까지 실행하고 중복 이메일이 없으면 USER_EMAIL_LEGACY_CHECK=false로 배포해 이메일 GSI 확인을 끔
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"Acho-mj/2025_Golang_MSA/backend/internal/config"
+	"Acho-mj/2025_Golang_MSA/backend/internal/logging"
+	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+)
+
+// 한 번에 Scan하는 사용자 수
+const pageSize = 100
+
+func main() {
+	ctx := context.Background()
+
+	cfg, err := config.LoadConfig(config.TableUser, config.TableOutbox)
+	if err != nil {
+		logging.Fatal("config load 실패", "error", err)
+	}
+	logging.Setup("user-backfill-email", cfg.LogLevel)
+	if cfg.StorageBackend != config.StorageBackendDynamoDB {
+		logging.Fatal("STORAGE_BACKEND=dynamodb에서만 실행할 수 있습니다", "storage_backend", cfg.StorageBackend)
+	}
+
+	dynamoClient, err := storage.NewDynamoClient(ctx, cfg)
+	if err != nil {
+		logging.Fatal("dynamodb 초기화 실패", "error", err)
+	}
+	userStorage, err := storage.NewUserStorage(dynamoClient, cfg.DynamoUserTable, cfg.DynamoOutboxTable)
+	if err != nil {
+		logging.Fatal("user storage 초기화 실패", "error", err)
+	}
+
+	var (
+		startKey   map[string]types.AttributeValue
+		updated    int
+		skipped    int
+		duplicates []string
+	)
+	for {
+		page, err := userStorage.BackfillEmailGuards(ctx, startKey, pageSize)
+		if err != nil {
+			// 이미 기록한 사용자는 다시 실행해도 같은 값으로 기록되므로 처음부터 다시 실행하면 됨
+			logging.Fatal("이메일 가드 backfill 실패", "updated", updated, "error", err)
+		}
+		updated += page.Updated
+		skipped += page.Skipped
+		duplicates = append(duplicates, page.Duplicates...)
+		slog.Info("이메일 가드 backfill 진행", "updated", updated, "skipped", skipped, "duplicates", len(duplicates))
+
+		if page.NextKey == nil {
+			break
+		}
+		startKey = page.NextKey
+	}
+
+	for _, userID := range duplicates {
+		slog.Warn("다른 사용자와 이메일이 같아 가드를 기록하지 못했습니다", "user_id", userID)
+	}
+	slog.Info("이메일 가드 backfill 완료", "updated", updated, "skipped", skipped, "duplicates", len(duplicates))
+	if len(duplicates) > 0 {
+		// 중복 사용자를 정리하기 전에는 이메일 GSI 확인을 끄면 안 되므로 실패로 종료
+		os.Exit(1)
+	}
+}
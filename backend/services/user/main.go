@@ -2,13 +2,19 @@ package main
 
 import (
 	"context"
-	"log"
-	"net/http"
+	"log/slog"
 
 	userconnect "Acho-mj/2025_Golang_MSA/backend/gen/user/userconnect"
 
+	"Acho-mj/2025_Golang_MSA/backend/internal/adminauth"
 	"Acho-mj/2025_Golang_MSA/backend/internal/config"
+	"Acho-mj/2025_Golang_MSA/backend/internal/idempotency"
+	"Acho-mj/2025_Golang_MSA/backend/internal/logging"
+	"Acho-mj/2025_Golang_MSA/backend/internal/outbox"
+	"Acho-mj/2025_Golang_MSA/backend/internal/pagination"
+	"Acho-mj/2025_Golang_MSA/backend/internal/server"
 	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
+	"Acho-mj/2025_Golang_MSA/backend/internal/tracing"
 	"Acho-mj/2025_Golang_MSA/backend/services/user/rpchandler"
 	"Acho-mj/2025_Golang_MSA/backend/services/user/store"
 )
@@ -18,38 +24,121 @@ func main() {
 	ctx := context.Background()
 
 	// 환경 변수 설정
-	cfg, err := config.LoadConfig()
+	cfg, err := config.LoadConfig(config.TableUser, config.TableIdempotency, config.TableOutbox)
 	if err != nil {
-		log.Fatalf("config load 실패: %v", err)
+		logging.Fatal("config load 실패", "error", err)
 	}
+	// JSON 로그 (LOG_LEVEL 이상만 남김)
+	logging.Setup("user-service", cfg.LogLevel)
 
-	// DynamoDB 연결
-	dynamoClient, err := storage.NewDynamoClient(ctx, cfg)
+	// HTTP 서버 (종료 신호를 받으면 진행 중인 요청과 백그라운드 작업을 마치고 종료)
+	srv, err := server.New("user service", server.OptionsFrom(cfg))
 	if err != nil {
-		log.Fatalf("dynamodb 초기화 실패: %v", err)
+		logging.Fatal("server 초기화 실패", "error", err)
 	}
 
-	userStorage, err := storage.NewUserStorage(dynamoClient, cfg.DynamoUserTable)
+	// OpenTelemetry trace (TRACING_EXPORTER), 종료할 때 남은 span을 내보냄
+	shutdownTracing, err := tracing.Setup(ctx, tracing.ConfigFrom(cfg, "user-service"))
 	if err != nil {
-		log.Fatalf("user storage 초기화 실패: %v", err)
+		logging.Fatal("tracing 초기화 실패", "error", err)
+	}
+	srv.OnShutdown(shutdownTracing)
+
+	// 저장소 선택 (DynamoDB 또는 메모리)
+	var userStorage storage.UserRepository
+	var idempotencyStorage storage.IdempotencyRepository
+	var outboxStorage storage.OutboxRepository
+	switch cfg.StorageBackend {
+	case config.StorageBackendMemory:
+		memoryOutbox := storage.NewMemoryOutboxStorage()
+		userStorage = storage.NewMemoryUserStorage(memoryOutbox)
+		outboxStorage = memoryOutbox
+		idempotencyStorage = storage.NewMemoryIdempotencyStorage()
+	default:
+		// DynamoDB 연결
+		dynamoClient, err := storage.NewDynamoClient(ctx, cfg)
+		if err != nil {
+			logging.Fatal("dynamodb 초기화 실패", "error", err)
+		}
+
+		dynamoUserStorage, err := storage.NewUserStorage(dynamoClient, cfg.DynamoUserTable, cfg.DynamoOutboxTable)
+		if err != nil {
+			logging.Fatal("user storage 초기화 실패", "error", err)
+		}
+		// 이메일 가드가 없는 이전 사용자가 남아 있는 동안 이메일 GSI로도 중복을 확인
+		dynamoUserStorage.SetLegacyEmailCheck(cfg.UserEmailLegacyCheck)
+		userStorage = dynamoUserStorage
+
+		idempotencyStorage, err = storage.NewIdempotencyStorage(dynamoClient, cfg.DynamoIdempotencyTable)
+		if err != nil {
+			logging.Fatal("idempotency storage 초기화 실패", "error", err)
+		}
+
+		outboxStorage, err = storage.NewOutboxStorage(dynamoClient, cfg.DynamoOutboxTable)
+		if err != nil {
+			logging.Fatal("outbox storage 초기화 실패", "error", err)
+		}
+
+		// readiness: 사용하는 테이블을 DescribeTable로 확인
+		for _, table := range []string{cfg.DynamoUserTable, cfg.DynamoIdempotencyTable, cfg.DynamoOutboxTable} {
+			srv.Check("dynamodb:"+table, func(ctx context.Context) error {
+				return storage.PingTable(ctx, dynamoClient, table)
+			})
+		}
+	}
+	slog.Info("저장소 설정", "storage_backend", cfg.StorageBackend)
+
+	// 사용자 목록 page token 서명용 secret
+	pageTokenSecret := []byte(cfg.PageTokenSecret)
+	if len(pageTokenSecret) == 0 {
+		slog.Warn("PAGE_TOKEN_SECRET이 없어 임시 secret을 사용합니다 (파드 간 page token 호환 불가)")
+		pageTokenSecret, err = pagination.RandomSecret()
+		if err != nil {
+			logging.Fatal("page token secret 생성 실패", "error", err)
+		}
+	}
+	pageTokens, err := pagination.NewTokenCodec(pageTokenSecret)
+	if err != nil {
+		logging.Fatal("page token codec 초기화 실패", "error", err)
+	}
+
+	idempotencyGuard, err := idempotency.NewGuard(idempotencyStorage, idempotency.DefaultTTL, idempotency.DefaultLeaseTTL)
+	if err != nil {
+		logging.Fatal("idempotency guard 초기화 실패", "error", err)
 	}
 
 	// 핸들러
-	userService := store.NewUserService(userStorage)
+	userService := store.NewUserService(userStorage, pageTokens, idempotencyGuard)
 	userHandler := rpchandler.NewUserHandler(userService)
 
-	mux := http.NewServeMux()
-	path, handler := userconnect.NewUserServiceHandler(userHandler)
-	mux.Handle(path, handler)
-	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
+	// outbox에 기록된 user 이벤트를 발행
+	publisher, err := outbox.NewPublisher(cfg)
+	if err != nil {
+		logging.Fatal("event publisher 초기화 실패", "error", err)
+	}
+	relay, err := outbox.NewRelay(outboxStorage, outbox.SourceUser, publisher)
+	if err != nil {
+		logging.Fatal("outbox relay 초기화 실패", "error", err)
+	}
+	srv.Go(func(ctx context.Context) {
+		relay.Run(ctx, outbox.DefaultPollInterval)
 	})
+	slog.Info("이벤트 발행 설정", "event_publisher", cfg.EventPublisher)
+
+	// 계정 상태를 바꾸는 운영용 RPC는 ADMIN_API_TOKEN을 가진 호출만 허용
+	if cfg.AdminAPIToken == "" {
+		slog.Warn("ADMIN_API_TOKEN이 없어 운영용 RPC(SuspendUser, ReactivateUser, CloseUser)를 모두 거부합니다")
+	}
+	adminAuth := adminauth.NewInterceptor(cfg.AdminAPIToken,
+		userconnect.UserServiceSuspendUserProcedure,
+		userconnect.UserServiceReactivateUserProcedure,
+		userconnect.UserServiceCloseUserProcedure,
+	)
 
-	addr := ":" + cfg.Port
-	log.Printf("user service listening on %s", addr)
+	path, handler := userconnect.NewUserServiceHandler(userHandler, srv.HandlerOptions(adminAuth)...)
+	srv.Handle(path, handler)
 
-	if err := http.ListenAndServe(addr, mux); err != nil {
-		log.Fatalf("서버 종료: %v", err)
+	if err := srv.Run(); err != nil {
+		logging.Fatal("서버 종료", "error", err)
 	}
 }
@@ -7,10 +7,13 @@ import (
 )
 
 type User struct {
-	UserID    string    `dynamodbav:"user_id"`
-	Email     string    `dynamodbav:"email"`
-	Name      string    `dynamodbav:"name"`
-	CreatedAt time.Time `dynamodbav:"created_at"`
+	UserID    string     `dynamodbav:"user_id"`
+	Email     string     `dynamodbav:"email"`
+	Name      string     `dynamodbav:"name"`
+	CreatedAt time.Time  `dynamodbav:"created_at"`
+	Status    UserStatus `dynamodbav:"status"`
+	// 정지/해지 사유
+	StatusReason string `dynamodbav:"status_reason,omitempty"`
 }
 
 // ToProto: DB 모델(User) -> Proto 모델(*userpb.User)로 변환
@@ -19,10 +22,12 @@ func (u *User) ToProto() *userpb.User {
 		return nil
 	}
 	return &userpb.User{
-		UserId:    u.UserID,
-		Email:     u.Email,
-		Name:      u.Name,
-		CreatedAt: u.CreatedAt.UTC().Format(time.RFC3339),
+		UserId:       u.UserID,
+		Email:        u.Email,
+		Name:         u.Name,
+		CreatedAt:    u.CreatedAt.UTC().Format(time.RFC3339),
+		Status:       u.Status.ToProto(),
+		StatusReason: u.StatusReason,
 	}
 }
 
@@ -37,10 +42,14 @@ func UserFromProto(p *userpb.User) *User {
 		createdAt = time.Time{}
 	}
 
+	status, _ := UserStatusFromProto(p.Status)
+
 	return &User{
-		UserID:    p.UserId,
-		Email:     p.Email,
-		Name:      p.Name,
-		CreatedAt: createdAt,
+		UserID:       p.UserId,
+		Email:        p.Email,
+		Name:         p.Name,
+		CreatedAt:    createdAt,
+		Status:       status,
+		StatusReason: p.StatusReason,
 	}
 }
@@ -0,0 +1,64 @@
+package models
+
+import (
+	userpb "Acho-mj/2025_Golang_MSA/backend/gen/user"
+)
+
+// UserStatus: DB에 저장되는 계정 상태 값
+type UserStatus string
+
+const (
+	UserStatusActive    UserStatus = "active"
+	UserStatusSuspended UserStatus = "suspended"
+	UserStatusClosed    UserStatus = "closed"
+)
+
+// 상태별로 다음에 올 수 있는 상태
+var userTransitions = map[UserStatus][]UserStatus{
+	UserStatusActive:    {UserStatusSuspended, UserStatusClosed},
+	UserStatusSuspended: {UserStatusActive, UserStatusClosed},
+	UserStatusClosed:    {},
+}
+
+var userStatusToProto = map[UserStatus]userpb.UserStatus{
+	UserStatusActive:    userpb.UserStatus_USER_STATUS_ACTIVE,
+	UserStatusSuspended: userpb.UserStatus_USER_STATUS_SUSPENDED,
+	UserStatusClosed:    userpb.UserStatus_USER_STATUS_CLOSED,
+}
+
+// UserStatusFromDB: 저장된 상태 값 (상태가 추가되기 전에 만든 사용자는 비어 있으므로 active로 취급)
+func UserStatusFromDB(status string) UserStatus {
+	if status == "" {
+		return UserStatusActive
+	}
+	return UserStatus(status)
+}
+
+func (s UserStatus) IsValid() bool {
+	_, ok := userTransitions[s]
+	return ok
+}
+
+// CanTransitionTo: 현재 상태에서 next로 변경할 수 있는지 여부
+func (s UserStatus) CanTransitionTo(next UserStatus) bool {
+	for _, allowed := range userTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (s UserStatus) ToProto() userpb.UserStatus {
+	return userStatusToProto[s]
+}
+
+// UserStatusFromProto: UNSPECIFIED나 알 수 없는 값이면 false를 반환
+func UserStatusFromProto(p userpb.UserStatus) (UserStatus, bool) {
+	for status, pb := range userStatusToProto {
+		if pb == p {
+			return status, true
+		}
+	}
+	return "", false
+}
@@ -2,13 +2,14 @@ package rpchandler
 
 import (
 	"context"
-	"errors"
-	"fmt"
 
 	connect "connectrpc.com/connect"
 
 	userpb "Acho-mj/2025_Golang_MSA/backend/gen/user"
 	userconnect "Acho-mj/2025_Golang_MSA/backend/gen/user/userconnect"
+	"Acho-mj/2025_Golang_MSA/backend/internal/apperr"
+	"Acho-mj/2025_Golang_MSA/backend/internal/idempotency"
+	"Acho-mj/2025_Golang_MSA/backend/internal/rpcerr"
 	"Acho-mj/2025_Golang_MSA/backend/services/user/store"
 )
 
@@ -21,16 +22,14 @@ func NewUserHandler(service *store.UserService) *UserHandler {
 }
 
 func (h *UserHandler) CreateUser(ctx context.Context, req *connect.Request[userpb.CreateUserRequest]) (*connect.Response[userpb.CreateUserResponse], error) {
-	email := req.Msg.GetEmail()
-	name := req.Msg.GetName()
-
-	if email == "" || name == "" {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("email과 name은 필수입니다"))
+	idempotencyKey, err := idempotency.KeyFrom(req.Header(), req.Msg.GetIdempotencyKey())
+	if err != nil {
+		return nil, rpcerr.ToConnect(apperr.WithField("idempotency_key", err))
 	}
 
-	user, err := h.service.CreateUser(ctx, email, name)
+	user, err := h.service.CreateUser(ctx, req.Msg.GetEmail(), req.Msg.GetName(), idempotencyKey)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, rpcerr.ToConnect(err)
 	}
 
 	resp := connect.NewResponse(&userpb.CreateUserResponse{
@@ -41,24 +40,121 @@ func (h *UserHandler) CreateUser(ctx context.Context, req *connect.Request[userp
 }
 
 func (h *UserHandler) GetUser(ctx context.Context, req *connect.Request[userpb.GetUserRequest]) (*connect.Response[userpb.GetUserResponse], error) {
-	userID := req.Msg.GetUserId()
-	if userID == "" {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("user_id는 필수입니다"))
+	user, err := h.service.GetUser(ctx, req.Msg.GetUserId())
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	resp := connect.NewResponse(&userpb.GetUserResponse{
+		User: user.ToProto(),
+	})
+
+	return resp, nil
+}
+
+func (h *UserHandler) GetUserByEmail(ctx context.Context, req *connect.Request[userpb.GetUserByEmailRequest]) (*connect.Response[userpb.GetUserByEmailResponse], error) {
+	user, err := h.service.GetUserByEmail(ctx, req.Msg.GetEmail())
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
 	}
 
-	user, err := h.service.GetUser(ctx, userID)
+	resp := connect.NewResponse(&userpb.GetUserByEmailResponse{
+		User: user.ToProto(),
+	})
+
+	return resp, nil
+}
+
+func (h *UserHandler) ListUsers(ctx context.Context, req *connect.Request[userpb.ListUsersRequest]) (*connect.Response[userpb.ListUsersResponse], error) {
+	users, nextPageToken, err := h.service.ListUsers(ctx, store.ListUsersParams{
+		NamePrefix:  req.Msg.GetNamePrefix(),
+		EmailPrefix: req.Msg.GetEmailPrefix(),
+		PageSize:    req.Msg.GetPageSize(),
+		PageToken:   req.Msg.GetPageToken(),
+	})
 	if err != nil {
-		if errors.Is(err, store.ErrUserNotFound) {
-			return nil, connect.NewError(connect.CodeNotFound, err)
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	pbUsers := make([]*userpb.User, 0, len(users))
+	for _, user := range users {
+		pbUsers = append(pbUsers, user.ToProto())
+	}
+
+	resp := connect.NewResponse(&userpb.ListUsersResponse{
+		Users:         pbUsers,
+		NextPageToken: nextPageToken,
+	})
+
+	return resp, nil
+}
+
+func (h *UserHandler) UpdateUser(ctx context.Context, req *connect.Request[userpb.UpdateUserRequest]) (*connect.Response[userpb.UpdateUserResponse], error) {
+	// update_mask에 지정된 필드만 수정 대상으로 넘김
+	// (update_mask에는 email, name만 올 수 있음을 proto 규칙으로 검증함)
+	var email, name *string
+	for _, path := range req.Msg.GetUpdateMask().GetPaths() {
+		switch path {
+		case "email":
+			v := req.Msg.GetEmail()
+			email = &v
+		case "name":
+			v := req.Msg.GetName()
+			name = &v
 		}
-		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	resp := connect.NewResponse(&userpb.GetUserResponse{
+	user, err := h.service.UpdateUser(ctx, req.Msg.GetUserId(), email, name)
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	resp := connect.NewResponse(&userpb.UpdateUserResponse{
 		User: user.ToProto(),
 	})
 
 	return resp, nil
 }
 
+func (h *UserHandler) DeleteUser(ctx context.Context, req *connect.Request[userpb.DeleteUserRequest]) (*connect.Response[userpb.DeleteUserResponse], error) {
+	if err := h.service.DeleteUser(ctx, req.Msg.GetUserId()); err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	return connect.NewResponse(&userpb.DeleteUserResponse{}), nil
+}
+
+func (h *UserHandler) SuspendUser(ctx context.Context, req *connect.Request[userpb.SuspendUserRequest]) (*connect.Response[userpb.SuspendUserResponse], error) {
+	user, err := h.service.SuspendUser(ctx, req.Msg.GetUserId(), req.Msg.GetReason())
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	return connect.NewResponse(&userpb.SuspendUserResponse{
+		User: user.ToProto(),
+	}), nil
+}
+
+func (h *UserHandler) ReactivateUser(ctx context.Context, req *connect.Request[userpb.ReactivateUserRequest]) (*connect.Response[userpb.ReactivateUserResponse], error) {
+	user, err := h.service.ReactivateUser(ctx, req.Msg.GetUserId())
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	return connect.NewResponse(&userpb.ReactivateUserResponse{
+		User: user.ToProto(),
+	}), nil
+}
+
+func (h *UserHandler) CloseUser(ctx context.Context, req *connect.Request[userpb.CloseUserRequest]) (*connect.Response[userpb.CloseUserResponse], error) {
+	user, err := h.service.CloseUser(ctx, req.Msg.GetUserId(), req.Msg.GetReason())
+	if err != nil {
+		return nil, rpcerr.ToConnect(err)
+	}
+
+	return connect.NewResponse(&userpb.CloseUserResponse{
+		User: user.ToProto(),
+	}), nil
+}
+
 var _ userconnect.UserServiceHandler = (*UserHandler)(nil)
@@ -4,57 +4,257 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"google.golang.org/protobuf/proto"
+
+	eventspb "Acho-mj/2025_Golang_MSA/backend/gen/events"
+	userpb "Acho-mj/2025_Golang_MSA/backend/gen/user"
+	"Acho-mj/2025_Golang_MSA/backend/internal/apperr"
+	"Acho-mj/2025_Golang_MSA/backend/internal/idempotency"
+	"Acho-mj/2025_Golang_MSA/backend/internal/ids"
+	"Acho-mj/2025_Golang_MSA/backend/internal/outbox"
+	"Acho-mj/2025_Golang_MSA/backend/internal/pagination"
 	"Acho-mj/2025_Golang_MSA/backend/internal/storage"
 	"Acho-mj/2025_Golang_MSA/backend/services/user/models"
 )
 
 var (
-	ErrInvalidInput = errors.New("잘못된 입력입니다")
-	ErrUserNotFound = errors.New("사용자를 찾을 수 없습니다")
+	ErrInvalidInput       = apperr.New(apperr.ErrInvalidInput, "잘못된 입력입니다")
+	ErrUserNotFound       = apperr.New(apperr.ErrNotFound, "사용자를 찾을 수 없습니다")
+	ErrEmailAlreadyExists = apperr.New(apperr.ErrAlreadyExists, "이미 사용 중인 이메일입니다")
+	// 현재 계정 상태에서 허용되지 않는 상태 변경
+	ErrInvalidTransition = apperr.New(apperr.ErrFailedPrecondition, "허용되지 않는 계정 상태 변경입니다")
+	ErrUserConflict      = apperr.New(apperr.ErrConflict, "사용자가 동시에 변경되었습니다")
+	// 같은 idempotency key가 내용이 다른 요청에 사용된 경우
+	ErrIdempotencyKeyReused = apperr.New(apperr.ErrInvalidInput, "idempotency key가 다른 요청에 이미 사용되었습니다")
+	// 같은 idempotency key의 이전 요청이 아직 처리 중인 경우
+	ErrRequestInProgress = apperr.New(apperr.ErrConflict, "같은 idempotency key의 요청이 처리 중입니다. 잠시 후 같은 key로 다시 시도하세요")
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
 )
 
 type UserService struct {
-	storage *storage.UserStorage
+	storage     storage.UserRepository
+	pageTokens  *pagination.TokenCodec
+	idempotency *idempotency.Guard
 }
 
-func NewUserService(storage *storage.UserStorage) *UserService {
+// ListUsersParams: 사용자 목록 조회 조건
+type ListUsersParams struct {
+	NamePrefix  string
+	EmailPrefix string
+	PageSize    int32
+	PageToken   string
+}
+
+func NewUserService(storage storage.UserRepository, pageTokens *pagination.TokenCodec, idempotency *idempotency.Guard) *UserService {
 	return &UserService{
-		storage: storage,
+		storage:     storage,
+		pageTokens:  pageTokens,
+		idempotency: idempotency,
 	}
 }
 
-func (s *UserService) CreateUser(ctx context.Context, email, name string) (*models.User, error) {
-	if email == "" || name == "" {
-		return nil, fmt.Errorf("%w: email과 name은 필수입니다", ErrInvalidInput)
+// CreateUser: idempotencyKey가 있으면 같은 key의 재시도에 처음 생성한 사용자를 돌려줌
+func (s *UserService) CreateUser(ctx context.Context, email, name, idempotencyKey string) (*models.User, error) {
+	var created *models.User
+	requestHash := idempotency.HashRequest(email, name)
+	result, err := s.idempotency.Do(ctx, "CreateUser", idempotencyKey, requestHash, func(ctx context.Context, _ idempotency.Hold) (idempotency.Result, error) {
+		userID, err := ids.NewUserID()
+		if err != nil {
+			return idempotency.Result{}, fmt.Errorf("사용자 ID 생성 실패: %w", err)
+		}
+
+		item := &storage.UserItem{
+			UserID:    userID,
+			Email:     email,
+			Name:      name,
+			CreatedAt: time.Now().UTC(),
+			Status:    string(models.UserStatusActive),
+		}
+
+		user := userFromItem(item)
+		// 사용자와 같은 트랜잭션으로 UserCreated 이벤트를 outbox에 기록
+		event, err := outbox.NewRecord(outbox.SourceUser, userID, &eventspb.Event{
+			Payload: &eventspb.Event_UserCreated{
+				UserCreated: &eventspb.UserCreated{User: user.ToProto()},
+			},
+		})
+		if err != nil {
+			return idempotency.Result{}, err
+		}
+		// 같은 key의 재시도에 돌려줄 응답
+		response, err := proto.Marshal(user.ToProto())
+		if err != nil {
+			return idempotency.Result{}, fmt.Errorf("사용자 응답 marshal 실패: %w", err)
+		}
+
+		if err := s.storage.CreateUser(ctx, item, event); err != nil {
+			if errors.Is(err, storage.ErrEmailAlreadyExists) {
+				return idempotency.Result{}, apperr.WithField("email", ErrEmailAlreadyExists)
+			}
+			return idempotency.Result{}, err
+		}
+
+		created = user
+		return idempotency.Result{ResourceID: item.UserID, Response: response}, nil
+	})
+	if err != nil {
+		return nil, idempotencyError(err)
 	}
+	if result.Replayed {
+		return s.replayedUser(ctx, result)
+	}
+
+	return created, nil
+}
 
-	item := &storage.UserItem{
-		UserID:    generateUserID(),
-		Email:     email,
-		Name:      name,
-		CreatedAt: time.Now().UTC(),
+// replayedUser: 같은 key의 재시도에 처음 응답한 사용자를 돌려줌
+// 응답을 기록하기 전에 완료된 기록이면 현재 사용자를 조회함
+func (s *UserService) replayedUser(ctx context.Context, result idempotency.Result) (*models.User, error) {
+	if len(result.Response) == 0 {
+		return s.GetUser(ctx, result.ResourceID)
 	}
+	var user userpb.User
+	if err := proto.Unmarshal(result.Response, &user); err != nil {
+		return nil, fmt.Errorf("idempotency 응답 언마샬 실패: %w", err)
+	}
+	return models.UserFromProto(&user), nil
+}
 
-	if err := s.storage.CreateUser(ctx, item); err != nil {
+func (s *UserService) GetUser(ctx context.Context, userID string) (*models.User, error) {
+	item, err := s.storage.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
 		return nil, err
 	}
 
-	return &models.User{
-		UserID:    item.UserID,
-		Email:     item.Email,
-		Name:      item.Name,
-		CreatedAt: item.CreatedAt,
-	}, nil
+	return userFromItem(item), nil
 }
 
-func (s *UserService) GetUser(ctx context.Context, userID string) (*models.User, error) {
-	if userID == "" {
-		return nil, fmt.Errorf("%w: userID는 필수입니다", ErrInvalidInput)
+func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	item, err := s.storage.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
 	}
 
-	item, err := s.storage.GetUserByID(ctx, userID)
+	return userFromItem(item), nil
+}
+
+// ListUsers: 사용자 목록을 한 페이지씩 조회하고 다음 페이지 token을 함께 반환
+func (s *UserService) ListUsers(ctx context.Context, params ListUsersParams) ([]*models.User, string, error) {
+	pageSize := params.PageSize
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	// token은 발급 당시의 필터에서만 유효
+	scope := fmt.Sprintf("users|%s|%s", params.NamePrefix, strings.ToLower(params.EmailPrefix))
+	startKey, err := s.pageTokens.Decode(scope, params.PageToken)
+	if err != nil {
+		return nil, "", apperr.WithField("page_token", fmt.Errorf("%w: %v", ErrInvalidInput, err))
+	}
+
+	page, err := s.storage.ListUsers(ctx, storage.UserScan{
+		NamePrefix:  params.NamePrefix,
+		EmailPrefix: params.EmailPrefix,
+		Limit:       pageSize,
+		StartKey:    startKey,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextToken, err := s.pageTokens.Encode(scope, page.NextKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	users := make([]*models.User, 0, len(page.Users))
+	for i := range page.Users {
+		users = append(users, userFromItem(&page.Users[i]))
+	}
+
+	return users, nextToken, nil
+}
+
+// UpdateUser: nil이 아닌 필드만 수정
+func (s *UserService) UpdateUser(ctx context.Context, userID string, email, name *string) (*models.User, error) {
+	if email == nil && name == nil {
+		return nil, invalidInput("update_mask", "수정할 필드가 없습니다")
+	}
+
+	item, err := s.storage.UpdateUser(ctx, userID, email, name)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrUserNotFound):
+			return nil, ErrUserNotFound
+		case errors.Is(err, storage.ErrEmailAlreadyExists):
+			return nil, apperr.WithField("email", ErrEmailAlreadyExists)
+		case errors.Is(err, storage.ErrUserConflict):
+			return nil, ErrUserConflict
+		}
+		return nil, err
+	}
+
+	return userFromItem(item), nil
+}
+
+// DeleteUser: 사용자를 삭제하고 같은 트랜잭션으로 UserDeleted 이벤트를 기록
+func (s *UserService) DeleteUser(ctx context.Context, userID string) error {
+	event, err := outbox.NewRecord(outbox.SourceUser, userID, &eventspb.Event{
+		Payload: &eventspb.Event_UserDeleted{
+			UserDeleted: &eventspb.UserDeleted{UserId: userID},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.storage.DeleteUser(ctx, userID, event); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrUserNotFound):
+			return ErrUserNotFound
+		case errors.Is(err, storage.ErrUserConflict):
+			return ErrUserConflict
+		}
+		return err
+	}
+
+	return nil
+}
+
+// SuspendUser: 계정 정지 (정지된 사용자는 새 주문을 만들 수 없음)
+func (s *UserService) SuspendUser(ctx context.Context, userID, reason string) (*models.User, error) {
+	return s.transitionUser(ctx, userID, models.UserStatusSuspended, reason)
+}
+
+// ReactivateUser: 정지 해제
+func (s *UserService) ReactivateUser(ctx context.Context, userID string) (*models.User, error) {
+	return s.transitionUser(ctx, userID, models.UserStatusActive, "")
+}
+
+// CloseUser: 계정 해지 (되돌릴 수 없음)
+func (s *UserService) CloseUser(ctx context.Context, userID, reason string) (*models.User, error) {
+	return s.transitionUser(ctx, userID, models.UserStatusClosed, reason)
+}
+
+// transitionUser: 현재 상태에서 허용된 경우에만 계정 상태를 변경하고 UserStatusChanged 이벤트를 함께 기록
+func (s *UserService) transitionUser(ctx context.Context, userID string, next models.UserStatus, reason string) (*models.User, error) {
+	current, err := s.storage.GetUserByID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserNotFound) {
 			return nil, ErrUserNotFound
@@ -62,14 +262,66 @@ func (s *UserService) GetUser(ctx context.Context, userID string) (*models.User,
 		return nil, err
 	}
 
+	status := models.UserStatusFromDB(current.Status)
+	if !status.CanTransitionTo(next) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, status, next)
+	}
+
+	event, err := outbox.NewRecord(outbox.SourceUser, userID, &eventspb.Event{
+		Payload: &eventspb.Event_UserStatusChanged{
+			UserStatusChanged: &eventspb.UserStatusChanged{
+				UserId:     userID,
+				FromStatus: status.ToProto(),
+				ToStatus:   next.ToProto(),
+				Reason:     reason,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 조회 이후 다른 요청이 상태를 바꿨다면 조건부 업데이트가 실패함
+	item, err := s.storage.UpdateUserStatus(ctx, userID, current.Status, string(next), reason, event)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrUserNotFound):
+			return nil, ErrUserNotFound
+		case errors.Is(err, storage.ErrUserConflict):
+			return nil, ErrUserConflict
+		}
+		return nil, err
+	}
+
+	return userFromItem(item), nil
+}
+
+// userFromItem: DB 아이템(UserItem) -> 도메인 모델(User)로 변환
+func userFromItem(item *storage.UserItem) *models.User {
 	return &models.User{
-		UserID:    item.UserID,
-		Email:     item.Email,
-		Name:      item.Name,
-		CreatedAt: item.CreatedAt,
-	}, nil
+		UserID:       item.UserID,
+		Email:        item.Email,
+		Name:         item.Name,
+		CreatedAt:    item.CreatedAt,
+		Status:       models.UserStatusFromDB(item.Status),
+		StatusReason: item.StatusReason,
+	}
+}
+
+// idempotencyError: idempotency 패키지 에러를 서비스 에러로 변환
+func idempotencyError(err error) error {
+	switch {
+	case errors.Is(err, idempotency.ErrInvalidKey):
+		return apperr.WithField("idempotency_key", fmt.Errorf("%w: %v", ErrInvalidInput, err))
+	case errors.Is(err, idempotency.ErrKeyReused):
+		return apperr.WithField("idempotency_key", ErrIdempotencyKeyReused)
+	case errors.Is(err, idempotency.ErrInProgress):
+		return apperr.WithRetryDelay(idempotency.InProgressRetryDelay, ErrRequestInProgress)
+	}
+	return err
 }
 
-func generateUserID() string {
-	return fmt.Sprintf("user-%d", time.Now().UnixNano())
+// invalidInput: 원인이 된 요청 필드를 함께 기록한 입력 에러
+func invalidInput(field, msg string) error {
+	return apperr.WithField(field, fmt.Errorf("%w: %s", ErrInvalidInput, msg))
 }
@@ -0,0 +1,42 @@
+{{- define "inventory-service.name" -}}
+{{- default .Chart.Name .Values.nameOverride | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
+
+{{- define "inventory-service.fullname" -}}
+{{- $name := default .Chart.Name .Values.nameOverride -}}
+{{- if .Values.fullnameOverride -}}
+{{- .Values.fullnameOverride | trunc 63 | trimSuffix "-" -}}
+{{- else -}}
+{{- printf "%s-%s" .Release.Name $name | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
+{{- end -}}
+
+{{- define "inventory-service.labels" -}}
+app.kubernetes.io/name: {{ include "inventory-service.name" . }}
+helm.sh/chart: {{ .Chart.Name }}-{{ .Chart.Version }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+app.kubernetes.io/version: {{ .Chart.AppVersion }}
+app.kubernetes.io/managed-by: {{ .Release.Service }}
+{{- end -}}
+
+{{- define "inventory-service.selectorLabels" -}}
+app.kubernetes.io/name: {{ include "inventory-service.name" . }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end -}}
+
+{{- define "inventory-service.serviceAccountName" -}}
+{{- if .Values.serviceAccount.create -}}
+  {{- if .Values.serviceAccount.name -}}
+    {{ .Values.serviceAccount.name }}
+  {{- else -}}
+    {{ include "inventory-service.fullname" . }}
+  {{- end -}}
+{{- else -}}
+  {{- if .Values.serviceAccount.name -}}
+    {{ .Values.serviceAccount.name }}
+  {{- else -}}
+    default
+  {{- end -}}
+{{- end -}}
+{{- end -}}
+
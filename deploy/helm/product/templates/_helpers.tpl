@@ -0,0 +1,42 @@
+{{- define "product-service.name" -}}
+{{- default .Chart.Name .Values.nameOverride | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
+
+{{- define "product-service.fullname" -}}
+{{- $name := default .Chart.Name .Values.nameOverride -}}
+{{- if .Values.fullnameOverride -}}
+{{- .Values.fullnameOverride | trunc 63 | trimSuffix "-" -}}
+{{- else -}}
+{{- printf "%s-%s" .Release.Name $name | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
+{{- end -}}
+
+{{- define "product-service.labels" -}}
+app.kubernetes.io/name: {{ include "product-service.name" . }}
+helm.sh/chart: {{ .Chart.Name }}-{{ .Chart.Version }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+app.kubernetes.io/version: {{ .Chart.AppVersion }}
+app.kubernetes.io/managed-by: {{ .Release.Service }}
+{{- end -}}
+
+{{- define "product-service.selectorLabels" -}}
+app.kubernetes.io/name: {{ include "product-service.name" . }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end -}}
+
+{{- define "product-service.serviceAccountName" -}}
+{{- if .Values.serviceAccount.create -}}
+  {{- if .Values.serviceAccount.name -}}
+    {{ .Values.serviceAccount.name }}
+  {{- else -}}
+    {{ include "product-service.fullname" . }}
+  {{- end -}}
+{{- else -}}
+  {{- if .Values.serviceAccount.name -}}
+    {{ .Values.serviceAccount.name }}
+  {{- else -}}
+    default
+  {{- end -}}
+{{- end -}}
+{{- end -}}
+